@@ -0,0 +1,267 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// A Mode determines whether a Recorder replays interactions from its cassette
+// directory, records them from the network, or both.
+type Mode int
+
+const (
+	// ModeReplay replays every request from the cassette directory and fails
+	// requests for which no interaction was recorded with ErrUnmatchedRequest, it
+	// never makes a network call.
+	ModeReplay Mode = iota
+
+	// ModeRecord makes every request over the network and records the response to
+	// the cassette directory, replacing any interaction previously recorded for it.
+	ModeRecord
+
+	// ModeReplayOrRecord replays requests for which an interaction was recorded and
+	// records the remaining ones from the network.
+	ModeReplayOrRecord
+)
+
+var (
+	// ErrInvalidOptions is reported when a Recorder is created with invalid Options,
+	// the error description will carry further details.
+	ErrInvalidOptions = errors.New("invalid_cassette_options")
+
+	// ErrUnmatchedRequest is reported by a Recorder in ModeReplay for a request for
+	// which no interaction was recorded, the error description will carry the method
+	// and URL of the request.
+	ErrUnmatchedRequest = errors.New("unmatched_cassette_request")
+)
+
+// An Interaction is a request made through a Recorder along with its response, it
+// is saved to its own file in the cassette directory.
+type Interaction struct {
+	// The method of the request.
+	Method string
+
+	// The path and query of the request URL, the query parameters are sorted by key
+	// so that the interaction matches regardless of the order of the parameters.
+	URL string
+
+	// The status code, Content-Type header and body of the response.
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// A Scrubber rewrites a recorded Interaction before it is saved, for removing
+// fields which vary between otherwise identical responses.
+type Scrubber func(interaction *Interaction) error
+
+// ScrubMeta is a Scrubber which zeroes the volatile "server_time" and
+// "execution_time" fields of the "@meta" object of YTS API responses, so that the
+// recording of an unchanged response is byte for byte identical.
+func ScrubMeta(interaction *Interaction) error {
+	if !strings.Contains(interaction.ContentType, "json") {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(interaction.Body))
+	decoder.UseNumber()
+	payload := make(map[string]any)
+	if err := decoder.Decode(&payload); err != nil {
+		return nil
+	}
+
+	meta, ok := payload["@meta"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	meta["server_time"] = 0
+	meta["execution_time"] = "0 ms"
+	body, err := marshalJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	interaction.Body = body
+	return nil
+}
+
+// Options configure the behavior of a Recorder.
+type Options struct {
+	// The Mode of the Recorder, ModeReplay being the zero value.
+	Mode Mode
+
+	// The transport used for making requests over the network in ModeRecord and
+	// ModeReplayOrRecord, when nil http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// The Scrubbers applied in order to every recorded Interaction.
+	Scrubbers []Scrubber
+}
+
+// DefaultOptions returns the default *Options used for creating a Recorder, which
+// replay interactions and scrub them with ScrubMeta when recorded.
+func DefaultOptions() *Options {
+	return &Options{
+		Mode:      ModeReplay,
+		Scrubbers: []Scrubber{ScrubMeta},
+	}
+}
+
+func (o *Options) validate() error {
+	if o.Mode < ModeReplay || ModeReplayOrRecord < o.Mode {
+		return fmt.Errorf("mode must be one of ModeReplay, ModeRecord or ModeReplayOrRecord")
+	}
+	return nil
+}
+
+// A Recorder is an http.RoundTripper which records interactions to and replays
+// them from a cassette directory, it is safe for concurrent use.
+type Recorder struct {
+	dir     string
+	options Options
+	mu      sync.Mutex
+}
+
+// New creates a *Recorder for the provided cassette directory, which is created
+// upon the first recorded interaction if need be. A nil opts uses DefaultOptions().
+func New(dir string, opts *Options) (*Recorder, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if dir == "" {
+		err := fmt.Errorf("cassette directory must not be empty")
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	return &Recorder{dir: dir, options: *opts}, nil
+}
+
+// RoundTrip implements the http.RoundTripper interface, requests are matched with
+// recorded interactions by their method, path and query, the host of the request
+// URL is disregarded so that interactions recorded against the YTS website can be
+// replayed against any base URL.
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	interaction := &Interaction{
+		Method: request.Method,
+		URL:    requestURL(request),
+	}
+
+	if r.options.Mode != ModeRecord {
+		recorded, err := r.load(interaction)
+		switch {
+		case err == nil:
+			return recorded.response(request), nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		case r.options.Mode == ModeReplay:
+			err := fmt.Errorf("%s %s", interaction.Method, interaction.URL)
+			return nil, fmt.Errorf("%w: %s", ErrUnmatchedRequest, err)
+		}
+	}
+
+	if err := r.record(request, interaction); err != nil {
+		return nil, err
+	}
+
+	return interaction.response(request), nil
+}
+
+func (r *Recorder) record(request *http.Request, interaction *Interaction) error {
+	transport := r.options.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	interaction.StatusCode = response.StatusCode
+	interaction.ContentType = response.Header.Get("Content-Type")
+	interaction.Body = body
+	for _, scrub := range r.options.Scrubbers {
+		if err := scrub(interaction); err != nil {
+			return err
+		}
+	}
+
+	return r.save(interaction)
+}
+
+func (r *Recorder) path(interaction *Interaction) string {
+	key := interaction.Method + " " + interaction.URL
+	sum := sha256.Sum256([]byte(key))
+
+	name, _, _ := strings.Cut(interaction.URL, "?")
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		}
+		return '_'
+	}, strings.Trim(name, "/"))
+
+	const maxNameLength = 64
+	if maxNameLength < len(name) {
+		name = name[:maxNameLength]
+	}
+
+	filename := fmt.Sprintf("%s_%s-%s.json", strings.ToLower(interaction.Method), name,
+		hex.EncodeToString(sum[:6]))
+	return filepath.Join(r.dir, filename)
+}
+
+func requestURL(request *http.Request) string {
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	if query := request.URL.Query().Encode(); query != "" {
+		return path + "?" + query
+	}
+
+	return path
+}
+
+func (i *Interaction) response(request *http.Request) *http.Response {
+	header := make(http.Header)
+	if i.ContentType != "" {
+		header.Set("Content-Type", i.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.StatusCode, http.StatusText(i.StatusCode)),
+		StatusCode:    i.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       request,
+	}
+}
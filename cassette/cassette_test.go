@@ -0,0 +1,195 @@
+package cassette_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/cassette"
+	"github.com/atifcppprogrammer/yflicks-yts/ytstest"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+func newClient(t *testing.T, s *ytstest.Server, dir string, mode cassette.Mode) *yts.Client {
+	t.Helper()
+	opts := cassette.DefaultOptions()
+	opts.Mode = mode
+
+	recorder, err := cassette.New(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := s.ClientConfig()
+	config.Transport = recorder
+	client, err := yts.NewClientWithConfig(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestNew(t *testing.T) {
+	const methodName = "New"
+	tests := []struct {
+		name    string
+		dir     string
+		opts    *cassette.Options
+		wantErr error
+	}{
+		{
+			name:    "returns error for empty directory",
+			dir:     "",
+			opts:    nil,
+			wantErr: cassette.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for unknown mode",
+			dir:     "cassettes",
+			opts:    &cassette.Options{Mode: cassette.ModeReplayOrRecord + 1},
+			wantErr: cassette.ErrInvalidOptions,
+		},
+		{
+			name:    "returns no error for default options",
+			dir:     "cassettes",
+			opts:    nil,
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cassette.New(tt.dir, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	const methodName = "Recorder.RoundTrip"
+
+	var (
+		dir     = t.TempDir()
+		s       = ytstest.NewServer(nil)
+		filters = yts.DefaultSearchMoviesFilters("dark knight")
+	)
+
+	client := newClient(t, s, dir, cassette.ModeRecord)
+	recordedSearch, err := client.SearchMovies(filters)
+	assertError(t, methodName, err, nil)
+	recordedDirector, err := client.MovieDirector("oppenheimer-2023")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, recordedSearch.Meta.ServerTime, 0)
+
+	// Requests are replayed without reaching the network once the server is closed.
+	s.Close()
+	client = newClient(t, s, dir, cassette.ModeReplay)
+	search, err := client.SearchMovies(filters)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, search, recordedSearch)
+
+	director, err := client.MovieDirector("oppenheimer-2023")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, director, recordedDirector)
+
+	_, err = client.MovieDirector("superbad-2007")
+	assertError(t, methodName, err, cassette.ErrUnmatchedRequest)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assertEqual(t, methodName, len(files), 2)
+	for _, file := range files {
+		payload, _ := os.ReadFile(file)
+		if strings.Contains(string(payload), `\u0026`) {
+			t.Errorf("%s() recorded %s with escaped HTML characters", methodName, file)
+		}
+	}
+}
+
+func TestRecorder_RoundTripReplayOrRecord(t *testing.T) {
+	const methodName = "Recorder.RoundTrip"
+
+	var (
+		dir = t.TempDir()
+		s   = ytstest.NewServer(nil)
+	)
+
+	t.Cleanup(s.Close)
+	client := newClient(t, s, dir, cassette.ModeRecord)
+	_, err := client.MovieDetails(4171, yts.DefaultMovieDetailsFilters())
+	assertError(t, methodName, err, nil)
+
+	s.SetFault(ytstest.MovieDetailsPath, ytstest.Fault{StatusCode: 503})
+	client = newClient(t, s, dir, cassette.ModeReplayOrRecord)
+	response, err := client.MovieDetails(4171, yts.DefaultMovieDetailsFilters())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, response.Data.Movie.Title, "The Dark Knight")
+
+	// Unsuccessful responses are recorded and replayed as well.
+	_, err = client.MovieDetails(57427, yts.DefaultMovieDetailsFilters())
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+	s.ClearFaults()
+	_, err = client.MovieDetails(57427, yts.DefaultMovieDetailsFilters())
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+	assertEqual(t, methodName, s.Requests(ytstest.MovieDetailsPath), 2)
+}
+
+func TestScrubMeta(t *testing.T) {
+	const methodName = "ScrubMeta"
+	tests := []struct {
+		name     string
+		input    cassette.Interaction
+		wantBody string
+	}{
+		{
+			name: "zeroes volatile meta fields",
+			input: cassette.Interaction{
+				ContentType: "application/json",
+				Body:        []byte(`{"@meta":{"api_version":2,"execution_time":"12 ms","server_time":1704067200},"data":{"url":"a?b=1&c=2"}}`),
+			},
+			wantBody: `{"@meta":{"api_version":2,"execution_time":"0 ms","server_time":0},"data":{"url":"a?b=1&c=2"}}`,
+		},
+		{
+			name: "leaves bodies without meta untouched",
+			input: cassette.Interaction{
+				ContentType: "application/json",
+				Body:        []byte(`{"status": "ok"}`),
+			},
+			wantBody: `{"status": "ok"}`,
+		},
+		{
+			name: "leaves html bodies untouched",
+			input: cassette.Interaction{
+				ContentType: "text/html",
+				Body:        []byte(`<div id="movie-info"></div>`),
+			},
+			wantBody: `<div id="movie-info"></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interaction := tt.input
+			err := cassette.ScrubMeta(&interaction)
+			assertError(t, methodName, err, nil)
+			assertEqual(t, methodName, string(interaction.Body), tt.wantBody)
+		})
+	}
+}
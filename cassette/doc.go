@@ -0,0 +1,35 @@
+/*
+Package cassette provides a Recorder, an http.RoundTripper which records the
+responses of the YTS API and website to a cassette directory and replays them
+deterministically, for generating test fixtures from real responses rather than by
+hand.
+
+The Recorder is used as the Transport of the yts.ClientConfig of the client under
+test, a test can then switch between recording and replaying with a flag.
+
+	var record = flag.Bool("record", false, "record cassettes from yts.mx")
+
+	opts := cassette.DefaultOptions()
+	if *record {
+		opts.Mode = cassette.ModeRecord
+	}
+
+	recorder, err := cassette.New("testdata/cassettes/search_movies", opts)
+	...
+	config := yts.DefaultClientConfig()
+	config.Transport = recorder
+	client, err := yts.NewClientWithConfig(&config)
+
+Every interaction is saved to its own JSON file, named after the method and path
+of the request along with a hash of its query, and response bodies which are valid
+JSON are saved unescaped so that the cassettes can be reviewed like any other
+fixture. Volatile fields are removed from recorded responses by the Scrubbers of
+the Options, the default ScrubMeta Scrubber zeroes the "server_time" and
+"execution_time" fields of YTS API responses so that recording an unchanged
+response leaves its cassette untouched.
+
+In ModeReplay, the default Mode, a request without a recorded interaction fails
+with ErrUnmatchedRequest rather than reaching the network, whereas in
+ModeReplayOrRecord it is recorded instead.
+*/
+package cassette
@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// An interactionFile is the representation of an Interaction saved to the cassette
+// directory, a response body which is valid JSON is saved as is under "json" so
+// that it remains readable, any other body is saved as a string under "text".
+type interactionFile struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		StatusCode  int             `json:"status_code"`
+		ContentType string          `json:"content_type,omitempty"`
+		JSON        json.RawMessage `json:"json,omitempty"`
+		Text        string          `json:"text,omitempty"`
+	} `json:"response"`
+}
+
+func (r *Recorder) load(interaction *Interaction) (*Interaction, error) {
+	path := r.path(interaction)
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := interactionFile{}
+	if err := json.Unmarshal(payload, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	body := []byte(file.Response.Text)
+	if file.Response.JSON != nil {
+		body = bytes.TrimSpace(file.Response.JSON)
+	}
+
+	return &Interaction{
+		Method:      file.Request.Method,
+		URL:         file.Request.URL,
+		StatusCode:  file.Response.StatusCode,
+		ContentType: file.Response.ContentType,
+		Body:        body,
+	}, nil
+}
+
+func (r *Recorder) save(interaction *Interaction) error {
+	file := interactionFile{}
+	file.Request.Method = interaction.Method
+	file.Request.URL = interaction.URL
+	file.Response.StatusCode = interaction.StatusCode
+	file.Response.ContentType = interaction.ContentType
+
+	body := bytes.TrimSpace(interaction.Body)
+	if len(body) != 0 && json.Valid(body) && !bytes.HasPrefix(body, []byte(`"`)) {
+		file.Response.JSON = body
+	} else {
+		file.Response.Text = string(interaction.Body)
+	}
+
+	payload, err := marshalJSON(file)
+	if err != nil {
+		return err
+	}
+
+	indented := bytes.Buffer{}
+	if err := json.Indent(&indented, payload, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path(interaction), indented.Bytes(), 0o644)
+}
+
+// marshalJSON encodes the provided value without escaping HTML characters, leaving
+// the URLs and magnet links of YTS responses readable.
+func marshalJSON(value any) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,298 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	// DefaultPageLimit is the value of the PageLimit field for the Options instance
+	// returned by the DefaultOptions() function, it is the largest page size
+	// accepted by the "/api/v2/list_movies.json" endpoint.
+	DefaultPageLimit = 50
+
+	// DefaultMaxPages is the value of the MaxPages field for the Options instance
+	// returned by the DefaultOptions() function, 0 leaves Sync unbounded.
+	DefaultMaxPages = 0
+)
+
+// ErrInvalidOptions is reported when a Catalog is created with invalid Options,
+// the error description will carry further details.
+var ErrInvalidOptions = errors.New("invalid_catalog_options")
+
+// A MovieSearcher is implemented by *yts.Client and is used by a Catalog for
+// walking the "/api/v2/list_movies.json" endpoint.
+type MovieSearcher interface {
+	SearchMoviesWithContext(ctx context.Context, filters *yts.SearchMoviesFilters) (
+		*yts.SearchMoviesResponse, error,
+	)
+}
+
+// An Options instance configures how a Catalog syncs with the YTS API.
+type Options struct {
+	// The number of movies requested per page, must be between 1 and 50.
+	PageLimit int
+
+	// The maximum number of pages fetched by a single call to Sync while building
+	// the initial full snapshot, allowing it to be built across several calls. A
+	// value of 0 leaves the full snapshot unbounded, incremental syncs are never
+	// bounded since they must reach the newest movie already in the catalog.
+	MaxPages int
+}
+
+// DefaultOptions returns the default *Options used for syncing a Catalog.
+func DefaultOptions() *Options {
+	return &Options{
+		PageLimit: DefaultPageLimit,
+		MaxPages:  DefaultMaxPages,
+	}
+}
+
+func (o *Options) validate() error {
+	if o.PageLimit < 1 || DefaultPageLimit < o.PageLimit {
+		return fmt.Errorf("page limit must be between 1 and %d", DefaultPageLimit)
+	}
+
+	if o.MaxPages < 0 {
+		return fmt.Errorf("max pages cannot be negative")
+	}
+
+	return nil
+}
+
+// A SyncResult summarises the work done by a single call to Sync.
+type SyncResult struct {
+	// The number of pages fetched from the YTS API.
+	Pages int `json:"pages"`
+
+	// The number of movies which were not present in the catalog before.
+	Added int `json:"added"`
+
+	// The number of movies which were already present in the catalog and were
+	// replaced by their latest version.
+	Updated int `json:"updated"`
+
+	// Complete reports whether the catalog holds a full snapshot after the sync.
+	Complete bool `json:"complete"`
+}
+
+// A Catalog is a local mirror of the movies available on YTS, it is safe for
+// concurrent use however only a single Sync runs at any time.
+type Catalog struct {
+	searcher MovieSearcher
+	store    Store
+	opts     Options
+
+	syncMu   sync.Mutex
+	mu       sync.RWMutex
+	movies   map[int]yts.Movie
+	progress Progress
+}
+
+// New creates a *Catalog which syncs using the provided searcher, usually a
+// *yts.Client, and persists to the provided store, whose content is loaded
+// immediately. A nil opts uses DefaultOptions().
+func New(searcher MovieSearcher, store Store, opts *Options) (*Catalog, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	movies, progress, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{
+		searcher: searcher,
+		store:    store,
+		opts:     *opts,
+		movies:   make(map[int]yts.Movie, len(movies)),
+		progress: progress,
+	}
+
+	for _, movie := range movies {
+		c.movies[movie.ID] = movie
+	}
+
+	return c, nil
+}
+
+// Len returns the number of movies held by the catalog.
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.movies)
+}
+
+// Progress returns the current sync Progress of the catalog.
+func (c *Catalog) Progress() Progress {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.progress
+}
+
+// Movie returns the movie held by the catalog for the provided YTS movie ID.
+func (c *Catalog) Movie(movieID int) (yts.Movie, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	movie, ok := c.movies[movieID]
+	return movie, ok
+}
+
+// Movies returns every movie held by the catalog, most recently uploaded first.
+func (c *Catalog) Movies() []yts.Movie {
+	c.mu.RLock()
+	movies := make([]yts.Movie, 0, len(c.movies))
+	for _, movie := range c.movies {
+		movies = append(movies, movie)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(movies, func(i, j int) bool {
+		if movies[i].DateUploadedUnix != movies[j].DateUploadedUnix {
+			return movies[i].DateUploadedUnix > movies[j].DateUploadedUnix
+		}
+		return movies[i].ID > movies[j].ID
+	})
+
+	return movies
+}
+
+func (c *Catalog) fetchPage(ctx context.Context, page int) ([]yts.Movie, error) {
+	filters := yts.DefaultSearchMoviesFilters("")
+	filters.Limit = c.opts.PageLimit
+	filters.Page = page
+	filters.SortBy = yts.SortByDateAdded
+	filters.OrderBy = yts.OrderByDesc
+
+	response, err := c.searcher.SearchMoviesWithContext(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data.Movies, nil
+}
+
+// commit merges the provided movies into the catalog and saves them along with the
+// provided Progress to the store, the in-memory state only changes once the store
+// has saved successfully.
+func (c *Catalog) commit(movies []yts.Movie, progress Progress, result *SyncResult) error {
+	for _, movie := range movies {
+		if progress.LastUploadedUnix < movie.DateUploadedUnix {
+			progress.LastUploadedUnix = movie.DateUploadedUnix
+		}
+	}
+
+	if err := c.store.Save(movies, progress); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, movie := range movies {
+		if _, ok := c.movies[movie.ID]; ok {
+			result.Updated++
+		} else {
+			result.Added++
+		}
+		c.movies[movie.ID] = movie
+	}
+
+	c.progress = progress
+	return nil
+}
+
+func (c *Catalog) pageBudgetLeft(result *SyncResult) bool {
+	return c.opts.MaxPages == 0 || result.Pages < c.opts.MaxPages
+}
+
+func (c *Catalog) syncFull(ctx context.Context, result *SyncResult) error {
+	progress := c.Progress()
+	if progress.NextPage < 1 {
+		progress.NextPage = 1
+	}
+
+	for !progress.Complete && c.pageBudgetLeft(result) {
+		movies, err := c.fetchPage(ctx, progress.NextPage)
+		if err != nil {
+			return err
+		}
+
+		result.Pages++
+		progress.NextPage++
+		progress.Complete = len(movies) < c.opts.PageLimit
+		if err := c.commit(movies, progress, result); err != nil {
+			return err
+		}
+		progress = c.Progress()
+	}
+
+	return nil
+}
+
+func (c *Catalog) syncIncremental(ctx context.Context, result *SyncResult) error {
+	var (
+		progress = c.Progress()
+		newer    = make([]yts.Movie, 0)
+		caughtUp = false
+	)
+
+	for page := 1; !caughtUp; page++ {
+		movies, err := c.fetchPage(ctx, page)
+		if err != nil {
+			return err
+		}
+
+		result.Pages++
+		for _, movie := range movies {
+			if movie.DateUploadedUnix < progress.LastUploadedUnix {
+				caughtUp = true
+				break
+			}
+			newer = append(newer, movie)
+		}
+
+		if len(movies) < c.opts.PageLimit {
+			caughtUp = true
+		}
+	}
+
+	// the newer movies are only saved once every page holding them has been
+	// fetched, otherwise an interrupted sync would advance LastUploadedUnix past
+	// movies which were never fetched.
+	return c.commit(newer, progress, result)
+}
+
+// Sync brings the catalog up to date with the YTS API. Until the initial full
+// snapshot is complete every page of movies is fetched in order and saved as soon
+// as it is fetched, so an interrupted snapshot resumes where it left off. Once the
+// snapshot is complete only the movies uploaded since the newest movie in the
+// catalog are fetched. Movies which appear more than once, as happens when movies
+// are added while a snapshot is built, replace their previous version.
+func (c *Catalog) Sync(ctx context.Context) (*SyncResult, error) {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+
+	var (
+		result = &SyncResult{}
+		err    error
+	)
+
+	if c.Progress().Complete {
+		err = c.syncIncremental(ctx, result)
+	} else {
+		err = c.syncFull(ctx, result)
+	}
+
+	result.Complete = c.Progress().Complete
+	return result, err
+}
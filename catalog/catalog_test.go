@@ -0,0 +1,211 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/catalog"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+// fakeSearcher serves its movies, most recently uploaded first, in pages of the
+// requested limit, every request is recorded in requestedPages.
+type fakeSearcher struct {
+	movies         []yts.Movie
+	requestedPages []int
+	failOnPage     int
+}
+
+var errFakeSearch = errors.New("fake_search_failure")
+
+func (fs *fakeSearcher) SearchMoviesWithContext(_ context.Context, filters *yts.SearchMoviesFilters) (
+	*yts.SearchMoviesResponse, error,
+) {
+	fs.requestedPages = append(fs.requestedPages, filters.Page)
+	if filters.Page == fs.failOnPage {
+		return nil, errFakeSearch
+	}
+
+	var (
+		start = (filters.Page - 1) * filters.Limit
+		end   = start + filters.Limit
+	)
+
+	start = min(start, len(fs.movies))
+	end = min(end, len(fs.movies))
+	response := &yts.SearchMoviesResponse{}
+	response.Data.MovieCount = len(fs.movies)
+	response.Data.Movies = fs.movies[start:end]
+	return response, nil
+}
+
+// prepend adds a movie which was uploaded after every movie already served.
+func (fs *fakeSearcher) prepend(movies ...yts.Movie) {
+	fs.movies = append(movies, fs.movies...)
+}
+
+func newMovie(id, uploadedUnix int) yts.Movie {
+	return yts.Movie{
+		MoviePartial: yts.MoviePartial{
+			ID:               id,
+			DateUploadedUnix: uploadedUnix,
+			Torrents:         []yts.Torrent{{Hash: "hash", Quality: yts.Quality1080p}},
+		},
+	}
+}
+
+func newFakeSearcher(count int) *fakeSearcher {
+	fs := &fakeSearcher{}
+	for i := count; 1 <= i; i-- {
+		fs.movies = append(fs.movies, newMovie(i, i*100))
+	}
+	return fs
+}
+
+func movieIDs(movies []yts.Movie) []int {
+	ids := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		ids = append(ids, movie.ID)
+	}
+	return ids
+}
+
+func TestNew(t *testing.T) {
+	const methodName = "catalog.New"
+
+	tests := []struct {
+		name    string
+		opts    *catalog.Options
+		wantErr error
+	}{
+		{
+			name: "uses default options when nil",
+			opts: nil,
+		},
+		{
+			name:    "returns error for page limit above 50",
+			opts:    &catalog.Options{PageLimit: 51},
+			wantErr: catalog.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for negative max pages",
+			opts:    &catalog.Options{PageLimit: 10, MaxPages: -1},
+			wantErr: catalog.ErrInvalidOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := catalog.New(newFakeSearcher(0), catalog.NewMemoryStore(), tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCatalog_Sync(t *testing.T) {
+	const methodName = "Catalog.Sync"
+
+	var (
+		searcher = newFakeSearcher(5)
+		store    = catalog.NewMemoryStore()
+		opts     = &catalog.Options{PageLimit: 2}
+	)
+
+	c, err := catalog.New(searcher, store, opts)
+	assertError(t, methodName, err, nil)
+
+	result, err := c.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, result, &catalog.SyncResult{Pages: 3, Added: 5, Complete: true})
+	assertEqual(t, methodName, movieIDs(c.Movies()), []int{5, 4, 3, 2, 1})
+	assertEqual(t, methodName, c.Progress(), catalog.Progress{
+		Complete:         true,
+		NextPage:         4,
+		LastUploadedUnix: 500,
+	})
+
+	searcher.prepend(newMovie(8, 800), newMovie(7, 700), newMovie(6, 600))
+	searcher.requestedPages = nil
+
+	result, err = c.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, searcher.requestedPages, []int{1, 2, 3})
+	assertEqual(t, methodName, result, &catalog.SyncResult{Pages: 3, Added: 3, Updated: 1, Complete: true})
+	assertEqual(t, methodName, movieIDs(c.Movies()), []int{8, 7, 6, 5, 4, 3, 2, 1})
+	assertEqual(t, methodName, c.Progress().LastUploadedUnix, 800)
+
+	reloaded, err := catalog.New(searcher, store, opts)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, reloaded.Len(), 8)
+	assertEqual(t, methodName, reloaded.Progress(), c.Progress())
+}
+
+func TestCatalog_Sync_Resumes(t *testing.T) {
+	const methodName = "Catalog.Sync"
+
+	var (
+		searcher = newFakeSearcher(5)
+		store    = catalog.NewMemoryStore()
+		opts     = &catalog.Options{PageLimit: 2, MaxPages: 1}
+	)
+
+	c, _ := catalog.New(searcher, store, opts)
+	result, err := c.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, result, &catalog.SyncResult{Pages: 1, Added: 2})
+	assertEqual(t, methodName, c.Progress().NextPage, 2)
+
+	searcher.failOnPage = 2
+	_, err = c.Sync(context.Background())
+	assertError(t, methodName, err, errFakeSearch)
+	assertEqual(t, methodName, c.Progress().NextPage, 2)
+
+	searcher.failOnPage = 0
+	searcher.requestedPages = nil
+	resumed, _ := catalog.New(searcher, store, &catalog.Options{PageLimit: 2})
+	result, err = resumed.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, searcher.requestedPages, []int{2, 3})
+	assertEqual(t, methodName, result, &catalog.SyncResult{Pages: 2, Added: 3, Complete: true})
+	assertEqual(t, methodName, movieIDs(resumed.Movies()), []int{5, 4, 3, 2, 1})
+}
+
+func TestCatalog_Sync_IncrementalFailure(t *testing.T) {
+	const methodName = "Catalog.Sync"
+
+	var (
+		searcher = newFakeSearcher(3)
+		opts     = &catalog.Options{PageLimit: 2}
+	)
+
+	c, _ := catalog.New(searcher, catalog.NewMemoryStore(), opts)
+	_, err := c.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+
+	searcher.prepend(newMovie(6, 600), newMovie(5, 500), newMovie(4, 400))
+	searcher.failOnPage = 2
+	_, err = c.Sync(context.Background())
+	assertError(t, methodName, err, errFakeSearch)
+	assertEqual(t, methodName, c.Len(), 3)
+	assertEqual(t, methodName, c.Progress().LastUploadedUnix, 300)
+
+	searcher.failOnPage = 0
+	_, err = c.Sync(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movieIDs(c.Movies()), []int{6, 5, 4, 3, 2, 1})
+}
@@ -0,0 +1,39 @@
+/*
+Package catalog maintains a local mirror of every movie available on YTS, along
+with its torrents, so that the movies can be browsed offline.
+
+A Catalog walks the "/api/v2/list_movies.json" endpoint of the YTS API sorted by
+date added, the first Sync builds a full snapshot and every later Sync only fetches
+the movies uploaded since the newest movie already present. The movies and the
+progress of the sync are persisted to a Store after every page, so an interrupted
+full snapshot resumes from the page it stopped at.
+
+	client := yts.NewClient()
+	store := catalog.NewJSONLinesStore("catalog")
+	c, err := catalog.New(client, store, catalog.DefaultOptions())
+	...
+	result, err := c.Sync(ctx)
+	...
+	movies := c.Movies()
+
+The movies of a Catalog can then be searched offline by building an Index, which
+supports fuzzy title search along with filters the YTS API lacks.
+
+	index := c.Index()
+	results, err := index.Search(&catalog.Query{
+		Title:      "openheimer",
+		Genres:     []yts.Genre{yts.GenreDrama, yts.GenreHistory},
+		MinRuntime: 120,
+		Sort:       []catalog.SortKey{{By: yts.SortByRating, Order: yts.OrderByDesc}},
+	})
+
+Since the movies returned by the YTS API carry no cast or directors, searching by
+person requires the Crew of each movie, such as the cast of its MovieDetails and
+the directors scraped by the MovieCrew method, to be passed to IndexWithCrew.
+
+	index := c.IndexWithCrew(map[int]catalog.Crew{
+		57427: {Cast: details.Cast, Directors: []string{"Christopher Nolan"}},
+	})
+	results, err := index.Search(&catalog.Query{Person: "cillian murphy"})
+*/
+package catalog
@@ -0,0 +1,606 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// ErrInvalidQuery is reported when an Index is searched with an invalid Query, the
+// error description will carry further details.
+var ErrInvalidQuery = errors.New("invalid_catalog_query")
+
+// A Document is a single movie added to an Index, the Cast and Directors fields
+// are optional and are only needed for searching by person. Since the Movie type
+// carries no cast, the Cast field is usually taken from the MovieDetails of the
+// movie and the Directors field from the MovieDirector or MovieCrew methods.
+type Document struct {
+	Movie     yts.Movie
+	Cast      []yts.Cast
+	Directors []string
+}
+
+type postings map[int]struct{}
+
+// An Index is an in-process inverted index over the movies of a catalog which
+// supports queries the YTS API does not, such as fuzzy title search, search by
+// person and range filters. An Index is safe for concurrent use.
+type Index struct {
+	mu           sync.RWMutex
+	docs         map[int]*Document
+	titleTerms   map[string]postings
+	personTerms  map[string]postings
+	genreTerms   map[string]postings
+	qualityTerms map[yts.Quality]postings
+}
+
+// NewIndex creates an *Index holding the provided documents.
+func NewIndex(docs ...Document) *Index {
+	ix := &Index{
+		docs:         make(map[int]*Document),
+		titleTerms:   make(map[string]postings),
+		personTerms:  make(map[string]postings),
+		genreTerms:   make(map[string]postings),
+		qualityTerms: make(map[yts.Quality]postings),
+	}
+
+	for _, doc := range docs {
+		ix.Add(doc)
+	}
+
+	return ix
+}
+
+// A Crew holds the cast members and directors of a movie, which are needed for
+// searching the movies of a catalog by person.
+type Crew struct {
+	Cast      []yts.Cast
+	Directors []string
+}
+
+// Index returns an *Index holding every movie of the catalog, since the movies of
+// a catalog carry no cast or directors the index only supports searching by
+// person when it is built using the IndexWithCrew method.
+func (c *Catalog) Index() *Index {
+	return c.IndexWithCrew(nil)
+}
+
+// IndexWithCrew returns an *Index holding every movie of the catalog, along with
+// the Crew of each movie found in the provided map keyed by YTS movie ID.
+func (c *Catalog) IndexWithCrew(crew map[int]Crew) *Index {
+	movies := c.Movies()
+	docs := make([]Document, len(movies))
+	for i := range movies {
+		docs[i].Movie = movies[i]
+		if movieCrew, ok := crew[movies[i].ID]; ok {
+			docs[i].Cast = movieCrew.Cast
+			docs[i].Directors = movieCrew.Directors
+		}
+	}
+
+	return NewIndex(docs...)
+}
+
+// tokenize splits s into lowercase terms made up of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func addPosting[K comparable](terms map[K]postings, term K, movieID int) {
+	if terms[term] == nil {
+		terms[term] = make(postings)
+	}
+	terms[term][movieID] = struct{}{}
+}
+
+func removePosting[K comparable](terms map[K]postings, term K, movieID int) {
+	delete(terms[term], movieID)
+	if len(terms[term]) == 0 {
+		delete(terms, term)
+	}
+}
+
+func (doc *Document) titleTerms() []string {
+	return append(tokenize(doc.Movie.Title), tokenize(doc.Movie.TitleEnglish)...)
+}
+
+func (doc *Document) personTerms() []string {
+	terms := make([]string, 0)
+	for _, member := range doc.Cast {
+		terms = append(terms, tokenize(member.Name)...)
+	}
+	for _, director := range doc.Directors {
+		terms = append(terms, tokenize(director)...)
+	}
+	return terms
+}
+
+// Add adds the provided document to the index, replacing any document previously
+// added for the same movie ID.
+func (ix *Index) Add(doc Document) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	movieID := doc.Movie.ID
+	ix.removeLocked(movieID)
+	ix.docs[movieID] = &doc
+
+	for _, term := range doc.titleTerms() {
+		addPosting(ix.titleTerms, term, movieID)
+	}
+	for _, term := range doc.personTerms() {
+		addPosting(ix.personTerms, term, movieID)
+	}
+	for _, genre := range doc.Movie.Genres {
+		addPosting(ix.genreTerms, strings.ToLower(string(genre)), movieID)
+	}
+	for _, torrent := range doc.Movie.Torrents {
+		addPosting(ix.qualityTerms, torrent.Quality, movieID)
+	}
+}
+
+// Remove removes the document for the provided movie ID from the index.
+func (ix *Index) Remove(movieID int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(movieID)
+}
+
+func (ix *Index) removeLocked(movieID int) {
+	doc, ok := ix.docs[movieID]
+	if !ok {
+		return
+	}
+
+	for _, term := range doc.titleTerms() {
+		removePosting(ix.titleTerms, term, movieID)
+	}
+	for _, term := range doc.personTerms() {
+		removePosting(ix.personTerms, term, movieID)
+	}
+	for _, genre := range doc.Movie.Genres {
+		removePosting(ix.genreTerms, strings.ToLower(string(genre)), movieID)
+	}
+	for _, torrent := range doc.Movie.Torrents {
+		removePosting(ix.qualityTerms, torrent.Quality, movieID)
+	}
+
+	delete(ix.docs, movieID)
+}
+
+// Len returns the number of documents held by the index.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// A GenreMatch determines how the Genres of a Query are combined.
+type GenreMatch string
+
+const (
+	// GenreMatchAll requires a movie to have every genre of the Query.
+	GenreMatchAll GenreMatch = "all"
+
+	// GenreMatchAny requires a movie to have at least one genre of the Query.
+	GenreMatchAny GenreMatch = "any"
+)
+
+// A SortKey is a single key of the combined sorting applied to search results.
+type SortKey struct {
+	By    yts.SortBy
+	Order yts.OrderBy
+}
+
+// A Query represents a search of an Index, the zero value of every field leaves
+// the corresponding criterion unconstrained and the criteria which are set must
+// all be met by a movie for it to match.
+type Query struct {
+	// Fuzzy matched against the Title and TitleEnglish fields, every term must
+	// match a title term exactly, as a prefix or within a small edit distance.
+	Title string
+
+	// Fuzzy matched against the names of the cast and directors of a Document in
+	// the same manner as Title.
+	Person string
+
+	// The genres a movie must have, combined according to GenreMatch.
+	Genres []yts.Genre
+
+	// How Genres are combined, defaults to GenreMatchAll.
+	GenreMatch GenreMatch
+
+	// A movie must have a torrent of this quality, QualityAll is unconstrained.
+	Quality yts.Quality
+
+	// A movie must have this language code e.g. "en", compared case insensitively.
+	Language string
+
+	// The inclusive year range of a movie, 0 leaves that end unbounded.
+	MinYear int
+	MaxYear int
+
+	// The inclusive runtime range of a movie in minutes, 0 leaves that end
+	// unbounded.
+	MinRuntime int
+	MaxRuntime int
+
+	// The minimum rating of a movie.
+	MinimumRating float64
+
+	// The keys results are sorted by, in order of precedence. When empty, results
+	// of a Title or Person query are sorted by relevance and all other results by
+	// SortByDateAdded in descending order. SortByDownloadCount and SortByLikeCount
+	// are not supported since Movie does not carry these fields.
+	Sort []SortKey
+
+	// Offset and Limit page through the results, a Limit of 0 returns every result.
+	Offset int
+	Limit  int
+}
+
+var querySortByRule = validation.In(
+	yts.SortByTitle,
+	yts.SortByYear,
+	yts.SortByRating,
+	yts.SortByPeers,
+	yts.SortBySeeds,
+	yts.SortByDateAdded,
+)
+
+// Validate implements the validation.Validatable interface.
+func (sk SortKey) Validate() error {
+	return validation.ValidateStruct(
+		&sk,
+		validation.Field(
+			&sk.By,
+			validation.Required,
+			querySortByRule,
+		),
+		validation.Field(
+			&sk.Order,
+			validation.Required,
+			validation.In(yts.OrderByAsc, yts.OrderByDesc),
+		),
+	)
+}
+
+func (q *Query) validate() error {
+	return validation.ValidateStruct(
+		q,
+		validation.Field(
+			&q.GenreMatch,
+			validation.In(GenreMatchAll, GenreMatchAny),
+		),
+		validation.Field(
+			&q.Quality,
+			validation.In(
+				yts.QualityAll,
+				yts.Quality480p,
+				yts.Quality720p,
+				yts.Quality1080p,
+				yts.Quality1080pX265,
+				yts.Quality2160p,
+				yts.Quality3D,
+			),
+		),
+		validation.Field(
+			&q.MinYear,
+			validation.Min(0),
+		),
+		validation.Field(
+			&q.MaxYear,
+			validation.Min(q.MinYear),
+		),
+		validation.Field(
+			&q.MinRuntime,
+			validation.Min(0),
+		),
+		validation.Field(
+			&q.MaxRuntime,
+			validation.Min(q.MinRuntime),
+		),
+		validation.Field(
+			&q.MinimumRating,
+			validation.Min(0.0),
+			validation.Max(float64(yts.DefaultRatingScale)),
+		),
+		validation.Field(
+			&q.Sort,
+		),
+		validation.Field(
+			&q.Offset,
+			validation.Min(0),
+		),
+		validation.Field(
+			&q.Limit,
+			validation.Min(0),
+		),
+	)
+}
+
+// A Result is a single movie matching a Query along with its relevance Score,
+// which is 0 for queries without a Title or Person.
+type Result struct {
+	Movie yts.Movie `json:"movie"`
+	Score float64   `json:"score"`
+}
+
+const (
+	scoreExact  = 1.0
+	scorePrefix = 0.75
+	scoreFuzzy  = 0.5
+)
+
+// termScore returns how closely the query term matches the indexed term, or 0 if
+// they do not match. Terms of up to 3 characters must match exactly or as a
+// prefix, longer terms may be 1 edit away and terms of 8 or more characters may
+// be 2 edits away.
+func termScore(query, term string) float64 {
+	const (
+		minFuzzyLen    = 4
+		minTwoEditsLen = 8
+	)
+
+	switch {
+	case query == term:
+		return scoreExact
+	case strings.HasPrefix(term, query):
+		return scorePrefix
+	}
+
+	queryLen := len([]rune(query))
+	if queryLen < minFuzzyLen {
+		return 0
+	}
+
+	maxEdits := 1
+	if minTwoEditsLen <= queryLen {
+		maxEdits = 2
+	}
+
+	if editDistance(query, term, maxEdits) <= maxEdits {
+		return scoreFuzzy
+	}
+
+	return 0
+}
+
+// editDistance returns the Levenshtein distance between a and b, distances above
+// maxEdits are reported as maxEdits+1.
+func editDistance(a, b string, maxEdits int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); maxEdits < diff || maxEdits < -diff {
+		return maxEdits + 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if maxEdits < rowMin {
+			return maxEdits + 1
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// matchTerms returns the score of every movie whose terms match each of the terms
+// of text, the score of a movie being the sum of the best score of each term.
+func matchTerms(terms map[string]postings, text string) map[int]float64 {
+	var scores map[int]float64
+	for _, queryTerm := range tokenize(text) {
+		best := make(map[int]float64)
+		for term, movieIDs := range terms {
+			score := termScore(queryTerm, term)
+			if score == 0 {
+				continue
+			}
+			for movieID := range movieIDs {
+				best[movieID] = max(best[movieID], score)
+			}
+		}
+
+		if scores == nil {
+			scores = best
+			continue
+		}
+
+		for movieID := range scores {
+			if score, ok := best[movieID]; ok {
+				scores[movieID] += score
+			} else {
+				delete(scores, movieID)
+			}
+		}
+	}
+
+	return scores
+}
+
+func (ix *Index) candidates(q *Query) map[int]float64 {
+	var scores map[int]float64
+	intersect := func(other map[int]float64) {
+		if scores == nil {
+			scores = other
+			return
+		}
+		for movieID := range scores {
+			if score, ok := other[movieID]; ok {
+				scores[movieID] += score
+			} else {
+				delete(scores, movieID)
+			}
+		}
+	}
+
+	if len(tokenize(q.Title)) != 0 {
+		intersect(matchTerms(ix.titleTerms, q.Title))
+	}
+
+	if len(tokenize(q.Person)) != 0 {
+		intersect(matchTerms(ix.personTerms, q.Person))
+	}
+
+	if scores == nil {
+		scores = make(map[int]float64, len(ix.docs))
+		for movieID := range ix.docs {
+			scores[movieID] = 0
+		}
+	}
+
+	return scores
+}
+
+func (ix *Index) matchesGenres(movieID int, q *Query) bool {
+	if len(q.Genres) == 0 {
+		return true
+	}
+
+	for _, genre := range q.Genres {
+		_, ok := ix.genreTerms[strings.ToLower(string(genre))][movieID]
+		if ok && q.GenreMatch == GenreMatchAny {
+			return true
+		}
+		if !ok && q.GenreMatch != GenreMatchAny {
+			return false
+		}
+	}
+
+	return q.GenreMatch != GenreMatchAny
+}
+
+func (ix *Index) matches(movieID int, q *Query) bool {
+	movie := &ix.docs[movieID].Movie
+	if q.Quality != "" && q.Quality != yts.QualityAll {
+		if _, ok := ix.qualityTerms[q.Quality][movieID]; !ok {
+			return false
+		}
+	}
+
+	switch {
+	case q.Language != "" && !strings.EqualFold(movie.Language, q.Language):
+	case q.MinYear != 0 && movie.Year < q.MinYear:
+	case q.MaxYear != 0 && q.MaxYear < movie.Year:
+	case q.MinRuntime != 0 && movie.Runtime < q.MinRuntime:
+	case q.MaxRuntime != 0 && q.MaxRuntime < movie.Runtime:
+	case movie.Rating < q.MinimumRating:
+	default:
+		return ix.matchesGenres(movieID, q)
+	}
+
+	return false
+}
+
+func torrentStat(movie *yts.Movie, stat func(*yts.Torrent) int) int {
+	best := 0
+	for i := range movie.Torrents {
+		best = max(best, stat(&movie.Torrents[i]))
+	}
+	return best
+}
+
+// compareBy returns a negative, zero or positive number depending on whether a
+// sorts before, alongside or after b for the provided SortBy in ascending order.
+func compareBy(a, b *yts.Movie, by yts.SortBy) int {
+	switch by {
+	case yts.SortByTitle:
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case yts.SortByYear:
+		return a.Year - b.Year
+	case yts.SortByRating:
+		switch {
+		case a.Rating < b.Rating:
+			return -1
+		case a.Rating > b.Rating:
+			return 1
+		}
+		return 0
+	case yts.SortByPeers:
+		peers := func(t *yts.Torrent) int { return t.Peers }
+		return torrentStat(a, peers) - torrentStat(b, peers)
+	case yts.SortBySeeds:
+		seeds := func(t *yts.Torrent) int { return t.Seeds }
+		return torrentStat(a, seeds) - torrentStat(b, seeds)
+	default:
+		return a.DateUploadedUnix - b.DateUploadedUnix
+	}
+}
+
+func sortResults(results []Result, keys []SortKey) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := &results[i], &results[j]
+		if len(keys) == 0 && a.Score != b.Score {
+			return a.Score > b.Score
+		}
+
+		for _, key := range keys {
+			cmp := compareBy(&a.Movie, &b.Movie, key.By)
+			if cmp == 0 {
+				continue
+			}
+			if key.Order == yts.OrderByAsc {
+				return cmp < 0
+			}
+			return cmp > 0
+		}
+
+		if cmp := compareBy(&a.Movie, &b.Movie, yts.SortByDateAdded); cmp != 0 {
+			return cmp > 0
+		}
+		return a.Movie.ID > b.Movie.ID
+	})
+}
+
+// Search returns the movies of the index matching the provided Query, an error
+// wrapping ErrInvalidQuery is returned in the event the Query is invalid.
+func (ix *Index) Search(q *Query) ([]Result, error) {
+	if err := q.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQuery, err)
+	}
+
+	ix.mu.RLock()
+	results := make([]Result, 0)
+	for movieID, score := range ix.candidates(q) {
+		if ix.matches(movieID, q) {
+			results = append(results, Result{ix.docs[movieID].Movie, score})
+		}
+	}
+	ix.mu.RUnlock()
+
+	sortResults(results, q.Sort)
+	if len(results) <= q.Offset {
+		return make([]Result, 0), nil
+	}
+
+	results = results[q.Offset:]
+	if q.Limit != 0 && q.Limit < len(results) {
+		results = results[:q.Limit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,234 @@
+package catalog_test
+
+import (
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/catalog"
+)
+
+func newSearchDocuments() []catalog.Document {
+	newDocument := func(id int, title string, year, runtime int, rating float64, language string,
+		genres []yts.Genre, qualities []yts.Quality, seeds int,
+	) catalog.Document {
+		torrents := make([]yts.Torrent, 0)
+		for _, quality := range qualities {
+			torrents = append(torrents, yts.Torrent{Quality: quality, Seeds: seeds})
+		}
+
+		return catalog.Document{
+			Movie: yts.Movie{
+				MoviePartial: yts.MoviePartial{
+					ID:               id,
+					Title:            title,
+					Year:             year,
+					Runtime:          runtime,
+					Rating:           rating,
+					Language:         language,
+					Genres:           genres,
+					Torrents:         torrents,
+					DateUploadedUnix: id * 100,
+				},
+			},
+		}
+	}
+
+	var (
+		oppenheimer = newDocument(1, "Oppenheimer", 2023, 180, 8.3, "en",
+			[]yts.Genre{yts.GenreBiography, yts.GenreDrama, yts.GenreHistory},
+			[]yts.Quality{yts.Quality1080p, yts.Quality2160p}, 900)
+		noCountry = newDocument(2, "No Country for Old Men", 2007, 122, 8.2, "en",
+			[]yts.Genre{yts.GenreCrime, yts.GenreDrama, yts.GenreThriller},
+			[]yts.Quality{yts.Quality720p, yts.Quality1080p}, 300)
+		amelie = newDocument(3, "Amélie", 2001, 122, 8.3, "fr",
+			[]yts.Genre{yts.GenreComedy, yts.GenreRomance},
+			[]yts.Quality{yts.Quality1080p}, 500)
+		sicario = newDocument(4, "Sicario", 2015, 121, 7.6, "en",
+			[]yts.Genre{yts.GenreAction, yts.GenreCrime, yts.GenreDrama},
+			[]yts.Quality{yts.Quality2160p}, 100)
+	)
+
+	oppenheimer.Cast = []yts.Cast{{Name: "Cillian Murphy"}, {Name: "Emily Blunt"}}
+	oppenheimer.Directors = []string{"Christopher Nolan"}
+	noCountry.Cast = []yts.Cast{{Name: "Josh Brolin"}, {Name: "Javier Bardem"}}
+	noCountry.Directors = []string{"Joel Coen", "Ethan Coen"}
+	sicario.Cast = []yts.Cast{{Name: "Emily Blunt"}, {Name: "Josh Brolin"}}
+	sicario.Directors = []string{"Denis Villeneuve"}
+
+	return []catalog.Document{oppenheimer, noCountry, amelie, sicario}
+}
+
+func resultIDs(results []catalog.Result) []int {
+	ids := make([]int, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Movie.ID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	const methodName = "Index.Search"
+
+	index := catalog.NewIndex(newSearchDocuments()...)
+
+	tests := []struct {
+		name    string
+		query   catalog.Query
+		want    []int
+		wantErr error
+	}{
+		{
+			name:  "returns every movie by date added for empty query",
+			query: catalog.Query{},
+			want:  []int{4, 3, 2, 1},
+		},
+		{
+			name:  "matches misspelt title",
+			query: catalog.Query{Title: "openheimer"},
+			want:  []int{1},
+		},
+		{
+			name:  "matches title prefixes and accented terms",
+			query: catalog.Query{Title: "amélie"},
+			want:  []int{3},
+		},
+		{
+			name:  "requires every title term to match",
+			query: catalog.Query{Title: "country old"},
+			want:  []int{2},
+		},
+		{
+			name:  "matches cast and directors",
+			query: catalog.Query{Person: "brolin"},
+			want:  []int{4, 2},
+		},
+		{
+			name:  "matches directors with fuzzy surname",
+			query: catalog.Query{Person: "christopher nolen"},
+			want:  []int{1},
+		},
+		{
+			name:  "requires every genre by default",
+			query: catalog.Query{Genres: []yts.Genre{yts.GenreCrime, yts.GenreDrama}},
+			want:  []int{4, 2},
+		},
+		{
+			name: "accepts any genre when matching any",
+			query: catalog.Query{
+				Genres:     []yts.Genre{yts.GenreComedy, yts.GenreHistory},
+				GenreMatch: catalog.GenreMatchAny,
+			},
+			want: []int{3, 1},
+		},
+		{
+			name:  "filters by quality",
+			query: catalog.Query{Quality: yts.Quality2160p},
+			want:  []int{4, 1},
+		},
+		{
+			name:  "filters by language",
+			query: catalog.Query{Language: "FR"},
+			want:  []int{3},
+		},
+		{
+			name:  "filters by year and runtime ranges",
+			query: catalog.Query{MinYear: 2005, MaxYear: 2020, MinRuntime: 122},
+			want:  []int{2},
+		},
+		{
+			name: "sorts by combined keys",
+			query: catalog.Query{
+				Sort: []catalog.SortKey{
+					{By: yts.SortByRating, Order: yts.OrderByDesc},
+					{By: yts.SortByYear, Order: yts.OrderByAsc},
+				},
+			},
+			want: []int{3, 1, 2, 4},
+		},
+		{
+			name: "sorts by seeds and pages results",
+			query: catalog.Query{
+				Sort:   []catalog.SortKey{{By: yts.SortBySeeds, Order: yts.OrderByDesc}},
+				Offset: 1,
+				Limit:  2,
+			},
+			want: []int{3, 2},
+		},
+		{
+			name:    "returns error for unsupported sort key",
+			query:   catalog.Query{Sort: []catalog.SortKey{{By: yts.SortByLikeCount, Order: yts.OrderByDesc}}},
+			wantErr: catalog.ErrInvalidQuery,
+		},
+		{
+			name:    "returns error for inverted year range",
+			query:   catalog.Query{MinYear: 2020, MaxYear: 2010},
+			wantErr: catalog.ErrInvalidQuery,
+		},
+		{
+			name:    "returns error for unknown genre match",
+			query:   catalog.Query{GenreMatch: "some"},
+			wantErr: catalog.ErrInvalidQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := index.Search(&tt.query)
+			assertError(t, methodName, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assertEqual(t, methodName, resultIDs(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_SearchRelevance(t *testing.T) {
+	const methodName = "Index.Search"
+
+	index := catalog.NewIndex(newSearchDocuments()...)
+	got, err := index.Search(&catalog.Query{Person: "emily blunt"})
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, resultIDs(got), []int{4, 1})
+	assertEqual(t, methodName, got[0].Score, 2.0)
+
+	index.Remove(4)
+	got, _ = index.Search(&catalog.Query{Person: "blunt"})
+	assertEqual(t, methodName, resultIDs(got), []int{1})
+	assertEqual(t, methodName, index.Len(), 3)
+
+	docs := newSearchDocuments()
+	docs[0].Movie.Title = "Renamed"
+	index.Add(docs[0])
+	got, _ = index.Search(&catalog.Query{Title: "oppenheimer"})
+	assertEqual(t, methodName, resultIDs(got), []int{})
+}
+
+func TestCatalog_Index(t *testing.T) {
+	const methodName = "Catalog.Index"
+
+	c, _ := catalog.New(newFakeSearcher(0), catalog.NewMemoryStore(), nil)
+	assertEqual(t, methodName, c.Index().Len(), 0)
+
+	var (
+		docs   = newSearchDocuments()
+		store  = catalog.NewMemoryStore()
+		movies = make([]yts.Movie, 0, len(docs))
+		crew   = make(map[int]catalog.Crew)
+	)
+
+	for _, doc := range docs {
+		movies = append(movies, doc.Movie)
+		crew[doc.Movie.ID] = catalog.Crew{Cast: doc.Cast, Directors: doc.Directors}
+	}
+
+	_ = store.Save(movies, catalog.Progress{Complete: true})
+	c, _ = catalog.New(newFakeSearcher(0), store, nil)
+	query := &catalog.Query{Person: "emily blunt"}
+
+	got, err := c.Index().Search(query)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, resultIDs(got), []int{})
+
+	got, err = c.IndexWithCrew(crew).Search(query)
+	assertError(t, "Catalog.IndexWithCrew", err, nil)
+	assertEqual(t, "Catalog.IndexWithCrew", resultIDs(got), []int{4, 1})
+}
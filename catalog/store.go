@@ -0,0 +1,199 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A Progress records how far a Catalog has synced with the YTS API.
+type Progress struct {
+	// Complete reports whether the initial full snapshot has been built, until
+	// then every Sync continues the full walk from NextPage.
+	Complete bool `json:"complete"`
+
+	// NextPage is the "/api/v2/list_movies.json" page the full walk resumes from.
+	NextPage int `json:"next_page"`
+
+	// LastUploadedUnix is the newest DateUploadedUnix of any synced movie, an
+	// incremental Sync only fetches movies uploaded at or after this time.
+	LastUploadedUnix int `json:"last_uploaded_unix"`
+}
+
+// A Store persists the movies and sync Progress of a Catalog.
+type Store interface {
+	// Load returns every movie saved to the store along with the last saved
+	// Progress, in the event a movie ID appears more than once the last movie
+	// saved for it takes precedence.
+	Load() ([]yts.Movie, Progress, error)
+
+	// Save persists the provided movies and then the provided Progress, movies
+	// replace any movie previously saved with the same ID.
+	Save(movies []yts.Movie, progress Progress) error
+}
+
+// A MemoryStore is a Store which only holds movies in memory, it is useful for
+// tests and for short lived catalogs, it is safe for concurrent use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	movies   []yts.Movie
+	progress Progress
+}
+
+// NewMemoryStore creates an empty *MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{movies: make([]yts.Movie, 0)}
+}
+
+// Load implements the Store interface.
+func (ms *MemoryStore) Load() ([]yts.Movie, Progress, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	movies := make([]yts.Movie, len(ms.movies))
+	copy(movies, ms.movies)
+	return movies, ms.progress, nil
+}
+
+// Save implements the Store interface.
+func (ms *MemoryStore) Save(movies []yts.Movie, progress Progress) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.movies = append(ms.movies, movies...)
+	ms.progress = progress
+	return nil
+}
+
+const (
+	moviesFilename   = "movies.jsonl"
+	progressFilename = "progress.json"
+)
+
+// A JSONLinesStore is a Store which persists a catalog to a directory, movies are
+// appended as JSON lines to a "movies.jsonl" file and the Progress is written to a
+// "progress.json" file once the movies of a Save have been written.
+type JSONLinesStore struct {
+	dir string
+}
+
+// NewJSONLinesStore creates a *JSONLinesStore for the provided directory, which is
+// created upon the first call to Save if need be.
+func NewJSONLinesStore(dir string) *JSONLinesStore {
+	return &JSONLinesStore{dir}
+}
+
+// Load implements the Store interface, a missing directory or file is treated as
+// an empty catalog.
+func (s *JSONLinesStore) Load() ([]yts.Movie, Progress, error) {
+	progress := Progress{}
+	payload, err := os.ReadFile(filepath.Join(s.dir, progressFilename))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, progress, err
+	default:
+		if err := json.Unmarshal(payload, &progress); err != nil {
+			return nil, progress, fmt.Errorf("%s: %w", progressFilename, err)
+		}
+	}
+
+	movies, err := s.loadMovies()
+	if err != nil {
+		return nil, progress, err
+	}
+
+	return movies, progress, nil
+}
+
+func (s *JSONLinesStore) loadMovies() ([]yts.Movie, error) {
+	movies := make([]yts.Movie, 0)
+	file, err := os.Open(filepath.Join(s.dir, moviesFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return movies, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	const maxLineSize = 4 << 20
+	var (
+		scanner = bufio.NewScanner(file)
+		line    = 0
+	)
+
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var movie yts.Movie
+		if err := json.Unmarshal(scanner.Bytes(), &movie); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", moviesFilename, line, err)
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, scanner.Err()
+}
+
+// Save implements the Store interface, the Progress is written to a temporary file
+// which then replaces "progress.json" so that it is never left partially written.
+func (s *JSONLinesStore) Save(movies []yts.Movie, progress Progress) error {
+	const (
+		dirMode  = 0o755
+		fileMode = 0o644
+	)
+
+	if err := os.MkdirAll(s.dir, dirMode); err != nil {
+		return err
+	}
+
+	if err := s.appendMovies(movies, fileMode); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	progressPath := filepath.Join(s.dir, progressFilename)
+	if err := os.WriteFile(progressPath+".tmp", payload, fileMode); err != nil {
+		return err
+	}
+
+	return os.Rename(progressPath+".tmp", progressPath)
+}
+
+func (s *JSONLinesStore) appendMovies(movies []yts.Movie, mode os.FileMode) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	moviesPath := filepath.Join(s.dir, moviesFilename)
+	file, err := os.OpenFile(moviesPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+
+	var (
+		writer  = bufio.NewWriter(file)
+		encoder = json.NewEncoder(writer)
+	)
+
+	for i := range movies {
+		if err := encoder.Encode(&movies[i]); err != nil {
+			return errors.Join(err, file.Close())
+		}
+	}
+
+	return errors.Join(writer.Flush(), file.Close())
+}
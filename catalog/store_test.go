@@ -0,0 +1,61 @@
+package catalog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/catalog"
+)
+
+func TestJSONLinesStore(t *testing.T) {
+	const methodName = "JSONLinesStore"
+
+	var (
+		dir   = filepath.Join(t.TempDir(), "catalog")
+		store = catalog.NewJSONLinesStore(dir)
+	)
+
+	movies, progress, err := store.Load()
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movies, []yts.Movie{})
+	assertEqual(t, methodName, progress, catalog.Progress{})
+
+	var (
+		first       = []yts.Movie{newMovie(2, 200), newMovie(1, 100)}
+		second      = []yts.Movie{newMovie(3, 300)}
+		wantPartial = catalog.Progress{NextPage: 2, LastUploadedUnix: 200}
+		wantFinal   = catalog.Progress{Complete: true, NextPage: 3, LastUploadedUnix: 300}
+	)
+
+	assertError(t, methodName, store.Save(first, wantPartial), nil)
+	movies, progress, err = store.Load()
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movies, first)
+	assertEqual(t, methodName, progress, wantPartial)
+
+	assertError(t, methodName, store.Save(second, wantFinal), nil)
+	assertError(t, methodName, store.Save(nil, wantFinal), nil)
+	movies, progress, err = store.Load()
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movies, append(first, second...))
+	assertEqual(t, methodName, progress, wantFinal)
+
+	c, err := catalog.New(newFakeSearcher(0), store, nil)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movieIDs(c.Movies()), []int{3, 2, 1})
+	assertEqual(t, methodName, c.Progress(), wantFinal)
+}
+
+func TestJSONLinesStore_CorruptMovies(t *testing.T) {
+	const methodName = "JSONLinesStore.Load"
+
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "movies.jsonl"), []byte("{\"id\": 1}\n{corrupt\n"), 0o600)
+
+	_, _, err := catalog.NewJSONLinesStore(dir).Load()
+	if err == nil {
+		t.Errorf("%s() expected error for corrupt movies file", methodName)
+	}
+}
@@ -0,0 +1,328 @@
+package yts
+
+import (
+	"fmt"
+	"math"
+)
+
+// A ChangeKind identifies the type of a Change between two snapshots.
+type ChangeKind string
+
+const (
+	// ChangeMovieAdded is reported for a movie present only in the new snapshot.
+	ChangeMovieAdded ChangeKind = "movie_added"
+
+	// ChangeMovieRemoved is reported for a movie present only in the old snapshot.
+	ChangeMovieRemoved ChangeKind = "movie_removed"
+
+	// ChangeTorrentAdded is reported for a torrent, such as a new 2160p release,
+	// present only in the new snapshot of a movie.
+	ChangeTorrentAdded ChangeKind = "torrent_added"
+
+	// ChangeTorrentRemoved is reported for a torrent present only in the old
+	// snapshot of a movie.
+	ChangeTorrentRemoved ChangeKind = "torrent_removed"
+
+	// ChangeRatingChanged is reported for a movie whose rating differs between the
+	// two snapshots.
+	ChangeRatingChanged ChangeKind = "rating_changed"
+
+	// ChangeSeedsChanged is reported for a torrent whose seeds changed by at least
+	// the thresholds of the DiffOptions in use.
+	ChangeSeedsChanged ChangeKind = "seeds_changed"
+
+	// ChangeUpcomingReleased is reported for an upcoming movie whose Progress has
+	// reached 100 in the new snapshot.
+	ChangeUpcomingReleased ChangeKind = "upcoming_released"
+)
+
+// A Change represents a single difference between two snapshots, only the fields
+// relevant to its Kind are set. The old and new ratings and seeds are pointers so
+// that a zero rating or seeds count is told apart from a Change it does not apply
+// to, for which they are nil. Changes between Movie snapshots carry the MovieID
+// of the movie whereas changes between HomePageContentData snapshots carry the
+// Section of the home page they were found in.
+type Change struct {
+	Kind      ChangeKind      `json:"kind"`
+	MovieID   int             `json:"movie_id,omitempty"`
+	Slug      string          `json:"slug,omitempty"`
+	Title     string          `json:"title"`
+	Section   HomePageSection `json:"section,omitempty"`
+	Torrent   *Torrent        `json:"torrent,omitempty"`
+	OldRating *float64        `json:"old_rating,omitempty"`
+	NewRating *float64        `json:"new_rating,omitempty"`
+	OldSeeds  *int            `json:"old_seeds,omitempty"`
+	NewSeeds  *int            `json:"new_seeds,omitempty"`
+}
+
+const (
+	// DefaultMinSeedsDelta is the value of the MinSeedsDelta field for the
+	// DiffOptions instance returned by the DefaultDiffOptions() function.
+	DefaultMinSeedsDelta = 50
+
+	// DefaultSeedsChangeRatio is the value of the SeedsChangeRatio field for the
+	// DiffOptions instance returned by the DefaultDiffOptions() function.
+	DefaultSeedsChangeRatio = 0.5
+)
+
+// A DiffOptions instance configures the thresholds used by DiffMovies for
+// reporting ChangeSeedsChanged, the seeds of a torrent must change by at least
+// MinSeedsDelta and by at least SeedsChangeRatio of their old value.
+type DiffOptions struct {
+	MinSeedsDelta    int
+	SeedsChangeRatio float64
+}
+
+// DefaultDiffOptions returns the default *DiffOptions used for comparing
+// snapshots.
+func DefaultDiffOptions() *DiffOptions {
+	return &DiffOptions{
+		MinSeedsDelta:    DefaultMinSeedsDelta,
+		SeedsChangeRatio: DefaultSeedsChangeRatio,
+	}
+}
+
+func (o *DiffOptions) validate() error {
+	if o.MinSeedsDelta < 1 {
+		return fmt.Errorf("min seeds delta must be at least 1")
+	}
+
+	if o.SeedsChangeRatio < 0 {
+		return fmt.Errorf("seeds change ratio cannot be negative")
+	}
+
+	return nil
+}
+
+func (o *DiffOptions) seedsChanged(oldSeeds, newSeeds int) bool {
+	delta := newSeeds - oldSeeds
+	if delta < 0 {
+		delta = -delta
+	}
+
+	if delta < o.MinSeedsDelta {
+		return false
+	}
+
+	return oldSeeds == 0 || o.SeedsChangeRatio <= float64(delta)/float64(oldSeeds)
+}
+
+// ratingEpsilon absorbs floating point noise when comparing ratings.
+const ratingEpsilon = 1e-9
+
+func ratingChanged(oldRating, newRating float64) bool {
+	return ratingEpsilon < math.Abs(newRating-oldRating)
+}
+
+func torrentKey(t *Torrent) string {
+	if t.Hash != "" {
+		return t.Hash
+	}
+
+	return fmt.Sprintf("%s/%s", t.Quality, t.Type)
+}
+
+func diffTorrents(oldMovie, newMovie *Movie, opts *DiffOptions) []Change {
+	var (
+		changes   = make([]Change, 0)
+		oldByKey  = make(map[string]*Torrent, len(oldMovie.Torrents))
+		newByKey  = make(map[string]*Torrent, len(newMovie.Torrents))
+		newChange = func(kind ChangeKind, t *Torrent) Change {
+			torrent := *t
+			return Change{
+				Kind:    kind,
+				MovieID: newMovie.ID,
+				Slug:    newMovie.Slug,
+				Title:   newMovie.Title,
+				Torrent: &torrent,
+			}
+		}
+	)
+
+	for i := range oldMovie.Torrents {
+		oldByKey[torrentKey(&oldMovie.Torrents[i])] = &oldMovie.Torrents[i]
+	}
+
+	for i := range newMovie.Torrents {
+		newTorrent := &newMovie.Torrents[i]
+		newByKey[torrentKey(newTorrent)] = newTorrent
+		oldTorrent, ok := oldByKey[torrentKey(newTorrent)]
+		switch {
+		case !ok:
+			changes = append(changes, newChange(ChangeTorrentAdded, newTorrent))
+		case opts.seedsChanged(oldTorrent.Seeds, newTorrent.Seeds):
+			oldSeeds, newSeeds := oldTorrent.Seeds, newTorrent.Seeds
+			change := newChange(ChangeSeedsChanged, newTorrent)
+			change.OldSeeds = &oldSeeds
+			change.NewSeeds = &newSeeds
+			changes = append(changes, change)
+		}
+	}
+
+	for i := range oldMovie.Torrents {
+		oldTorrent := &oldMovie.Torrents[i]
+		if _, ok := newByKey[torrentKey(oldTorrent)]; !ok {
+			changes = append(changes, newChange(ChangeTorrentRemoved, oldTorrent))
+		}
+	}
+
+	return changes
+}
+
+// DiffMovies compares the old and new snapshots of movies, such as two versions
+// of a catalog, and returns the changes between them. Movies are matched by their
+// ID and torrents by their Hash, changes are ordered by the position of their
+// movie in the new snapshot followed by the removed movies in the order of the
+// old snapshot. A nil opts uses DefaultDiffOptions().
+func DiffMovies(oldMovies, newMovies []Movie, opts *DiffOptions) ([]Change, error) {
+	if opts == nil {
+		opts = DefaultDiffOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	var (
+		changes = make([]Change, 0)
+		oldByID = make(map[int]*Movie, len(oldMovies))
+		newByID = make(map[int]*Movie, len(newMovies))
+	)
+
+	for i := range oldMovies {
+		oldByID[oldMovies[i].ID] = &oldMovies[i]
+	}
+
+	for i := range newMovies {
+		newMovie := &newMovies[i]
+		newByID[newMovie.ID] = newMovie
+		oldMovie, ok := oldByID[newMovie.ID]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:    ChangeMovieAdded,
+				MovieID: newMovie.ID,
+				Slug:    newMovie.Slug,
+				Title:   newMovie.Title,
+			})
+			continue
+		}
+
+		if ratingChanged(oldMovie.Rating, newMovie.Rating) {
+			oldRating, newRating := oldMovie.Rating, newMovie.Rating
+			changes = append(changes, Change{
+				Kind:      ChangeRatingChanged,
+				MovieID:   newMovie.ID,
+				Slug:      newMovie.Slug,
+				Title:     newMovie.Title,
+				OldRating: &oldRating,
+				NewRating: &newRating,
+			})
+		}
+
+		changes = append(changes, diffTorrents(oldMovie, newMovie, opts)...)
+	}
+
+	for i := range oldMovies {
+		oldMovie := &oldMovies[i]
+		if _, ok := newByID[oldMovie.ID]; !ok {
+			changes = append(changes, Change{
+				Kind:    ChangeMovieRemoved,
+				MovieID: oldMovie.ID,
+				Slug:    oldMovie.Slug,
+				Title:   oldMovie.Title,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func diffSiteMovies(oldMovies, newMovies []SiteMovie, section HomePageSection) []Change {
+	var (
+		changes   = make([]Change, 0)
+		oldBySlug = make(map[string]*SiteMovie, len(oldMovies))
+		newBySlug = make(map[string]*SiteMovie, len(newMovies))
+		newChange = func(kind ChangeKind, sm *SiteMovie) Change {
+			return Change{Kind: kind, Slug: sm.Slug, Title: sm.Title, Section: section}
+		}
+	)
+
+	for i := range oldMovies {
+		oldBySlug[oldMovies[i].Slug] = &oldMovies[i]
+	}
+
+	for i := range newMovies {
+		newMovie := &newMovies[i]
+		newBySlug[newMovie.Slug] = newMovie
+		oldMovie, ok := oldBySlug[newMovie.Slug]
+		switch {
+		case !ok:
+			changes = append(changes, newChange(ChangeMovieAdded, newMovie))
+		case oldMovie.Rating.Compare(newMovie.Rating) != 0:
+			oldRating, newRating := oldMovie.Rating.Normalized(), newMovie.Rating.Normalized()
+			change := newChange(ChangeRatingChanged, newMovie)
+			change.OldRating = &oldRating
+			change.NewRating = &newRating
+			changes = append(changes, change)
+		}
+	}
+
+	for i := range oldMovies {
+		if _, ok := newBySlug[oldMovies[i].Slug]; !ok {
+			changes = append(changes, newChange(ChangeMovieRemoved, &oldMovies[i]))
+		}
+	}
+
+	return changes
+}
+
+func diffUpcomingMovies(oldMovies, newMovies []SiteUpcomingMovie) []Change {
+	const releasedProgress = 100
+
+	var (
+		changes   = make([]Change, 0)
+		oldByLink = make(map[string]*SiteUpcomingMovie, len(oldMovies))
+		newByLink = make(map[string]*SiteUpcomingMovie, len(newMovies))
+		newChange = func(kind ChangeKind, sum *SiteUpcomingMovie) Change {
+			return Change{Kind: kind, Title: sum.Title, Section: HomePageSectionUpcoming}
+		}
+	)
+
+	for i := range oldMovies {
+		oldByLink[oldMovies[i].Link] = &oldMovies[i]
+	}
+
+	for i := range newMovies {
+		newMovie := &newMovies[i]
+		newByLink[newMovie.Link] = newMovie
+		oldMovie, ok := oldByLink[newMovie.Link]
+		if !ok {
+			changes = append(changes, newChange(ChangeMovieAdded, newMovie))
+		}
+
+		released := newMovie.Progress >= releasedProgress
+		if released && (!ok || oldMovie.Progress < releasedProgress) {
+			changes = append(changes, newChange(ChangeUpcomingReleased, newMovie))
+		}
+	}
+
+	for i := range oldMovies {
+		if _, ok := newByLink[oldMovies[i].Link]; !ok {
+			changes = append(changes, newChange(ChangeMovieRemoved, &oldMovies[i]))
+		}
+	}
+
+	return changes
+}
+
+// DiffHomePageContent compares the old and new snapshots of the home page
+// content, such as those returned by two calls to the HomePageContent method, and
+// returns the changes between them section by section. Popular and latest movies
+// are matched by their slug and upcoming movies by their link, an upcoming movie
+// whose Progress reaches 100 is reported as ChangeUpcomingReleased.
+func DiffHomePageContent(oldContent, newContent *HomePageContentData) []Change {
+	changes := diffSiteMovies(oldContent.Popular, newContent.Popular, HomePageSectionPopular)
+	changes = append(changes, diffSiteMovies(oldContent.Latest, newContent.Latest, HomePageSectionLatest)...)
+	changes = append(changes, diffUpcomingMovies(oldContent.Upcoming, newContent.Upcoming)...)
+	return changes
+}
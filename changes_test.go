@@ -0,0 +1,202 @@
+package yts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func TestDiffMovies(t *testing.T) {
+	const methodName = "DiffMovies"
+
+	newMovie := func(id int, rating float64, torrents ...yts.Torrent) yts.Movie {
+		return yts.Movie{
+			MoviePartial: yts.MoviePartial{
+				ID:       id,
+				Slug:     "slug",
+				Title:    "title",
+				Rating:   rating,
+				Torrents: torrents,
+			},
+		}
+	}
+
+	var (
+		hd     = yts.Torrent{Hash: "HD", Quality: yts.Quality1080p, Seeds: 100}
+		hdBusy = yts.Torrent{Hash: "HD", Quality: yts.Quality1080p, Seeds: 400}
+		hdCalm = yts.Torrent{Hash: "HD", Quality: yts.Quality1080p, Seeds: 120}
+		uhd    = yts.Torrent{Hash: "UHD", Quality: yts.Quality2160p, Seeds: 20}
+		sd     = yts.Torrent{Hash: "SD", Quality: yts.Quality720p, Seeds: 5}
+		hdDead = yts.Torrent{Hash: "HD", Quality: yts.Quality1080p, Seeds: 0}
+	)
+
+	tests := []struct {
+		name      string
+		oldMovies []yts.Movie
+		newMovies []yts.Movie
+		opts      *yts.DiffOptions
+		want      []yts.Change
+		wantErr   error
+	}{
+		{
+			name:      "returns no changes for identical snapshots",
+			oldMovies: []yts.Movie{newMovie(1, 7, hd)},
+			newMovies: []yts.Movie{newMovie(1, 7, hd)},
+			want:      []yts.Change{},
+		},
+		{
+			name:      "reports added and removed movies",
+			oldMovies: []yts.Movie{newMovie(1, 7), newMovie(2, 7)},
+			newMovies: []yts.Movie{newMovie(3, 7), newMovie(1, 7)},
+			want: []yts.Change{
+				{Kind: yts.ChangeMovieAdded, MovieID: 3, Slug: "slug", Title: "title"},
+				{Kind: yts.ChangeMovieRemoved, MovieID: 2, Slug: "slug", Title: "title"},
+			},
+		},
+		{
+			name:      "reports new quality and removed torrent",
+			oldMovies: []yts.Movie{newMovie(1, 7, hd, sd)},
+			newMovies: []yts.Movie{newMovie(1, 7, hd, uhd)},
+			want: []yts.Change{
+				{Kind: yts.ChangeTorrentAdded, MovieID: 1, Slug: "slug", Title: "title", Torrent: &uhd},
+				{Kind: yts.ChangeTorrentRemoved, MovieID: 1, Slug: "slug", Title: "title", Torrent: &sd},
+			},
+		},
+		{
+			name:      "reports rating change and dramatic seeds change",
+			oldMovies: []yts.Movie{newMovie(1, 7, hd)},
+			newMovies: []yts.Movie{newMovie(1, 7.4, hdBusy)},
+			want: []yts.Change{
+				{Kind: yts.ChangeRatingChanged, MovieID: 1, Slug: "slug", Title: "title", OldRating: float64Ptr(7), NewRating: float64Ptr(7.4)},
+				{
+					Kind: yts.ChangeSeedsChanged, MovieID: 1, Slug: "slug", Title: "title",
+					Torrent: &hdBusy, OldSeeds: intPtr(100), NewSeeds: intPtr(400),
+				},
+			},
+		},
+		{
+			name:      "reports zero rating and seeds",
+			oldMovies: []yts.Movie{newMovie(1, 7, hdDead)},
+			newMovies: []yts.Movie{newMovie(1, 0, hd)},
+			want: []yts.Change{
+				{
+					Kind: yts.ChangeRatingChanged, MovieID: 1, Slug: "slug", Title: "title",
+					OldRating: float64Ptr(7), NewRating: float64Ptr(0),
+				},
+				{
+					Kind: yts.ChangeSeedsChanged, MovieID: 1, Slug: "slug", Title: "title",
+					Torrent: &hd, OldSeeds: intPtr(0), NewSeeds: intPtr(100),
+				},
+			},
+		},
+		{
+			name:      "ignores seeds change below thresholds",
+			oldMovies: []yts.Movie{newMovie(1, 7, hd)},
+			newMovies: []yts.Movie{newMovie(1, 7, hdCalm)},
+			want:      []yts.Change{},
+		},
+		{
+			name:      "applies custom seeds thresholds",
+			oldMovies: []yts.Movie{newMovie(1, 7, hd)},
+			newMovies: []yts.Movie{newMovie(1, 7, hdCalm)},
+			opts:      &yts.DiffOptions{MinSeedsDelta: 10, SeedsChangeRatio: 0.1},
+			want: []yts.Change{
+				{
+					Kind: yts.ChangeSeedsChanged, MovieID: 1, Slug: "slug", Title: "title",
+					Torrent: &hdCalm, OldSeeds: intPtr(100), NewSeeds: intPtr(120),
+				},
+			},
+		},
+		{
+			name:    "returns error for invalid options",
+			opts:    &yts.DiffOptions{MinSeedsDelta: 0},
+			wantErr: yts.ErrValidationFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yts.DiffMovies(tt.oldMovies, tt.newMovies, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestChange_JSON(t *testing.T) {
+	const methodName = "Change.MarshalJSON"
+
+	tests := []struct {
+		name   string
+		change yts.Change
+		want   string
+	}{
+		{
+			name:   "keeps zero seeds",
+			change: yts.Change{Kind: yts.ChangeSeedsChanged, Title: "title", OldSeeds: intPtr(0), NewSeeds: intPtr(80)},
+			want:   `{"kind":"seeds_changed","title":"title","old_seeds":0,"new_seeds":80}`,
+		},
+		{
+			name:   "keeps zero rating",
+			change: yts.Change{Kind: yts.ChangeRatingChanged, Title: "title", OldRating: float64Ptr(7), NewRating: float64Ptr(0)},
+			want:   `{"kind":"rating_changed","title":"title","old_rating":7,"new_rating":0}`,
+		},
+		{
+			name:   "omits fields not applicable to kind",
+			change: yts.Change{Kind: yts.ChangeMovieAdded, Title: "title"},
+			want:   `{"kind":"movie_added","title":"title"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.change)
+			assertError(t, methodName, err, nil)
+			assertEqual(t, methodName, string(got), tt.want)
+		})
+	}
+}
+
+func TestDiffHomePageContent(t *testing.T) {
+	const methodName = "DiffHomePageContent"
+
+	newSiteMovie := func(slug string, rating float64) yts.SiteMovie {
+		return yts.SiteMovie{
+			SiteMovieBase: yts.SiteMovieBase{Slug: slug, Title: slug},
+			Rating:        yts.Rating{Value: rating, Scale: 10},
+		}
+	}
+
+	newUpcoming := func(link string, progress int) yts.SiteUpcomingMovie {
+		return yts.SiteUpcomingMovie{
+			SiteMovieBase: yts.SiteMovieBase{Title: link, Link: link},
+			Progress:      progress,
+		}
+	}
+
+	oldContent := &yts.HomePageContentData{
+		Popular:  []yts.SiteMovie{newSiteMovie("a", 7), newSiteMovie("b", 6)},
+		Latest:   []yts.SiteMovie{newSiteMovie("c", 5)},
+		Upcoming: []yts.SiteUpcomingMovie{newUpcoming("u1", 40), newUpcoming("u2", 100), newUpcoming("u3", 10)},
+	}
+
+	newContent := &yts.HomePageContentData{
+		Popular:  []yts.SiteMovie{newSiteMovie("a", 7.5), newSiteMovie("d", 8)},
+		Latest:   []yts.SiteMovie{newSiteMovie("c", 5)},
+		Upcoming: []yts.SiteUpcomingMovie{newUpcoming("u1", 100), newUpcoming("u2", 100), newUpcoming("u4", 100)},
+	}
+
+	got := yts.DiffHomePageContent(oldContent, newContent)
+	assertEqual(t, methodName, got, []yts.Change{
+		{Kind: yts.ChangeRatingChanged, Slug: "a", Title: "a", Section: yts.HomePageSectionPopular, OldRating: float64Ptr(7), NewRating: float64Ptr(7.5)},
+		{Kind: yts.ChangeMovieAdded, Slug: "d", Title: "d", Section: yts.HomePageSectionPopular},
+		{Kind: yts.ChangeMovieRemoved, Slug: "b", Title: "b", Section: yts.HomePageSectionPopular},
+		{Kind: yts.ChangeUpcomingReleased, Title: "u1", Section: yts.HomePageSectionUpcoming},
+		{Kind: yts.ChangeMovieAdded, Title: "u4", Section: yts.HomePageSectionUpcoming},
+		{Kind: yts.ChangeUpcomingReleased, Title: "u4", Section: yts.HomePageSectionUpcoming},
+		{Kind: yts.ChangeMovieRemoved, Title: "u3", Section: yts.HomePageSectionUpcoming},
+	})
+}
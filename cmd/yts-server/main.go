@@ -0,0 +1,83 @@
+/*
+Yts-server serves the JSON endpoints of the server package, exposing the methods of
+a yts.Client to clients such as browsers which cannot call YTS directly.
+
+Usage:
+
+	yts-server [-addr :8080] [-cache-ttl 5m] [-allow-origin origins] [-timeout 1m]
+	           [-graphql] [-graphql-schema]
+
+The -allow-origin flag takes a comma separated list of the origins allowed to make
+cross origin requests, every origin is allowed when it is empty. The -graphql flag
+serves the endpoint of the graphql package at /graphql, the -graphql-schema flag
+prints its schema and exits.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/graphql"
+	"github.com/atifcppprogrammer/yflicks-yts/server"
+)
+
+func main() {
+	var (
+		addr         = flag.String("addr", ":8080", "the address to listen on")
+		cacheTTL     = flag.Duration("cache-ttl", server.DefaultCacheTTL, "the duration responses are cached for, 0 disables caching")
+		allowOrigins = flag.String("allow-origin", "", "comma separated origins allowed to make cross origin requests")
+		timeout      = flag.Duration("timeout", time.Minute, "the timeout of requests made to YTS")
+		withGraphQL  = flag.Bool("graphql", false, "serve the GraphQL endpoint at /graphql")
+		printSchema  = flag.Bool("graphql-schema", false, "print the schema of the GraphQL endpoint and exit")
+	)
+	flag.Parse()
+
+	config := yts.DefaultClientConfig()
+	config.RequestTimeout = *timeout
+	client, err := yts.NewClientWithConfig(&config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "yts-server: %s\n", err)
+		os.Exit(2)
+	}
+
+	schema := graphql.NewSchema(client)
+	if *printSchema {
+		fmt.Print(schema.SDL())
+		return
+	}
+
+	opts := server.DefaultOptions()
+	opts.CacheTTL = *cacheTTL
+	if *allowOrigins != "" {
+		opts.AllowedOrigins = strings.Split(*allowOrigins, ",")
+	}
+
+	if *withGraphQL {
+		opts.Mounts = map[string]http.Handler{"/graphql": schema}
+	}
+
+	s, err := server.New(client, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "yts-server: %s\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("yts-server: listening on %s", *addr)
+	if err := server.ListenAndServe(ctx, *addr, s); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("yts-server: %s", err)
+	}
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A ytsClient is implemented by *yts.Client, it holds the methods used by the app
+// so that tests can provide a fake client.
+type ytsClient interface {
+	SearchMoviesWithContext(ctx context.Context, filters *yts.SearchMoviesFilters) (
+		*yts.SearchMoviesResponse, error,
+	)
+	MovieDetailsWithContext(ctx context.Context, movieID int, filters *yts.MovieDetailsFilters) (
+		*yts.MovieDetailsResponse, error,
+	)
+	MovieReviewsWithContext(ctx context.Context, movieSlug string) (*yts.MovieReviewsResponse, error)
+	MovieCommentsWithContext(ctx context.Context, movieSlug string, page int) (
+		*yts.MovieCommentsResponse, error,
+	)
+	MagnetLinks(t yts.TorrentInfoGetter) yts.TorrentMagnets
+}
+
+type screen int
+
+const (
+	screenSearch screen = iota
+	screenResults
+	screenDetails
+	screenComments
+	screenReviews
+	screenMagnets
+)
+
+// A loaded is the result of an asynchronous load started by the load method, the
+// apply function updates the app with the loaded content.
+type loaded struct {
+	seq   int
+	apply func(a *app)
+	err   error
+}
+
+type magnet struct {
+	quality yts.Quality
+	link    string
+}
+
+// An app holds the state of the terminal UI, every method is called from the
+// goroutine executing the run method except for the fetch functions passed to
+// load which only communicate with the app through the loads channel.
+type app struct {
+	client    ytsClient
+	out       io.Writer
+	clipboard func(text string) error
+	width     int
+	height    int
+
+	screen  screen
+	query   string
+	filters *yts.SearchMoviesFilters
+	results []yts.Movie
+	total   int
+	cursor  int
+	scroll  int
+
+	details      *yts.MovieDetails
+	comments     []yts.SiteMovieComment
+	commentsPage int
+	commentsMore bool
+	reviews      []yts.SiteMovieReview
+	magnets      []magnet
+
+	status  string
+	loading bool
+	seq     int
+	cancel  context.CancelFunc
+	loads   chan loaded
+	quit    bool
+}
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+func newApp(client ytsClient, out io.Writer) *app {
+	return &app{
+		client:    client,
+		out:       out,
+		clipboard: func(text string) error { return copyToClipboard(out, text) },
+		width:     defaultWidth,
+		height:    defaultHeight,
+		loads:     make(chan loaded),
+	}
+}
+
+// load cancels the load in progress, if any, and executes fetch in a goroutine.
+// The function returned by fetch is applied once the run loop receives it unless
+// the load was cancelled or superseded by another load in the meantime.
+func (a *app) load(ctx context.Context, status string, fetch func(ctx context.Context) (func(*app), error)) {
+	a.cancelLoad()
+	loadCtx, cancel := context.WithCancel(ctx)
+	a.cancel, a.loading, a.status = cancel, true, status
+
+	seq := a.seq
+	go func() {
+		apply, err := fetch(loadCtx)
+		select {
+		case a.loads <- loaded{seq, apply, err}:
+		case <-loadCtx.Done():
+		}
+	}()
+}
+
+// cancelLoad cancels the load in progress, the sequence number is bumped so that
+// a result sent before the cancellation was noticed is discarded.
+func (a *app) cancelLoad() {
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+	a.loading = false
+	a.seq++
+}
+
+func (a *app) handleLoaded(l loaded) {
+	if l.seq != a.seq || !a.loading {
+		return
+	}
+
+	a.cancelLoad()
+	a.status = ""
+	switch {
+	case errors.Is(l.err, context.Canceled):
+		a.status = "cancelled"
+	case l.err != nil:
+		a.status = fmt.Sprintf("error: %s", l.err)
+	default:
+		l.apply(a)
+	}
+}
+
+func (a *app) search(ctx context.Context, page int) {
+	filters := yts.DefaultSearchMoviesFilters(strings.TrimSpace(a.query))
+	filters.Page = page
+
+	a.load(ctx, fmt.Sprintf("searching page %d...", page), func(ctx context.Context) (func(*app), error) {
+		response, err := a.client.SearchMoviesWithContext(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(a *app) {
+			a.filters = filters
+			a.results = response.Data.Movies
+			a.total = response.Data.MovieCount
+			a.screen, a.cursor = screenResults, 0
+		}, nil
+	})
+}
+
+func (a *app) openDetails(ctx context.Context) {
+	if len(a.results) <= a.cursor {
+		return
+	}
+
+	movieID := a.results[a.cursor].ID
+	filters := yts.DefaultMovieDetailsFilters()
+	a.load(ctx, "loading details...", func(ctx context.Context) (func(*app), error) {
+		response, err := a.client.MovieDetailsWithContext(ctx, movieID, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(a *app) {
+			a.details = &response.Data.Movie
+			a.screen, a.scroll = screenDetails, 0
+		}, nil
+	})
+}
+
+func (a *app) openComments(ctx context.Context, page int) {
+	slug := a.details.Slug
+	a.load(ctx, fmt.Sprintf("loading comments page %d...", page), func(ctx context.Context) (func(*app), error) {
+		response, err := a.client.MovieCommentsWithContext(ctx, slug, page)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(a *app) {
+			a.comments = response.Data.Comments
+			a.commentsPage = page
+			a.commentsMore = response.Data.CommentsMore
+			a.screen, a.scroll = screenComments, 0
+		}, nil
+	})
+}
+
+func (a *app) openReviews(ctx context.Context) {
+	slug := a.details.Slug
+	a.load(ctx, "loading reviews...", func(ctx context.Context) (func(*app), error) {
+		response, err := a.client.MovieReviewsWithContext(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(a *app) {
+			a.reviews = response.Data.Reviews
+			a.screen, a.scroll = screenReviews, 0
+		}, nil
+	})
+}
+
+// openMagnets lists the magnet links of the torrents of the movie shown, in the
+// order of its torrents, no network request is required for preparing them.
+func (a *app) openMagnets() {
+	links := a.client.MagnetLinks(&a.details.MoviePartial)
+	a.magnets = make([]magnet, 0, len(links))
+	for _, torrent := range a.details.Torrents {
+		if link, ok := links[torrent.Quality]; ok {
+			a.magnets = append(a.magnets, magnet{torrent.Quality, link})
+			delete(links, torrent.Quality)
+		}
+	}
+	a.screen, a.cursor = screenMagnets, 0
+}
+
+func (a *app) copyMagnet() {
+	if len(a.magnets) <= a.cursor {
+		return
+	}
+
+	selected := a.magnets[a.cursor]
+	if err := a.clipboard(selected.link); err != nil {
+		a.status = fmt.Sprintf("error: %s", err)
+		return
+	}
+	a.status = fmt.Sprintf("copied %s magnet link to the clipboard", selected.quality)
+}
+
+func (a *app) moveCursor(delta, count int) {
+	a.cursor = max(0, min(a.cursor+delta, count-1))
+}
+
+func (a *app) handleKey(ctx context.Context, k key) {
+	if k == keyCtrlC {
+		a.quit = true
+		return
+	}
+
+	if a.loading && k == keyEscape {
+		a.cancelLoad()
+		a.status = "cancelled"
+		return
+	}
+
+	switch a.screen {
+	case screenSearch:
+		a.handleSearchKey(ctx, k)
+	case screenResults:
+		a.handleResultsKey(ctx, k)
+	case screenDetails:
+		a.handleDetailsKey(ctx, k)
+	case screenComments:
+		a.handleCommentsKey(ctx, k)
+	case screenReviews:
+		a.handleScrollKey(k)
+	case screenMagnets:
+		a.handleMagnetsKey(k)
+	}
+}
+
+func (a *app) handleSearchKey(ctx context.Context, k key) {
+	switch {
+	case k == keyEnter:
+		a.search(ctx, 1)
+	case k == keyBackspace && a.query != "":
+		runes := []rune(a.query)
+		a.query = string(runes[:len(runes)-1])
+	case k == keyEscape && a.filters != nil:
+		a.screen = screenResults
+	case 0 <= k:
+		a.query += string(rune(k))
+	}
+}
+
+func (a *app) handleResultsKey(ctx context.Context, k key) {
+	page := a.filters.Page
+	switch k {
+	case keyUp, 'k':
+		a.moveCursor(-1, len(a.results))
+	case keyDown, 'j':
+		a.moveCursor(1, len(a.results))
+	case keyRight, 'n':
+		if page*a.filters.Limit < a.total {
+			a.search(ctx, page+1)
+		}
+	case keyLeft, 'p':
+		if 1 < page {
+			a.search(ctx, page-1)
+		}
+	case keyEnter:
+		a.openDetails(ctx)
+	case '/', 's':
+		a.screen = screenSearch
+	case 'q':
+		a.quit = true
+	}
+}
+
+func (a *app) handleDetailsKey(ctx context.Context, k key) {
+	switch k {
+	case 'c':
+		a.openComments(ctx, 1)
+	case 'r':
+		a.openReviews(ctx)
+	case 'm':
+		a.openMagnets()
+	case keyEscape, keyBackspace, keyLeft:
+		a.screen = screenResults
+	case 'q':
+		a.quit = true
+	default:
+		a.handleScrollKey(k)
+	}
+}
+
+func (a *app) handleCommentsKey(ctx context.Context, k key) {
+	switch k {
+	case keyRight, 'n':
+		if a.commentsMore {
+			a.openComments(ctx, a.commentsPage+1)
+		}
+	case keyLeft, 'p':
+		if 1 < a.commentsPage {
+			a.openComments(ctx, a.commentsPage-1)
+		}
+	default:
+		a.handleScrollKey(k)
+	}
+}
+
+func (a *app) handleScrollKey(k key) {
+	switch k {
+	case keyUp, 'k':
+		a.scroll = max(0, a.scroll-1)
+	case keyDown, 'j':
+		a.scroll++
+	case keyEscape, keyBackspace:
+		a.screen = screenDetails
+	}
+}
+
+func (a *app) handleMagnetsKey(k key) {
+	switch k {
+	case keyUp, 'k':
+		a.moveCursor(-1, len(a.magnets))
+	case keyDown, 'j':
+		a.moveCursor(1, len(a.magnets))
+	case keyEnter, 'y':
+		a.copyMagnet()
+	case keyEscape, keyBackspace, keyLeft:
+		a.screen = screenDetails
+	}
+}
+
+// run renders the app and handles keys and loaded content until the user quits,
+// the keys channel is closed or the provided context is cancelled.
+func (a *app) run(ctx context.Context, keys <-chan key) error {
+	defer a.cancelLoad()
+
+	a.render()
+	for !a.quit {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			a.handleKey(ctx, k)
+		case l := <-a.loads:
+			a.handleLoaded(l)
+		}
+		a.render()
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+// fakeClient serves a fixed set of movies, when block is set searches block
+// until their context is cancelled and report the cancellation on cancelled.
+type fakeClient struct {
+	movies    []yts.Movie
+	block     bool
+	cancelled chan error
+	pages     []int
+}
+
+func (fc *fakeClient) SearchMoviesWithContext(ctx context.Context, filters *yts.SearchMoviesFilters) (
+	*yts.SearchMoviesResponse, error,
+) {
+	if fc.block {
+		<-ctx.Done()
+		fc.cancelled <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	fc.pages = append(fc.pages, filters.Page)
+	response := &yts.SearchMoviesResponse{}
+	response.Data.Movies = fc.movies
+	response.Data.MovieCount = 45
+	return response, nil
+}
+
+func (fc *fakeClient) MovieDetailsWithContext(_ context.Context, movieID int, _ *yts.MovieDetailsFilters) (
+	*yts.MovieDetailsResponse, error,
+) {
+	for i := range fc.movies {
+		if fc.movies[i].ID == movieID {
+			response := &yts.MovieDetailsResponse{}
+			response.Data.Movie.MoviePartial = fc.movies[i].MoviePartial
+			return response, nil
+		}
+	}
+	return nil, yts.ErrMovieNotFound
+}
+
+func (fc *fakeClient) MovieReviewsWithContext(context.Context, string) (*yts.MovieReviewsResponse, error) {
+	response := &yts.MovieReviewsResponse{}
+	response.Data.Reviews = []yts.SiteMovieReview{{Author: "reviewer", Title: "Great", Content: "A great movie."}}
+	return response, nil
+}
+
+func (fc *fakeClient) MovieCommentsWithContext(_ context.Context, _ string, page int) (
+	*yts.MovieCommentsResponse, error,
+) {
+	response := &yts.MovieCommentsResponse{}
+	response.Data.CommentsMore = page < 2
+	response.Data.Comments = []yts.SiteMovieComment{{Author: "commenter", Content: "Nice."}}
+	return response, nil
+}
+
+func (fc *fakeClient) MagnetLinks(t yts.TorrentInfoGetter) yts.TorrentMagnets {
+	magnets := make(yts.TorrentMagnets)
+	for _, torrent := range t.GetTorrentInfo().Torrents {
+		magnets[torrent.Quality] = "magnet:?xt=urn:btih:" + torrent.Hash
+	}
+	return magnets
+}
+
+func newFakeClient() *fakeClient {
+	movie := func(id int, title string) yts.Movie {
+		return yts.Movie{MoviePartial: yts.MoviePartial{
+			ID:        id,
+			Title:     title,
+			TitleLong: title,
+			Slug:      strings.ToLower(title),
+			Torrents: []yts.Torrent{
+				{Hash: "HD", Quality: yts.Quality1080p},
+				{Hash: "UHD", Quality: yts.Quality2160p},
+			},
+		}}
+	}
+	return &fakeClient{movies: []yts.Movie{movie(1, "Oppenheimer"), movie(2, "Barbie")}}
+}
+
+func pressKeys(ctx context.Context, a *app, keys ...key) {
+	for _, k := range keys {
+		a.handleKey(ctx, k)
+	}
+}
+
+// settle waits for the load in progress and applies its result.
+func settle(t *testing.T, a *app) {
+	t.Helper()
+	select {
+	case l := <-a.loads:
+		a.handleLoaded(l)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for load")
+	}
+}
+
+func TestApp_Browse(t *testing.T) {
+	const methodName = "app.handleKey"
+
+	var (
+		ctx    = context.Background()
+		client = newFakeClient()
+		copied = make([]string, 0)
+		a      = newApp(client, &bytes.Buffer{})
+	)
+
+	a.clipboard = func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+
+	pressKeys(ctx, a, 'o', 'p', 'x', keyBackspace, keyEnter)
+	assertEqual(t, methodName, a.loading, true)
+	settle(t, a)
+	assertEqual(t, methodName, a.screen, screenResults)
+	assertEqual(t, methodName, a.filters.QueryTerm, "op")
+	assertEqual(t, methodName, len(a.results), 2)
+
+	pressKeys(ctx, a, 'n')
+	settle(t, a)
+	pressKeys(ctx, a, 'p')
+	settle(t, a)
+	assertEqual(t, methodName, client.pages, []int{1, 2, 1})
+
+	pressKeys(ctx, a, keyDown, keyDown, keyEnter)
+	settle(t, a)
+	assertEqual(t, methodName, a.screen, screenDetails)
+	assertEqual(t, methodName, a.details.ID, 2)
+
+	pressKeys(ctx, a, 'c')
+	settle(t, a)
+	assertEqual(t, methodName, a.screen, screenComments)
+	pressKeys(ctx, a, 'n')
+	settle(t, a)
+	assertEqual(t, methodName, a.commentsPage, 2)
+	pressKeys(ctx, a, 'n')
+	assertEqual(t, methodName, a.loading, false)
+
+	pressKeys(ctx, a, keyEscape, 'r')
+	settle(t, a)
+	assertEqual(t, methodName, a.screen, screenReviews)
+	assertEqual(t, methodName, strings.Contains(strings.Join(a.view(), "\n"), "A great movie."), true)
+
+	pressKeys(ctx, a, keyEscape, 'm', keyDown, keyEnter)
+	assertEqual(t, methodName, a.screen, screenMagnets)
+	assertEqual(t, methodName, copied, []string{"magnet:?xt=urn:btih:UHD"})
+	assertEqual(t, methodName, a.status, "copied 2160p magnet link to the clipboard")
+
+	pressKeys(ctx, a, keyEscape, keyEscape)
+	assertEqual(t, methodName, a.screen, screenResults)
+	pressKeys(ctx, a, 'q')
+	assertEqual(t, methodName, a.quit, true)
+}
+
+func TestApp_CancelLoad(t *testing.T) {
+	const methodName = "app.handleKey"
+
+	var (
+		ctx    = context.Background()
+		client = &fakeClient{block: true, cancelled: make(chan error, 1)}
+		a      = newApp(client, &bytes.Buffer{})
+	)
+
+	pressKeys(ctx, a, 'x', keyEnter)
+	assertEqual(t, methodName, a.loading, true)
+	pressKeys(ctx, a, keyEscape)
+	assertEqual(t, methodName, a.loading, false)
+	assertEqual(t, methodName, a.status, "cancelled")
+	assertEqual(t, methodName, <-client.cancelled, context.Canceled)
+	assertEqual(t, methodName, a.screen, screenSearch)
+}
+
+func TestApp_Run(t *testing.T) {
+	const methodName = "app.run"
+
+	var (
+		out  = &bytes.Buffer{}
+		a    = newApp(newFakeClient(), out)
+		keys = make(chan key, 1)
+	)
+
+	keys <- keyCtrlC
+	err := a.run(context.Background(), keys)
+	assertEqual(t, methodName, err, nil)
+	assertEqual(t, methodName, strings.Contains(out.String(), clearScreen+"yts · Search"), true)
+}
+
+func TestWrap(t *testing.T) {
+	const methodName = "wrap"
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "breaks lines between words and splits long words",
+			text:  "the quick brown fox jumps over the extraordinarily lazy dog",
+			width: 10,
+			want: []string{
+				"the quick", "brown fox", "jumps over", "the", "extraordin", "arily lazy", "dog",
+			},
+		},
+		{
+			name:  "treats zero width as one rune",
+			text:  "ab c",
+			width: 0,
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "treats negative width as one rune",
+			text:  "ab c",
+			width: -3,
+			want:  []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, methodName, wrap(tt.text, tt.width), tt.want)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Yts-tui is a keyboard driven terminal UI for browsing YTS built on the yts.Client
+provided by the github.com/atifcppprogrammer/yflicks-yts package.
+
+Usage:
+
+	yts-tui [-timeout duration] [query]
+
+When a query is provided the search results are shown immediately, otherwise the
+search screen is shown first. Content is loaded asynchronously, pressing escape
+while content is loading cancels the request in progress. The key bindings of every
+screen are shown at its bottom, they are the following.
+
+	search    type a query, enter searches and escape returns to the results
+	results   up/down or j/k select a movie, left/right or p/n change the page,
+	          enter shows its details, / returns to the search and q quits
+	details   c shows the comments, r the reviews and m the magnet links of the
+	          movie, up/down scroll and escape returns to the results
+	comments  left/right or p/n change the page, up/down scroll and escape returns
+	          to the details
+	reviews   up/down scroll and escape returns to the details
+	magnets   up/down select a torrent, enter or y copies its magnet link to the
+	          clipboard and escape returns to the details
+
+Magnet links are copied using the OSC 52 escape sequence which most terminal
+emulators support, the selected magnet link is shown on screen as well for those
+that do not. Ctrl+C quits from every screen.
+*/
+package main
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// A key is a single key press read from the terminal, printable characters are
+// represented by their rune whereas special keys use the constants below.
+type key rune
+
+const (
+	keyEnter key = -(iota + 1)
+	keyBackspace
+	keyEscape
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyCtrlC
+)
+
+const (
+	byteCtrlC     = 0x03
+	byteBackspace = 0x08
+	byteTab       = 0x09
+	byteLineFeed  = 0x0a
+	byteReturn    = 0x0d
+	byteEscape    = 0x1b
+	byteDelete    = 0x7f
+)
+
+// decodeKeys decodes the keys contained in a chunk of bytes read from a terminal
+// in raw mode, an escape byte followed by "[" and a letter within the same chunk
+// is decoded as an arrow key and a lone escape byte as keyEscape.
+func decodeKeys(chunk []byte) []key {
+	keys := make([]key, 0, len(chunk))
+	for 0 < len(chunk) {
+		switch b := chunk[0]; {
+		case b == byteEscape && 3 <= len(chunk) && chunk[1] == '[':
+			arrows := map[byte]key{'A': keyUp, 'B': keyDown, 'C': keyRight, 'D': keyLeft}
+			if k, ok := arrows[chunk[2]]; ok {
+				keys = append(keys, k)
+			}
+			chunk = chunk[3:]
+			continue
+		case b == byteEscape:
+			keys = append(keys, keyEscape)
+		case b == byteReturn || b == byteLineFeed:
+			keys = append(keys, keyEnter)
+		case b == byteBackspace || b == byteDelete:
+			keys = append(keys, keyBackspace)
+		case b == byteCtrlC:
+			keys = append(keys, keyCtrlC)
+		case b == byteTab || 0x20 <= b:
+			r, size := utf8.DecodeRune(chunk)
+			keys = append(keys, key(r))
+			chunk = chunk[size:]
+			continue
+		}
+		chunk = chunk[1:]
+	}
+	return keys
+}
+
+// readKeys reads chunks from the provided reader and sends the keys decoded from
+// them on the returned channel, which is closed once the reader is exhausted.
+func readKeys(r io.Reader) <-chan key {
+	const chunkSize = 64
+
+	keys := make(chan key)
+	go func() {
+		defer close(keys)
+		chunk := make([]byte, chunkSize)
+		for {
+			n, err := r.Read(chunk)
+			for _, k := range decodeKeys(chunk[:n]) {
+				keys <- k
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return keys
+}
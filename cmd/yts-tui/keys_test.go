@@ -0,0 +1,44 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	const methodName = "decodeKeys"
+
+	tests := []struct {
+		name  string
+		chunk []byte
+		want  []key
+	}{
+		{
+			name:  "decodes printable characters",
+			chunk: []byte("ab é"),
+			want:  []key{'a', 'b', ' ', 'é'},
+		},
+		{
+			name:  "decodes special keys",
+			chunk: []byte{byteReturn, byteDelete, byteBackspace, byteCtrlC, byteLineFeed},
+			want:  []key{keyEnter, keyBackspace, keyBackspace, keyCtrlC, keyEnter},
+		},
+		{
+			name:  "decodes arrow keys and lone escape",
+			chunk: []byte("\x1b[A\x1b[B\x1b[C\x1b[Dx\x1b"),
+			want:  []key{keyUp, keyDown, keyRight, keyLeft, 'x', keyEscape},
+		},
+		{
+			name:  "skips unknown escape sequences and control bytes",
+			chunk: []byte("\x1b[Z\x01q"),
+			want:  []key{'q'},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeKeys(tt.chunk); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s() = %v, want %v", methodName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func main() {
+	timeout := flag.Duration("timeout", time.Minute, "the request timeout")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: yts-tui [flags] [query]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	config := yts.DefaultClientConfig()
+	config.RequestTimeout = *timeout
+	client, err := yts.NewClientWithConfig(&config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "yts-tui: %s\n", err)
+		os.Exit(2)
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "yts-tui: %s\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	a := newApp(client, os.Stdout)
+	a.width, a.height = terminalSize(os.Stdin)
+	if a.query = strings.Join(flag.Args(), " "); a.query != "" {
+		a.search(ctx, 1)
+	}
+
+	fmt.Fprint(os.Stdout, enterAlternateScreen)
+	err = a.run(ctx, readKeys(os.Stdin))
+	fmt.Fprint(os.Stdout, leaveAlternateScreen)
+	restore()
+	stop()
+
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "yts-tui: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+const (
+	enterAlternateScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAlternateScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// copyToClipboard copies the provided text to the clipboard using the OSC 52
+// escape sequence, which is supported by most terminal emulators and works over
+// SSH without requiring access to a clipboard service.
+func copyToClipboard(w io.Writer, text string) error {
+	_, err := fmt.Fprintf(w, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+// makeRaw puts the terminal into raw mode, so that keys are read as soon as they
+// are pressed, and returns a function restoring its previous mode.
+func makeRaw(tty *os.File) (func(), error) {
+	state, err := stty(tty, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terminal mode: %w", err)
+	}
+
+	if _, err := stty(tty, "raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("failed to set terminal mode: %w", err)
+	}
+
+	return func() { _, _ = stty(tty, state) }, nil
+}
+
+// terminalSize returns the width and height of the terminal, falling back to the
+// default size when it cannot be determined.
+func terminalSize(tty *os.File) (width, height int) {
+	size, err := stty(tty, "size")
+	if err != nil {
+		return defaultWidth, defaultHeight
+	}
+
+	if _, err := fmt.Sscanf(size, "%d %d", &height, &width); err != nil || width <= 0 || height <= 0 {
+		return defaultWidth, defaultHeight
+	}
+	return width, height
+}
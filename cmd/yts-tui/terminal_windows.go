@@ -0,0 +1,16 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// makeRaw leaves the console untouched on Windows, keys are then only read once
+// enter is pressed.
+func makeRaw(*os.File) (func(), error) {
+	return func() {}, nil
+}
+
+// terminalSize returns the default size on Windows.
+func terminalSize(*os.File) (width, height int) {
+	return defaultWidth, defaultHeight
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// chromeLines is the number of lines used by the title, the status and the help
+	// lines of every screen.
+	chromeLines = 4
+)
+
+var screenHelp = map[screen]string{
+	screenSearch:   "type a query · enter search · esc back · ctrl+c quit",
+	screenResults:  "↑/↓ select · ←/→ page · enter details · / search · q quit",
+	screenDetails:  "c comments · r reviews · m magnets · ↑/↓ scroll · esc back · q quit",
+	screenComments: "←/→ page · ↑/↓ scroll · esc back",
+	screenReviews:  "↑/↓ scroll · esc back",
+	screenMagnets:  "↑/↓ select · enter copy · esc back",
+}
+
+// wrap splits the provided text into lines of at most width runes, breaking lines
+// between words whenever possible. A width below 1, as happens on tiny terminals
+// or for deeply nested replies, is treated as 1.
+func wrap(text string, width int) []string {
+	width = max(1, width)
+	lines := make([]string, 0)
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for width < len([]rune(word)) {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		switch {
+		case line == "":
+			line = word
+		case len([]rune(line))+1+len([]rune(word)) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func truncate(line string, width int) string {
+	if runes := []rune(line); width < len(runes) {
+		return string(runes[:max(0, width-1)]) + "…"
+	}
+	return line
+}
+
+func formatRating(rating float64) string {
+	return strconv.FormatFloat(rating, 'f', 1, 64)
+}
+
+// page returns the lines of the body visible within height lines, starting from
+// the offset line clamped so that the last page remains filled.
+func page(lines []string, offset, height int) []string {
+	offset = max(0, min(offset, len(lines)-height))
+	return lines[offset:min(len(lines), offset+height)]
+}
+
+func (a *app) bodyHeight() int {
+	return max(1, a.height-chromeLines)
+}
+
+func (a *app) title() string {
+	switch a.screen {
+	case screenSearch:
+		return "Search"
+	case screenResults:
+		pages := (a.total + a.filters.Limit - 1) / max(1, a.filters.Limit)
+		return fmt.Sprintf("Results for %q · page %d of %d · %d movies", a.filters.QueryTerm, a.filters.Page, pages, a.total)
+	case screenComments:
+		return fmt.Sprintf("%s · comments page %d", a.details.TitleLong, a.commentsPage)
+	case screenReviews:
+		return fmt.Sprintf("%s · reviews", a.details.TitleLong)
+	case screenMagnets:
+		return fmt.Sprintf("%s · magnet links", a.details.TitleLong)
+	default:
+		return a.details.TitleLong
+	}
+}
+
+func (a *app) searchLines() []string {
+	return []string{"", "  Query: " + a.query + "_", "", "  An empty query lists the latest movies."}
+}
+
+// cursorLines renders one line per item with the item at the cursor highlighted,
+// the lines are scrolled so that the cursor is always visible.
+func (a *app) cursorLines(items []string) []string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		prefix := "  "
+		if i == a.cursor {
+			prefix = "> "
+		}
+		lines[i] = prefix + item
+	}
+	return page(lines, a.cursor-a.bodyHeight()+1, a.bodyHeight())
+}
+
+func (a *app) resultsLines() []string {
+	items := make([]string, len(a.results))
+	for i := range a.results {
+		movie := &a.results[i]
+		qualities := make([]string, len(movie.Torrents))
+		for j := range movie.Torrents {
+			qualities[j] = string(movie.Torrents[j].Quality)
+		}
+		items[i] = fmt.Sprintf("%s (%d) · %s · %s", movie.Title, movie.Year, formatRating(movie.Rating), strings.Join(qualities, " "))
+	}
+
+	if len(items) == 0 {
+		return []string{"  No movies found."}
+	}
+	return a.cursorLines(items)
+}
+
+func (a *app) detailsLines() []string {
+	var (
+		movie  = a.details
+		width  = a.width - 2
+		genres = make([]string, len(movie.Genres))
+	)
+
+	for i, genre := range movie.Genres {
+		genres[i] = string(genre)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Rating %s · %d min · %s · %s", formatRating(movie.Rating), movie.Runtime, movie.Language, movie.ImdbCode),
+		strings.Join(genres, ", "),
+		"",
+	}
+	lines = append(lines, wrap(movie.DescriptionIntro, width)...)
+	lines = append(lines, "", "Torrents")
+	for _, torrent := range movie.Torrents {
+		lines = append(lines, fmt.Sprintf(
+			"  %s %s · %s · %d seeds · %d peers",
+			torrent.Quality, torrent.Type, torrent.Size, torrent.Seeds, torrent.Peers,
+		))
+	}
+
+	if 0 < len(movie.Cast) {
+		lines = append(lines, "", "Cast")
+		for _, cast := range movie.Cast {
+			lines = append(lines, fmt.Sprintf("  %s as %s", cast.Name, cast.CharacterName))
+		}
+	}
+	return lines
+}
+
+func commentLines(comment *yts.SiteMovieComment, width int, indent string) []string {
+	lines := []string{fmt.Sprintf("%s%s · %s · %d likes", indent, comment.Author, comment.Timestamp, comment.LikeCount)}
+	for _, line := range wrap(comment.Content, width-len(indent)) {
+		lines = append(lines, indent+line)
+	}
+	lines = append(lines, "")
+
+	for i := range comment.Replies {
+		lines = append(lines, commentLines(&comment.Replies[i], width, indent+"    ")...)
+	}
+	return lines
+}
+
+func (a *app) commentsLines() []string {
+	if len(a.comments) == 0 {
+		return []string{"No comments."}
+	}
+
+	lines := make([]string, 0)
+	for i := range a.comments {
+		lines = append(lines, commentLines(&a.comments[i], a.width-2, "")...)
+	}
+	return lines
+}
+
+func (a *app) reviewsLines() []string {
+	if len(a.reviews) == 0 {
+		return []string{"No reviews."}
+	}
+
+	lines := make([]string, 0)
+	for i := range a.reviews {
+		review := &a.reviews[i]
+		lines = append(lines, fmt.Sprintf("%s · %s · %s", review.Author, review.Rating, review.Title))
+		lines = append(lines, wrap(review.Content, a.width-2)...)
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func (a *app) magnetsLines() []string {
+	if len(a.magnets) == 0 {
+		return []string{"  No torrents."}
+	}
+
+	items := make([]string, len(a.magnets))
+	for i, m := range a.magnets {
+		items[i] = string(m.quality)
+	}
+
+	lines := a.cursorLines(items)
+	lines = append(lines, "")
+	return append(lines, wrap(a.magnets[a.cursor].link, a.width-2)...)
+}
+
+func (a *app) bodyLines() []string {
+	switch a.screen {
+	case screenSearch:
+		return a.searchLines()
+	case screenResults:
+		return a.resultsLines()
+	case screenMagnets:
+		return a.magnetsLines()
+	}
+
+	var lines []string
+	switch a.screen {
+	case screenComments:
+		lines = a.commentsLines()
+	case screenReviews:
+		lines = a.reviewsLines()
+	default:
+		lines = a.detailsLines()
+	}
+
+	// the scroll offset is clamped here so that scrolling past the end of the
+	// content does not require as many key presses to scroll back.
+	a.scroll = max(0, min(a.scroll, len(lines)-a.bodyHeight()))
+	return page(lines, a.scroll, a.bodyHeight())
+}
+
+// view returns the lines shown on the terminal for the current state of the app.
+func (a *app) view() []string {
+	lines := []string{"yts · " + a.title(), ""}
+	body := a.bodyLines()
+	lines = append(lines, body...)
+	for i := len(body); i < a.bodyHeight(); i++ {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, a.status, screenHelp[a.screen])
+	for i := range lines {
+		lines[i] = truncate(lines[i], a.width)
+	}
+	return lines
+}
+
+// render redraws the whole screen, lines are terminated with "\r\n" since the
+// terminal is in raw mode and does not translate "\n".
+func (a *app) render() {
+	io.WriteString(a.out, clearScreen+strings.Join(a.view(), "\r\n"))
+}
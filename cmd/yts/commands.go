@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// commands lists every command in the order they are shown by the usage message.
+var commands = []*command{
+	{"search", "[query]", "Search movies through the YTS API.", anyArgs, setupSearch},
+	{"details", "<movie>", "Show the details of a movie.", 1, setupDetails},
+	{"suggestions", "<movie>", "List movies suggested for a movie.", 1, setupSuggestions},
+	{"trending", "", "List the movies trending in the past 24 hours.", 0, setupTrending},
+	{"home", "", "List the movies shown on the home page.", 0, setupHome},
+	{"director", "<slug>", "Show the director of a movie.", 1, setupDirector},
+	{"reviews", "<slug>", "List the reviews of a movie.", 1, setupReviews},
+	{"comments", "<slug>", "List a page of the comments of a movie.", 1, setupComments},
+	{"magnets", "<movie>", "List the magnet links of the torrents of a movie.", 1, setupMagnets},
+	{"resolve", "<movie>", "Resolve a movie slug or IMDb ID to its YTS movie ID.", 1, setupResolve},
+}
+
+func lookupCommand(name string) (*command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
+// resolveMovieID returns the YTS movie ID for the provided argument, which may be
+// a YTS movie ID, an IMDb ID or a movie slug.
+func resolveMovieID(ctx context.Context, client *yts.Client, movie string) (int, error) {
+	if movieID, err := strconv.Atoi(movie); err == nil {
+		return movieID, nil
+	}
+
+	if yts.IsIMDbID(movie) {
+		response, err := client.MovieByIMDbIDWithContext(ctx, movie)
+		if err != nil {
+			return 0, err
+		}
+		return response.Data.Movie.ID, nil
+	}
+
+	return client.ResolveMovieSlugToIDWithContext(ctx, movie)
+}
+
+func joinGenres(genres []yts.Genre) string {
+	names := make([]string, len(genres))
+	for i, genre := range genres {
+		names[i] = string(genre)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinQualities(torrents []yts.Torrent) string {
+	qualities := make([]string, len(torrents))
+	for i := range torrents {
+		qualities[i] = string(torrents[i].Quality)
+	}
+	return strings.Join(qualities, ", ")
+}
+
+func moviesOutput(value any, movies []yts.Movie) *output {
+	out := &output{value: value, header: []string{"ID", "TITLE", "YEAR", "RATING", "QUALITIES"}}
+	for i := range movies {
+		out.addRow(
+			strconv.Itoa(movies[i].ID),
+			movies[i].Title,
+			strconv.Itoa(movies[i].Year),
+			strconv.FormatFloat(movies[i].Rating, 'f', 1, 64),
+			joinQualities(movies[i].Torrents),
+		)
+	}
+	return out
+}
+
+func siteMoviesOutput(value any, movies []yts.SiteMovie) *output {
+	out := &output{value: value, header: []string{"SLUG", "TITLE", "YEAR", "RATING", "GENRES"}}
+	for i := range movies {
+		out.addRow(
+			movies[i].Slug,
+			movies[i].Title,
+			strconv.Itoa(movies[i].Year),
+			movies[i].Rating.String(),
+			joinGenres(movies[i].Genres),
+		)
+	}
+	return out
+}
+
+func setupSearch(fs *flag.FlagSet) action {
+	filters := yts.DefaultSearchMoviesFilters("")
+	fs.IntVar(&filters.Limit, "limit", filters.Limit, "the number of movies per page, at most 50")
+	fs.IntVar(&filters.Page, "page", filters.Page, "the page of results to return")
+	fs.IntVar(&filters.MinimumRating, "min-rating", filters.MinimumRating, "the minimum IMDb rating, from 0 to 9")
+	fs.BoolVar(&filters.WithRTRatings, "rt-ratings", filters.WithRTRatings, "include Rotten Tomatoes ratings")
+
+	var (
+		quality = fs.String("quality", string(filters.Quality), "the torrent quality, such as 720p or 2160p")
+		genre   = fs.String("genre", string(filters.Genre), "the genre, such as Action or Sci-Fi")
+		sortBy  = fs.String("sort", string(filters.SortBy), "the sort field, such as title, year or rating")
+		orderBy = fs.String("order", string(filters.OrderBy), "the sort order, asc or desc")
+	)
+
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		filters.QueryTerm = strings.Join(args, " ")
+		filters.Quality = yts.Quality(*quality)
+		filters.Genre = yts.Genre(*genre)
+		filters.SortBy = yts.SortBy(*sortBy)
+		filters.OrderBy = yts.OrderBy(*orderBy)
+
+		response, err := client.SearchMoviesWithContext(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return moviesOutput(response.Data, response.Data.Movies), nil
+	}
+}
+
+func setupDetails(fs *flag.FlagSet) action {
+	filters := yts.DefaultMovieDetailsFilters()
+	fs.BoolVar(&filters.WithImages, "images", filters.WithImages, "include screenshot images")
+	fs.BoolVar(&filters.WithCast, "cast", filters.WithCast, "include the cast")
+
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		movieID, err := resolveMovieID(ctx, client, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := client.MovieDetailsWithContext(ctx, movieID, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		movie := &response.Data.Movie
+		out := &output{value: movie, header: []string{"FIELD", "VALUE"}}
+		out.addRow("id", strconv.Itoa(movie.ID))
+		out.addRow("title", movie.TitleLong)
+		out.addRow("slug", movie.Slug)
+		out.addRow("imdb_code", movie.ImdbCode)
+		out.addRow("rating", strconv.FormatFloat(movie.Rating, 'f', 1, 64))
+		out.addRow("runtime", fmt.Sprintf("%d min", movie.Runtime))
+		out.addRow("genres", joinGenres(movie.Genres))
+		out.addRow("language", movie.Language)
+		out.addRow("qualities", joinQualities(movie.Torrents))
+		for _, cast := range movie.Cast {
+			out.addRow("cast", fmt.Sprintf("%s as %s", cast.Name, cast.CharacterName))
+		}
+		out.addRow("description", movie.DescriptionIntro)
+		return out, nil
+	}
+}
+
+func setupSuggestions(*flag.FlagSet) action {
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		movieID, err := resolveMovieID(ctx, client, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := client.MovieSuggestionsWithContext(ctx, movieID)
+		if err != nil {
+			return nil, err
+		}
+
+		return moviesOutput(response.Data, response.Data.Movies), nil
+	}
+}
+
+func setupTrending(*flag.FlagSet) action {
+	return func(ctx context.Context, client *yts.Client, _ []string) (*output, error) {
+		response, err := client.TrendingMoviesWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return siteMoviesOutput(response.Data, response.Data.Movies), nil
+	}
+}
+
+func setupHome(fs *flag.FlagSet) action {
+	section := fs.String("section", "", "only list the movies of a section, one of popular, latest or upcoming")
+
+	return func(ctx context.Context, client *yts.Client, _ []string) (*output, error) {
+		switch yts.HomePageSection(*section) {
+		case "", yts.HomePageSectionPopular, yts.HomePageSectionLatest, yts.HomePageSectionUpcoming:
+		default:
+			err := fmt.Errorf("section must be one of popular, latest or upcoming, got %q", *section)
+			return nil, fmt.Errorf("%w: %s", yts.ErrValidationFailure, err)
+		}
+
+		response, err := client.HomePageContentWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		data := &response.Data
+		switch yts.HomePageSection(*section) {
+		case "":
+			out := &output{value: data, header: []string{"SECTION", "SLUG", "TITLE", "YEAR", "RATING"}}
+			for _, sm := range data.Popular {
+				out.addRow(string(yts.HomePageSectionPopular), sm.Slug, sm.Title, strconv.Itoa(sm.Year), sm.Rating.String())
+			}
+			for _, sm := range data.Latest {
+				out.addRow(string(yts.HomePageSectionLatest), sm.Slug, sm.Title, strconv.Itoa(sm.Year), sm.Rating.String())
+			}
+			for _, sum := range data.Upcoming {
+				out.addRow(string(yts.HomePageSectionUpcoming), sum.Slug, sum.Title, strconv.Itoa(sum.Year), "")
+			}
+			return out, nil
+		case yts.HomePageSectionPopular:
+			return siteMoviesOutput(data.Popular, data.Popular), nil
+		case yts.HomePageSectionLatest:
+			return siteMoviesOutput(data.Latest, data.Latest), nil
+		default:
+			out := &output{value: data.Upcoming, header: []string{"TITLE", "YEAR", "QUALITY", "PROGRESS"}}
+			for _, sum := range data.Upcoming {
+				out.addRow(sum.Title, strconv.Itoa(sum.Year), string(sum.Quality), fmt.Sprintf("%d%%", sum.Progress))
+			}
+			return out, nil
+		}
+	}
+}
+
+func setupDirector(*flag.FlagSet) action {
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		response, err := client.MovieDirectorWithContext(ctx, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		director := response.Data.Director
+		out := &output{value: director, header: []string{"NAME", "IMAGE"}}
+		out.addRow(director.Name, director.URLSmallImage)
+		return out, nil
+	}
+}
+
+func setupReviews(fs *flag.FlagSet) action {
+	all := fs.Bool("all", false, "list every review instead of those shown on the movie page")
+
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		var (
+			value   any
+			reviews []yts.SiteMovieReview
+		)
+
+		if *all {
+			response, err := client.AllMovieReviewsWithContext(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+			value, reviews = response.Data, response.Data.Reviews
+		} else {
+			response, err := client.MovieReviewsWithContext(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+			value, reviews = response.Data, response.Data.Reviews
+		}
+
+		out := &output{value: value, header: []string{"AUTHOR", "RATING", "TITLE"}}
+		for i := range reviews {
+			out.addRow(reviews[i].Author, reviews[i].Rating.String(), reviews[i].Title)
+		}
+		return out, nil
+	}
+}
+
+func setupComments(fs *flag.FlagSet) action {
+	page := fs.Int("page", 1, "the page of comments to return")
+
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		response, err := client.MovieCommentsWithContext(ctx, args[0], *page)
+		if err != nil {
+			return nil, err
+		}
+
+		out := &output{value: response.Data, header: []string{"AUTHOR", "POSTED", "LIKES", "CONTENT"}}
+		for _, comment := range response.Data.Comments {
+			out.addRow(comment.Author, comment.Timestamp, strconv.Itoa(comment.LikeCount), comment.Content)
+		}
+		return out, nil
+	}
+}
+
+func setupMagnets(fs *flag.FlagSet) action {
+	quality := fs.String("quality", "", "only list the magnet link of a torrent quality, such as 1080p")
+
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		movieID, err := resolveMovieID(ctx, client, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		filters := &yts.MovieDetailsFilters{}
+		response, err := client.MovieDetailsWithContext(ctx, movieID, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			movie   = &response.Data.Movie
+			magnets = client.MagnetLinks(&movie.MoviePartial)
+			out     = &output{header: []string{"QUALITY", "MAGNET"}}
+		)
+
+		if *quality != "" {
+			magnet, ok := magnets[yts.Quality(*quality)]
+			if !ok {
+				err := fmt.Errorf("no %s torrent for movie with ID %d", *quality, movieID)
+				return nil, fmt.Errorf("%w: %s", yts.ErrMovieNotFound, err)
+			}
+			magnets = yts.TorrentMagnets{yts.Quality(*quality): magnet}
+		}
+
+		// the torrents are walked rather than the map so that rows keep their order.
+		for _, torrent := range movie.Torrents {
+			if magnet, ok := magnets[torrent.Quality]; ok {
+				out.addRow(string(torrent.Quality), magnet)
+			}
+		}
+
+		out.value = magnets
+		return out, nil
+	}
+}
+
+func setupResolve(*flag.FlagSet) action {
+	return func(ctx context.Context, client *yts.Client, args []string) (*output, error) {
+		ref := yts.MovieRef{Slug: args[0]}
+		if yts.IsIMDbID(args[0]) {
+			response, err := client.MovieByIMDbIDWithContext(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			movie := &response.Data.Movie
+			ref = yts.MovieRef{ID: movie.ID, Slug: movie.Slug, ImdbCode: movie.ImdbCode}
+		} else {
+			movieID, err := client.ResolveMovieSlugToIDWithContext(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+			ref.ID = movieID
+		}
+
+		out := &output{value: ref, header: []string{"ID", "SLUG", "IMDB_CODE"}}
+		out.addRow(strconv.Itoa(ref.ID), ref.Slug, ref.ImdbCode)
+		return out, nil
+	}
+}
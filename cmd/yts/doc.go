@@ -0,0 +1,41 @@
+/*
+Yts is a command line client for the YTS API and website built on the yts.Client
+provided by the github.com/atifcppprogrammer/yflicks-yts package.
+
+Usage:
+
+	yts [global flags] <command> [flags] [arguments]
+
+The global flags are the following, they may also be provided among the flags of
+the command.
+
+	-format table|json|yaml   the output format (default "table")
+	-timeout duration         the request timeout (default 1m0s)
+	-debug                    log debugging information of the client
+
+The commands are the following, run "yts <command> -h" for the flags of a command.
+Commands accepting a <movie> argument take either a YTS movie ID, a movie slug such
+as "oppenheimer-2023" or an IMDb ID such as "tt15398776".
+
+	search [query]      search movies through the "/api/v2/list_movies.json" endpoint
+	details <movie>     show the details of a movie
+	suggestions <movie> list movies suggested for a movie
+	trending            list the movies trending in the past 24 hours
+	home                list the popular, latest and upcoming movies of the home page
+	director <slug>     show the director of a movie
+	reviews <slug>      list the reviews of a movie
+	comments <slug>     list a page of the comments of a movie
+	magnets <movie>     list the magnet links of the torrents of a movie
+	resolve <movie>     resolve a movie slug or IMDb ID to its YTS movie ID
+
+The exit status of yts identifies the type of failure encountered.
+
+	0  success
+	1  any other failure
+	2  invalid command line usage
+	3  validation failure of the provided arguments or flags
+	4  network failure, including unexpected HTTP response statuses
+	5  failure to scrape or decode the retrieved content
+	6  no movie found for the provided input
+*/
+package main
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+	exitValidation
+	exitNetwork
+	exitScrape
+	exitNotFound
+)
+
+// anyArgs is the value of the nargs field of a command accepting any number of
+// positional arguments.
+const anyArgs = -1
+
+// An action executes a command with its positional arguments, its flags having
+// been captured when the command registered them.
+type action func(ctx context.Context, client *yts.Client, args []string) (*output, error)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	nargs   int
+	setup   func(fs *flag.FlagSet) action
+}
+
+type globalOptions struct {
+	format  string
+	timeout time.Duration
+	debug   bool
+}
+
+// addGlobalFlags registers the global flags on the provided flag set, the current
+// values of opts are used as defaults so that flags parsed before the command
+// name are not reset when they are registered again for the command.
+func addGlobalFlags(fs *flag.FlagSet, opts *globalOptions) {
+	fs.StringVar(&opts.format, "format", opts.format, "the output format, one of table, json or yaml")
+	fs.DurationVar(&opts.timeout, "timeout", opts.timeout, "the request timeout")
+	fs.BoolVar(&opts.debug, "debug", opts.debug, "log debugging information of the client")
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: yts [global flags] <command> [flags] [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-24s %s\n", fmt.Sprintf("%s %s", cmd.name, cmd.args), cmd.summary)
+	}
+	fmt.Fprintln(w, "\nglobal flags:")
+}
+
+func exitCode(err error) int {
+	var (
+		netErr       net.Error
+		syntaxErr    *json.SyntaxError
+		unmarshalErr *json.UnmarshalTypeError
+	)
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, yts.ErrValidationFailure),
+		errors.Is(err, yts.ErrFilterValidationFailure),
+		errors.Is(err, yts.ErrInvalidClientConfig),
+		errors.Is(err, errInvalidFormat):
+		return exitValidation
+	case errors.Is(err, yts.ErrMovieNotFound):
+		return exitNotFound
+	case errors.Is(err, yts.ErrUnexpectedHTTPResponseStatus),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr):
+		return exitNetwork
+	case errors.Is(err, yts.ErrContentRetrievalFailure),
+		errors.As(err, &syntaxErr),
+		errors.As(err, &unmarshalErr):
+		return exitScrape
+	default:
+		return exitFailure
+	}
+}
+
+// run executes the command line provided in args using a client created from the
+// provided config and returns the exit status of the program.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, config yts.ClientConfig) int {
+	opts := &globalOptions{format: formatTable, timeout: config.RequestTimeout}
+	global := flag.NewFlagSet("yts", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	global.Usage = func() {
+		usage(stderr)
+		global.PrintDefaults()
+	}
+
+	addGlobalFlags(global, opts)
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if global.NArg() == 0 {
+		global.Usage()
+		return exitUsage
+	}
+
+	cmd, ok := lookupCommand(global.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "yts: unknown command %q\n", global.Arg(0))
+		global.Usage()
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: yts %s [flags] %s\n\n%s\n\nflags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	addGlobalFlags(fs, opts)
+	act := cmd.setup(fs)
+	if err := fs.Parse(global.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if cmd.nargs != anyArgs && fs.NArg() != cmd.nargs {
+		fmt.Fprintf(stderr, "yts: %s expects %d argument(s), got %d\n", cmd.name, cmd.nargs, fs.NArg())
+		fs.Usage()
+		return exitUsage
+	}
+
+	err := func() error {
+		if err := validateFormat(opts.format); err != nil {
+			return err
+		}
+
+		config.RequestTimeout = opts.timeout
+		config.Debug = opts.debug
+		client, err := yts.NewClientWithConfig(&config)
+		if err != nil {
+			return err
+		}
+
+		out, err := act(ctx, client, fs.Args())
+		if err != nil {
+			return err
+		}
+
+		return out.write(stdout, opts.format)
+	}()
+
+	if err != nil {
+		fmt.Fprintf(stderr, "yts: %s\n", err)
+	}
+
+	return exitCode(err)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, yts.DefaultClientConfig())
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// testdataDir is the testdata directory of the yts package, whose fixtures are
+// shared by the tests of this command.
+const testdataDir = "../../testdata"
+
+type testHandlerConfig struct {
+	pattern    string
+	filename   string
+	statusCode int
+}
+
+func createTestServer(t *testing.T, cfg testHandlerConfig) *httptest.Server {
+	t.Helper()
+	serveMux := &http.ServeMux{}
+	serveMux.HandleFunc(cfg.pattern, func(w http.ResponseWriter, r *http.Request) {
+		if cfg.statusCode != http.StatusOK {
+			w.WriteHeader(cfg.statusCode)
+			return
+		}
+		http.ServeFile(w, r, path.Join(testdataDir, cfg.filename))
+	})
+	return httptest.NewServer(serveMux)
+}
+
+func TestRun(t *testing.T) {
+	const methodName = "run"
+
+	tests := []struct {
+		name       string
+		args       []string
+		handlerCfg testHandlerConfig
+		wantCode   int
+		wantStdout string
+	}{
+		{
+			name:       "prints search results as json",
+			args:       []string{"-format", "json", "search", "oppenheimer"},
+			handlerCfg: testHandlerConfig{"/list_movies.json", "search_movies/ok_response.json", http.StatusOK},
+			wantCode:   exitOK,
+			wantStdout: `"movie_count": 3`,
+		},
+		{
+			name:       "prints search results as table",
+			args:       []string{"search", "-limit", "3", "oppenheimer"},
+			handlerCfg: testHandlerConfig{"/list_movies.json", "search_movies/ok_response.json", http.StatusOK},
+			wantCode:   exitOK,
+			wantStdout: "ID     TITLE  YEAR  RATING  QUALITIES\n57427",
+		},
+		{
+			name:       "prints resolved imdb id as yaml",
+			args:       []string{"resolve", "-format", "yaml", "tt15398776"},
+			handlerCfg: testHandlerConfig{"/list_movies.json", "movie_by_imdb_id/list_movies.json", http.StatusOK},
+			wantCode:   exitOK,
+			wantStdout: "id: 57427\nslug: oppenheimer-2023\nimdb_code: tt15398776\n",
+		},
+		{
+			name:     "exits with usage status for unknown command",
+			args:     []string{"download"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "exits with usage status for missing argument",
+			args:     []string{"details"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "exits with usage status for unknown flag",
+			args:     []string{"search", "-year", "2023"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "exits with validation status for invalid filters",
+			args:     []string{"search", "-limit", "100"},
+			wantCode: exitValidation,
+		},
+		{
+			name:     "exits with validation status for invalid format",
+			args:     []string{"-format", "xml", "trending"},
+			wantCode: exitValidation,
+		},
+		{
+			name:     "exits with validation status for invalid home section",
+			args:     []string{"home", "-section", "trending"},
+			wantCode: exitValidation,
+		},
+		{
+			name:       "exits with network status for unexpected status code",
+			args:       []string{"search"},
+			handlerCfg: testHandlerConfig{"/list_movies.json", "", http.StatusInternalServerError},
+			wantCode:   exitNetwork,
+		},
+		{
+			name:       "exits with scrape status for invalid page",
+			args:       []string{"trending"},
+			handlerCfg: testHandlerConfig{"/trending-movies", "trending_movies/missing_selector.html", http.StatusOK},
+			wantCode:   exitScrape,
+		},
+		{
+			name:       "exits with not found status for unknown imdb id",
+			args:       []string{"resolve", "tt0000001"},
+			handlerCfg: testHandlerConfig{"/list_movies.json", "movie_by_imdb_id/list_movies.json", http.StatusOK},
+			wantCode:   exitNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := yts.DefaultClientConfig()
+			if tt.handlerCfg.pattern != "" {
+				server := createTestServer(t, tt.handlerCfg)
+				serverURL, _ := url.Parse(server.URL)
+				config.APIBaseURL = *serverURL
+				config.SiteURL = *serverURL
+				defer server.Close()
+			}
+
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(context.Background(), tt.args, stdout, stderr, config)
+			if code != tt.wantCode {
+				t.Errorf("%s() = %d, want %d, stderr %q", methodName, code, tt.wantCode, stderr)
+			}
+
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("%s() stdout = %q, want it to contain %q", methodName, stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	const methodName = "writeYAML"
+
+	type nested struct {
+		Name   string   `json:"name"`
+		Tags   []string `json:"tags"`
+		Rating float64  `json:"rating"`
+	}
+
+	value := struct {
+		Title   string         `json:"title"`
+		Year    int            `json:"year"`
+		Quality yts.Quality    `json:"quality"`
+		Note    string         `json:"note"`
+		Empty   []string       `json:"empty"`
+		Extra   map[string]int `json:"extra"`
+		Items   []nested       `json:"items"`
+		Missing *nested        `json:"missing"`
+	}{
+		Title:   "Oppenheimer",
+		Year:    2023,
+		Quality: yts.Quality1080p,
+		Note:    "key: value",
+		Empty:   []string{},
+		Extra:   map[string]int{"seeds": 10},
+		Items:   []nested{{"a", []string{"yes", "x", ".inf", "-.Inf", ".NaN"}, 7.5}},
+	}
+
+	want := strings.Join([]string{
+		"title: Oppenheimer",
+		"year: 2023",
+		`quality: "1080p"`,
+		`note: "key: value"`,
+		"empty: []",
+		"extra:",
+		"  seeds: 10",
+		"items:",
+		"- name: a",
+		"  tags:",
+		`  - "yes"`,
+		"  - x",
+		`  - ".inf"`,
+		`  - "-.Inf"`,
+		`  - ".NaN"`,
+		"  rating: 7.5",
+		"missing: null",
+		"",
+	}, "\n")
+
+	buffer := &bytes.Buffer{}
+	if err := writeYAML(buffer, value); err != nil {
+		t.Fatalf("%s() error = %v", methodName, err)
+	}
+
+	if got := buffer.String(); got != want {
+		t.Errorf("%s() = %q, want %q", methodName, got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// maxCellLength caps the number of characters shown in a single table cell, longer
+// values such as comments are truncated.
+const maxCellLength = 80
+
+var errInvalidFormat = errors.New("invalid_output_format")
+
+func validateFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	default:
+		err := fmt.Errorf("format must be one of table, json or yaml, got %q", format)
+		return fmt.Errorf("%w: %s", errInvalidFormat, err)
+	}
+}
+
+// An output holds the result of a command, the value is written as is for the
+// json and yaml formats whereas the header and rows are used for the table format.
+type output struct {
+	value  any
+	header []string
+	rows   [][]string
+}
+
+func (o *output) addRow(cells ...string) {
+	o.rows = append(o.rows, cells)
+}
+
+func (o *output) write(w io.Writer, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(o.value)
+	case formatYAML:
+		return writeYAML(w, o.value)
+	default:
+		return o.writeTable(w)
+	}
+}
+
+func (o *output) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(o.header, "\t"))
+	for _, row := range o.rows {
+		cells := make([]string, len(row))
+		for i, value := range row {
+			cells[i] = cell(value)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// cell collapses the whitespace of the provided value, so that it fits on a single
+// line of a table, and truncates it to maxCellLength characters.
+func cell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); maxCellLength < len(runes) {
+		value = string(runes[:maxCellLength-3]) + "..."
+	}
+
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A yamlMap is a JSON object decoded with the order of its fields preserved, so
+// that the YAML output lists fields in the same order as the JSON output.
+type yamlMap []yamlField
+
+type yamlField struct {
+	key   string
+	value any
+}
+
+// writeYAML writes the provided value as a YAML document, the value is first
+// encoded as JSON so that the json struct tags and custom marshalers of the yts
+// types are honoured.
+func writeYAML(w io.Writer, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	node, err := decodeYAMLNode(decoder)
+	if err != nil {
+		return err
+	}
+
+	buffer := &bytes.Buffer{}
+	if isYAMLScalar(node) {
+		buffer.WriteString(yamlScalar(node) + "\n")
+	} else {
+		writeYAMLBlock(buffer, node, 0, "")
+	}
+
+	_, err = w.Write(buffer.Bytes())
+	return err
+}
+
+func decodeYAMLNode(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		node := make(yamlMap, 0)
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			key, _ := keyToken.(string)
+			node = append(node, yamlField{key, value})
+		}
+		_, err := decoder.Token()
+		return node, err
+	case json.Delim('['):
+		node := make([]any, 0)
+		for decoder.More() {
+			value, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, value)
+		}
+		_, err := decoder.Token()
+		return node, err
+	default:
+		return token, nil
+	}
+}
+
+func isYAMLScalar(node any) bool {
+	switch node := node.(type) {
+	case yamlMap:
+		return len(node) == 0
+	case []any:
+		return len(node) == 0
+	default:
+		return true
+	}
+}
+
+// writeYAMLBlock writes a non empty map or list indented by the provided number of
+// spaces, the first line is prefixed by firstPrefix instead so that a map can start
+// on the same line as the "- " of the list item holding it.
+func writeYAMLBlock(buffer *bytes.Buffer, node any, indent int, firstPrefix string) {
+	var (
+		prefix = firstPrefix
+		pad    = strings.Repeat(" ", indent)
+	)
+
+	writeChild := func(child any) {
+		if isYAMLScalar(child) {
+			buffer.WriteString(" " + yamlScalar(child) + "\n")
+			return
+		}
+
+		if _, ok := child.(yamlMap); ok {
+			buffer.WriteString("\n")
+			writeYAMLBlock(buffer, child, indent+2, pad+"  ")
+			return
+		}
+
+		buffer.WriteString("\n")
+		writeYAMLBlock(buffer, child, indent, pad)
+	}
+
+	switch node := node.(type) {
+	case yamlMap:
+		for _, field := range node {
+			buffer.WriteString(prefix + yamlString(field.key) + ":")
+			writeChild(field.value)
+			prefix = pad
+		}
+	case []any:
+		for _, item := range node {
+			if isYAMLScalar(item) {
+				buffer.WriteString(prefix + "- " + yamlScalar(item) + "\n")
+			} else {
+				writeYAMLBlock(buffer, item, indent+2, prefix+"- ")
+			}
+			prefix = pad
+		}
+	}
+}
+
+func yamlScalar(node any) string {
+	switch node := node.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(node)
+	case json.Number:
+		return node.String()
+	case string:
+		return yamlString(node)
+	case yamlMap:
+		return "{}"
+	case []any:
+		return "[]"
+	default:
+		return ""
+	}
+}
+
+var (
+	yamlPlainRegex    = regexp.MustCompile(`^[A-Za-z0-9_./(][A-Za-z0-9 _./:?&=%+(),'-]*$`)
+	yamlNumberRegex   = regexp.MustCompile(`^[-+]?(\.?[0-9]|0[xob])`)
+	yamlReservedWords = map[string]bool{
+		"true": true, "false": true, "yes": true, "no": true, "on": true,
+		"off": true, "null": true, "y": true, "n": true, "~": true,
+		".inf": true, "-.inf": true, "+.inf": true, ".nan": true,
+	}
+)
+
+// yamlString returns the provided string as a plain YAML scalar when that is
+// unambiguous and as a double quoted scalar otherwise.
+func yamlString(s string) string {
+	plain := yamlPlainRegex.MatchString(s) &&
+		!strings.HasSuffix(s, " ") &&
+		!strings.Contains(s, ": ") &&
+		!strings.HasSuffix(s, ":") &&
+		!yamlNumberRegex.MatchString(s) &&
+		!yamlReservedWords[strings.ToLower(s)]
+
+	if plain {
+		return s
+	}
+
+	return strconv.Quote(s)
+}
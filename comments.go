@@ -0,0 +1,177 @@
+package yts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var commentTimestampLayouts = []string{
+	"January 2, 2006 at 03:04 pm",
+	"January 2, 2006 at 3:04 pm",
+	"January 2, 2006",
+	"Jan 2, 2006 at 03:04 pm",
+	"Jan 2, 2006",
+}
+
+var relativeTimestampRegex = regexp.MustCompile(
+	`^(a|an|one|\d+)\s+(second|minute|hour|day|week|month|year)s?\s+ago$`,
+)
+
+func parseRelativeTimestamp(timestamp string, reference time.Time) (time.Time, bool) {
+	switch timestamp {
+	case "just now", "now", "moments ago", "a moment ago":
+		return reference, true
+	case "yesterday":
+		return reference.AddDate(0, 0, -1), true
+	}
+
+	matches := relativeTimestampRegex.FindStringSubmatch(timestamp)
+	if matches == nil {
+		return time.Time{}, false
+	}
+
+	amount := 1
+	if n, err := strconv.Atoi(matches[1]); err == nil {
+		amount = n
+	}
+
+	switch matches[2] {
+	case "second":
+		return reference.Add(-time.Duration(amount) * time.Second), true
+	case "minute":
+		return reference.Add(-time.Duration(amount) * time.Minute), true
+	case "hour":
+		return reference.Add(-time.Duration(amount) * time.Hour), true
+	case "day":
+		return reference.AddDate(0, 0, -amount), true
+	case "week":
+		const daysPerWeek = 7
+		return reference.AddDate(0, 0, -amount*daysPerWeek), true
+	case "month":
+		return reference.AddDate(0, -amount, 0), true
+	default:
+		return reference.AddDate(-amount, 0, 0), true
+	}
+}
+
+// ParseCommentTimestamp parses the timestamp of a SiteMovieComment into a
+// time.Time, both absolute timestamps such as "April 30, 2024 at 09:46 am" and
+// relative timestamps such as "3 years ago" are supported. Relative timestamps are
+// resolved against the provided reference time, whose location is also used for
+// interpreting absolute timestamps.
+func ParseCommentTimestamp(timestamp string, reference time.Time) (time.Time, error) {
+	normalized := strings.ToLower(cleanString(timestamp))
+	if postedAt, ok := parseRelativeTimestamp(normalized, reference); ok {
+		return postedAt, nil
+	}
+
+	location := reference.Location()
+	for _, layout := range commentTimestampLayouts {
+		postedAt, err := time.ParseInLocation(layout, normalized, location)
+		if err == nil {
+			return postedAt, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised comment timestamp %q", timestamp)
+}
+
+// SortSiteMovieCommentsByTime sorts the provided comments in place by their
+// PostedAt field in the provided order, comments with equal times keep their
+// original relative order.
+func SortSiteMovieCommentsByTime(comments []SiteMovieComment, order OrderBy) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		if order == OrderByAsc {
+			return comments[i].PostedAt.Before(comments[j].PostedAt)
+		}
+		return comments[i].PostedAt.After(comments[j].PostedAt)
+	})
+}
+
+// FilterSiteMovieCommentsByTime returns the provided comments posted within the
+// [since, until] interval, a zero since or until leaves that end of the interval
+// unbounded. Comments whose PostedAt field is zero are excluded.
+func FilterSiteMovieCommentsByTime(comments []SiteMovieComment, since, until time.Time) []SiteMovieComment {
+	filtered := make([]SiteMovieComment, 0)
+	for _, comment := range comments {
+		switch {
+		case comment.PostedAt.IsZero():
+		case !since.IsZero() && comment.PostedAt.Before(since):
+		case !until.IsZero() && comment.PostedAt.After(until):
+		default:
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// ThreadSiteMovieComments arranges the provided flattened comments, such as those
+// returned by the MovieComments method, into threads using their ID and ParentID
+// fields. The returned slice holds the top level comments with their replies
+// nested in the Replies field, comments whose parent is absent from the provided
+// comments are treated as top level comments. The original order of comments is
+// preserved at every level of nesting.
+func ThreadSiteMovieComments(comments []SiteMovieComment) []SiteMovieComment {
+	var (
+		present  = make(map[int]bool)
+		children = make(map[int][]int)
+		roots    = make([]int, 0)
+	)
+
+	for _, comment := range comments {
+		if comment.ID != 0 {
+			present[comment.ID] = true
+		}
+	}
+
+	for i, comment := range comments {
+		isReply := comment.ParentID != 0 &&
+			comment.ParentID != comment.ID &&
+			present[comment.ParentID]
+		if isReply {
+			children[comment.ParentID] = append(children[comment.ParentID], i)
+		} else {
+			roots = append(roots, i)
+		}
+	}
+
+	visited := make(map[int]bool)
+	var buildThread func(i int) SiteMovieComment
+	buildThread = func(i int) SiteMovieComment {
+		visited[i] = true
+		comment := comments[i]
+		comment.Replies = nil
+		for _, j := range children[comment.ID] {
+			if !visited[j] {
+				comment.Replies = append(comment.Replies, buildThread(j))
+			}
+		}
+		return comment
+	}
+
+	threaded := make([]SiteMovieComment, 0, len(roots))
+	for _, i := range roots {
+		threaded = append(threaded, buildThread(i))
+	}
+
+	return threaded
+}
+
+// FlattenSiteMovieComments is the inverse of ThreadSiteMovieComments, it returns
+// the provided threaded comments in depth first order with each reply directly
+// following its parent, the Replies field of every returned comment is empty.
+func FlattenSiteMovieComments(threaded []SiteMovieComment) []SiteMovieComment {
+	flattened := make([]SiteMovieComment, 0, len(threaded))
+	for _, comment := range threaded {
+		replies := comment.Replies
+		comment.Replies = nil
+		flattened = append(flattened, comment)
+		flattened = append(flattened, FlattenSiteMovieComments(replies)...)
+	}
+
+	return flattened
+}
@@ -0,0 +1,243 @@
+package yts_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestParseCommentTimestamp(t *testing.T) {
+	const methodName = "ParseCommentTimestamp"
+
+	reference := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		want      time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "parses absolute timestamp with time",
+			timestamp: "April 30, 2024 at 09:46 am",
+			want:      time.Date(2024, 4, 30, 9, 46, 0, 0, time.UTC),
+		},
+		{
+			name:      "parses absolute timestamp with afternoon time",
+			timestamp: "January 19, 2024 at 10:44 pm",
+			want:      time.Date(2024, 1, 19, 22, 44, 0, 0, time.UTC),
+		},
+		{
+			name:      "parses absolute timestamp without time",
+			timestamp: "January 29, 2024",
+			want:      time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "parses relative timestamp in minutes",
+			timestamp: "45 minutes ago",
+			want:      reference.Add(-45 * time.Minute),
+		},
+		{
+			name:      "parses relative timestamp with article",
+			timestamp: "an hour ago",
+			want:      reference.Add(-time.Hour),
+		},
+		{
+			name:      "parses relative timestamp in weeks",
+			timestamp: "2 weeks ago",
+			want:      reference.AddDate(0, 0, -14),
+		},
+		{
+			name:      "parses relative timestamp in years",
+			timestamp: "3 Years Ago",
+			want:      reference.AddDate(-3, 0, 0),
+		},
+		{
+			name:      "parses yesterday",
+			timestamp: "yesterday",
+			want:      reference.AddDate(0, 0, -1),
+		},
+		{
+			name:      "returns error for unrecognised timestamp",
+			timestamp: "sometime last spring",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yts.ParseCommentTimestamp(tt.timestamp, reference)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s() error = %v, wantErr %v", methodName, err, tt.wantErr)
+			}
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestClient_MovieCommentsWithContext_RelativeTimestamps(t *testing.T) {
+	const (
+		methodName  = "Client.MovieComments"
+		testdataDir = "movie_comments/relative_timestamps"
+	)
+
+	reference := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	server := createTestServer(
+		t,
+		defaultHandlerConfig(t, "ajax/comments/57427", testdataDir, "comments.html"),
+		defaultHandlerConfig(t, "movies/oppenheimer-2023", testdataDir, "comments_count.html"),
+	)
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	clientCfg := yts.DefaultClientConfig()
+	clientCfg.SiteURL = *serverURL
+	clientCfg.Clock = func() time.Time { return reference }
+
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+	got, err := c.MovieCommentsWithContext(context.Background(), "oppenheimer-2023", 1)
+	assertError(t, methodName, err, nil)
+
+	var (
+		timestamps []string
+		postedAt   []time.Time
+	)
+
+	for _, comment := range got.Data.Comments {
+		timestamps = append(timestamps, comment.Timestamp)
+		postedAt = append(postedAt, comment.PostedAt)
+	}
+
+	assertEqual(t, methodName, timestamps, []string{"2 hours ago", "3 years ago", "a month ago"})
+	assertEqual(t, methodName, postedAt, []time.Time{
+		reference.Add(-2 * time.Hour),
+		reference.AddDate(-3, 0, 0),
+		reference.AddDate(0, -1, 0),
+	})
+}
+
+func TestSortAndFilterSiteMovieCommentsByTime(t *testing.T) {
+	var (
+		reference = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		newest    = yts.SiteMovieComment{Author: "newest", PostedAt: reference.Add(-time.Hour)}
+		middle    = yts.SiteMovieComment{Author: "middle", PostedAt: reference.AddDate(0, -1, 0)}
+		oldest    = yts.SiteMovieComment{Author: "oldest", PostedAt: reference.AddDate(-3, 0, 0)}
+		undated   = yts.SiteMovieComment{Author: "undated"}
+		comments  = []yts.SiteMovieComment{middle, undated, oldest, newest}
+	)
+
+	yts.SortSiteMovieCommentsByTime(comments, yts.OrderByDesc)
+	assertEqual(t, "SortSiteMovieCommentsByTime", comments, []yts.SiteMovieComment{
+		newest, middle, oldest, undated,
+	})
+
+	yts.SortSiteMovieCommentsByTime(comments, yts.OrderByAsc)
+	assertEqual(t, "SortSiteMovieCommentsByTime", comments, []yts.SiteMovieComment{
+		undated, oldest, middle, newest,
+	})
+
+	since := reference.AddDate(-1, 0, 0)
+	got := yts.FilterSiteMovieCommentsByTime(comments, since, time.Time{})
+	assertEqual(t, "FilterSiteMovieCommentsByTime", got, []yts.SiteMovieComment{middle, newest})
+
+	until := reference.AddDate(0, 0, -1)
+	got = yts.FilterSiteMovieCommentsByTime(comments, time.Time{}, until)
+	assertEqual(t, "FilterSiteMovieCommentsByTime", got, []yts.SiteMovieComment{oldest, middle})
+}
+
+func TestClient_MovieCommentsWithContext_Replies(t *testing.T) {
+	const (
+		methodName  = "Client.MovieComments"
+		testdataDir = "movie_comments/ok_response_replies"
+		avatarURL   = "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg"
+	)
+
+	server := createTestServer(
+		t,
+		defaultHandlerConfig(t, "ajax/comments/57427", testdataDir, "comments.html"),
+		defaultHandlerConfig(t, "movies/oppenheimer-2023", testdataDir, "comments_count.html"),
+	)
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	clientCfg := yts.DefaultClientConfig()
+	clientCfg.SiteURL = *serverURL
+
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+	got, err := c.MovieCommentsWithContext(context.Background(), "oppenheimer-2023", 1)
+	assertError(t, methodName, err, nil)
+
+	var (
+		parent = yts.SiteMovieComment{
+			ID:               35774453,
+			Author:           "aaron2023",
+			AuthorProfileURL: "https://yts.mx/user/aaron2023",
+			AvatarURL:        avatarURL,
+			Timestamp:        "April 30, 2024 at 09:46 am",
+			PostedAt:         time.Date(2024, 4, 30, 9, 46, 0, 0, time.Local),
+			Content:          "content-one",
+			LikeCount:        4,
+		}
+		reply = yts.SiteMovieComment{
+			ID:               35774460,
+			ParentID:         35774453,
+			Author:           "AmanS666",
+			AuthorProfileURL: "https://yts.mx/user/amans666",
+			AvatarURL:        avatarURL,
+			Timestamp:        "April 30, 2024 at 10:02 am",
+			PostedAt:         time.Date(2024, 4, 30, 10, 2, 0, 0, time.Local),
+			Content:          "reply-one",
+			LikeCount:        1,
+		}
+		other = yts.SiteMovieComment{
+			ID:               35755966,
+			Author:           "zorg2",
+			AuthorProfileURL: "https://yts.mx/user/zorg2",
+			AvatarURL:        avatarURL,
+			Timestamp:        "January 19, 2024 at 10:44 am",
+			PostedAt:         time.Date(2024, 1, 19, 10, 44, 0, 0, time.Local),
+			Content:          "content-three",
+			LikeCount:        0,
+		}
+	)
+
+	flattened := []yts.SiteMovieComment{parent, reply, other}
+	assertEqual(t, methodName, got.Data.Comments, flattened)
+
+	threadedParent := parent
+	threadedParent.Replies = []yts.SiteMovieComment{reply}
+	threaded := yts.ThreadSiteMovieComments(got.Data.Comments)
+	assertEqual(t, "ThreadSiteMovieComments", threaded, []yts.SiteMovieComment{threadedParent, other})
+	assertEqual(t, "FlattenSiteMovieComments", yts.FlattenSiteMovieComments(threaded), flattened)
+}
+
+func TestThreadSiteMovieComments(t *testing.T) {
+	const methodName = "ThreadSiteMovieComments"
+
+	var (
+		root     = yts.SiteMovieComment{ID: 1}
+		child    = yts.SiteMovieComment{ID: 2, ParentID: 1}
+		grand    = yts.SiteMovieComment{ID: 3, ParentID: 2}
+		orphan   = yts.SiteMovieComment{ID: 4, ParentID: 99}
+		sibling  = yts.SiteMovieComment{ID: 5, ParentID: 1}
+		selfLoop = yts.SiteMovieComment{ID: 6, ParentID: 6}
+	)
+
+	var (
+		wantChild = child
+		wantRoot  = root
+	)
+
+	wantChild.Replies = []yts.SiteMovieComment{grand}
+	wantRoot.Replies = []yts.SiteMovieComment{wantChild, sibling}
+
+	got := yts.ThreadSiteMovieComments([]yts.SiteMovieComment{grand, root, orphan, child, sibling, selfLoop})
+	assertEqual(t, methodName, got, []yts.SiteMovieComment{wantRoot, orphan, selfLoop})
+
+	flattened := yts.FlattenSiteMovieComments(got)
+	assertEqual(t, "FlattenSiteMovieComments", flattened, []yts.SiteMovieComment{
+		root, child, grand, sibling, orphan, selfLoop,
+	})
+}
@@ -0,0 +1,60 @@
+package yts
+
+import "strings"
+
+func castKey(imdbCode, name string) string {
+	if imdbCode != "" {
+		return imdbCode
+	}
+
+	return strings.ToLower(cleanString(name))
+}
+
+// ReconcileCast combines the Cast returned by the MovieDetails method with the
+// cast members scraped by the MovieCrew method. Members are matched by IMDb code,
+// falling back to their name, and empty fields of a Cast are filled in using its
+// matching scraped member. The returned slice holds the provided Cast in its
+// original order followed by scraped members which had no match.
+func ReconcileCast(cast []Cast, scraped []SiteMovieCastMember) []Cast {
+	var (
+		reconciled = make([]Cast, 0, len(cast)+len(scraped))
+		indexByKey = make(map[string]int)
+	)
+
+	for _, member := range cast {
+		reconciled = append(reconciled, member)
+		indexByKey[castKey(member.ImdbCode, member.Name)] = len(reconciled) - 1
+		indexByKey[castKey("", member.Name)] = len(reconciled) - 1
+	}
+
+	for _, member := range scraped {
+		i, ok := indexByKey[castKey(member.ImdbCode, member.Name)]
+		if !ok {
+			i, ok = indexByKey[castKey("", member.Name)]
+		}
+
+		if !ok {
+			reconciled = append(reconciled, Cast{
+				Name:          member.Name,
+				CharacterName: member.CharacterName,
+				ImdbCode:      member.ImdbCode,
+				URLSmallImage: member.URLSmallImage,
+			})
+			indexByKey[castKey(member.ImdbCode, member.Name)] = len(reconciled) - 1
+			continue
+		}
+
+		target := &reconciled[i]
+		if target.CharacterName == "" {
+			target.CharacterName = member.CharacterName
+		}
+		if target.ImdbCode == "" {
+			target.ImdbCode = member.ImdbCode
+		}
+		if target.URLSmallImage == "" {
+			target.URLSmallImage = member.URLSmallImage
+		}
+	}
+
+	return reconciled
+}
@@ -0,0 +1,66 @@
+package yts_test
+
+import (
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestReconcileCast(t *testing.T) {
+	const methodName = "ReconcileCast"
+
+	cast := []yts.Cast{
+		{Name: "Josh Brolin", ImdbCode: "0000982"},
+		{Name: "Javier Bardem", CharacterName: "Anton Chigurh", URLSmallImage: "https://api/nm0000849.jpg"},
+	}
+
+	scraped := []yts.SiteMovieCastMember{
+		{
+			SiteMoviePerson: yts.SiteMoviePerson{
+				Name:          "Josh Brolin",
+				ImdbCode:      "0000982",
+				URLSmallImage: "https://site/nm0000982.jpg",
+			},
+			CharacterName: "Llewelyn Moss",
+		},
+		{
+			SiteMoviePerson: yts.SiteMoviePerson{
+				Name:          "Javier Bardem",
+				ImdbCode:      "0000849",
+				URLSmallImage: "https://site/nm0000849.jpg",
+			},
+			CharacterName: "Chigurh",
+		},
+		{
+			SiteMoviePerson: yts.SiteMoviePerson{
+				Name:     "Tommy Lee Jones",
+				ImdbCode: "0000169",
+			},
+			CharacterName: "Ed Tom Bell",
+		},
+	}
+
+	got := yts.ReconcileCast(cast, scraped)
+	want := []yts.Cast{
+		{
+			Name:          "Josh Brolin",
+			CharacterName: "Llewelyn Moss",
+			ImdbCode:      "0000982",
+			URLSmallImage: "https://site/nm0000982.jpg",
+		},
+		{
+			Name:          "Javier Bardem",
+			CharacterName: "Anton Chigurh",
+			ImdbCode:      "0000849",
+			URLSmallImage: "https://api/nm0000849.jpg",
+		},
+		{
+			Name:          "Tommy Lee Jones",
+			CharacterName: "Ed Tom Bell",
+			ImdbCode:      "0000169",
+		},
+	}
+
+	assertEqual(t, methodName, got, want)
+	assertEqual(t, methodName, yts.ReconcileCast(nil, nil), []yts.Cast{})
+}
@@ -40,6 +40,16 @@ modifying it to suit your needs.
 	config.RequestTimeout = time.Minute * 2
 	client, err := NewClientWithConfig(&config)
 
+Movie slugs, YTS movie IDs and IMDb codes seen in API responses are recorded in a
+resolution index, providing an index backed by a file allows these resolutions to
+be reused across program runs.
+
+	store := yts.NewFileResolutionStore("resolutions.jsonl")
+	index, err := yts.NewResolutionIndex(store)
+	config := DefaultClientConfig()
+	config.ResolutionIndex = index
+	client, err := NewClientWithConfig(&config)
+
 With the the *yts.Client instance instantiated you can leverage the methods provided
 by the client in the following manner.
 
@@ -58,17 +68,42 @@ by the client in the following manner.
 	...
 	response, err := client.HomePageContent()
 	...
+	response, err := client.HomePageContentPartial()
+	...
+	response, err := client.PopularDownloads()
+	...
+	response, err := client.LatestTorrents()
+	...
+	response, err := client.UpcomingMovies()
+	...
 	slug := "oppenheimer-2023"
 	response, err := client.MovieDirector(slug)
 	...
+	response, err := client.MovieCrew(slug)
+	...
+	response, err := client.MoviesByPerson("nm0000982")
+	...
 	response, err := client.MovieReviews(slug)
 	...
+	response, err := client.AllMovieReviews(slug)
+	...
 	page := 1
 	slug := "oppenheimer-2023"
 	response, err := client.MovieComments(slug, page)
 	...
 	slug := "oppenheimer-2023"
 	response, err := client.MovieAdditionalDetails(slug)
+	...
+	response, err := client.MoviePageDetails(slug)
+	...
+	response, err := client.MovieByIMDbID("tt15398776")
+	...
+	trending, err := client.TrendingMovies()
+	movies := trending.Data.Movies
+	enriched, err := client.EnrichSiteMovies(movies, yts.DefaultEnrichOptions())
+	...
+	changes, err := yts.DiffMovies(previous, current, yts.DefaultDiffOptions())
+	changes := yts.DiffHomePageContent(&previous.Data, &current.Data)
 
 See the accompanying example program for a more detailed tutorial on how to use this
 package.
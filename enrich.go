@@ -0,0 +1,274 @@
+package yts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultEnrichConcurrency is the value of the Concurrency field for the
+// EnrichOptions instance returned by the DefaultEnrichOptions() function.
+const DefaultEnrichConcurrency = 4
+
+// An EnrichOptions instance configures how the EnrichSiteMovies and
+// EnrichSiteUpcomingMovies methods of a yts.Client resolve scraped movie cards.
+type EnrichOptions struct {
+	// The maximum number of movie cards which are resolved concurrently, must be
+	// at least 1.
+	Concurrency int
+
+	// The filters passed to the MovieDetails method for each resolved movie card,
+	// DefaultMovieDetailsFilters() is used when this field is nil.
+	Filters *MovieDetailsFilters
+}
+
+// DefaultEnrichOptions returns the default *EnrichOptions used for resolving
+// scraped movie cards into their corresponding MovieDetails.
+func DefaultEnrichOptions() *EnrichOptions {
+	return &EnrichOptions{
+		Concurrency: DefaultEnrichConcurrency,
+		Filters:     DefaultMovieDetailsFilters(),
+	}
+}
+
+func (o *EnrichOptions) validate() error {
+	if o.Concurrency < 1 {
+		return fmt.Errorf("enrich concurrency must be at least 1")
+	}
+
+	return nil
+}
+
+// An EnrichedMovie pairs a scraped movie card with the MovieDetails it resolved
+// to, in the event a movie card could not be resolved the Movie field is nil and
+// the Err field carries the reason for this failure.
+type EnrichedMovie struct {
+	Source SiteMovieBase `json:"source"`
+	Movie  *MovieDetails `json:"movie"`
+	Err    error         `json:"-"`
+}
+
+const (
+	// movieCacheTTL is the duration for which the MovieDetails cached by the
+	// EnrichSiteMovies and EnrichSiteUpcomingMovies methods are reused, after which
+	// they are fetched again so that torrent seeds and peers do not go stale.
+	movieCacheTTL = 10 * time.Minute
+
+	// movieCacheMaxEntries is the maximum number of MovieDetails held by the cache
+	// of a yts.Client.
+	movieCacheMaxEntries = 256
+)
+
+type movieCacheEntry struct {
+	details MovieDetails
+	expires time.Time
+}
+
+// A movieCache holds the MovieDetails fetched while enriching movie cards until
+// they expire, once full the entry expiring first is evicted. It is safe for
+// concurrent use.
+type movieCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]movieCacheEntry
+}
+
+func newMovieCache(ttl time.Duration, maxEntries int) *movieCache {
+	return &movieCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]movieCacheEntry),
+	}
+}
+
+func (mc *movieCache) getDetails(key string, now time.Time) (MovieDetails, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry, ok := mc.entries[key]
+	if !ok {
+		return MovieDetails{}, false
+	}
+
+	if !now.Before(entry.expires) {
+		delete(mc.entries, key)
+		return MovieDetails{}, false
+	}
+
+	return entry.details, true
+}
+
+func (mc *movieCache) putDetails(key string, details *MovieDetails, now time.Time) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, ok := mc.entries[key]; !ok && mc.maxEntries <= len(mc.entries) {
+		mc.evict(now)
+	}
+
+	mc.entries[key] = movieCacheEntry{*details, now.Add(mc.ttl)}
+}
+
+// evict removes every expired entry, or the entry expiring first when none has
+// expired yet.
+func (mc *movieCache) evict(now time.Time) {
+	var (
+		firstKey     string
+		firstExpires time.Time
+	)
+
+	for key, entry := range mc.entries {
+		if !now.Before(entry.expires) {
+			delete(mc.entries, key)
+			continue
+		}
+
+		if firstKey == "" || entry.expires.Before(firstExpires) {
+			firstKey, firstExpires = key, entry.expires
+		}
+	}
+
+	if len(mc.entries) < mc.maxEntries {
+		return
+	}
+
+	delete(mc.entries, firstKey)
+}
+
+func (c *Client) resolveSiteMovieID(ctx context.Context, smb *SiteMovieBase) (int, error) {
+	if smb.Slug == "" {
+		err := fmt.Errorf("movie card %q has no slug", smb.Title)
+		return 0, wrapErr(ErrValidationFailure, err)
+	}
+
+	return c.ResolveMovieSlugToIDWithContext(ctx, smb.Slug)
+}
+
+func (c *Client) resolveSiteUpcomingMovieID(ctx context.Context, smb *SiteMovieBase) (int, error) {
+	imdbCode := imdbIDFromLink(smb.Link)
+	if imdbCode == "" {
+		err := fmt.Errorf("movie card %q has no IMDb link", smb.Title)
+		return 0, wrapErr(ErrValidationFailure, err)
+	}
+
+	if ref, ok := c.index.LookupIMDbCode(imdbCode); ok {
+		return ref.ID, nil
+	}
+
+	response, err := c.MovieByIMDbIDWithContext(ctx, imdbCode)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.Data.Movie.ID, nil
+}
+
+func (c *Client) cachedMovieDetails(ctx context.Context, movieID int, filters *MovieDetailsFilters) (
+	*MovieDetails, error,
+) {
+	cacheKey := fmt.Sprintf("%d?%s", movieID, filters.getQueryString())
+	if details, ok := c.cache.getDetails(cacheKey, c.now()); ok {
+		return &details, nil
+	}
+
+	response, err := c.MovieDetailsWithContext(ctx, movieID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &response.Data.Movie
+	c.cache.putDetails(cacheKey, details, c.now())
+	return details, nil
+}
+
+type movieIDResolver func(ctx context.Context, smb *SiteMovieBase) (int, error)
+
+func (c *Client) enrichMovies(
+	ctx context.Context, sources []SiteMovieBase, resolve movieIDResolver, opts *EnrichOptions,
+) ([]EnrichedMovie, error) {
+	if opts == nil {
+		opts = DefaultEnrichOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	filters := opts.Filters
+	if filters == nil {
+		filters = DefaultMovieDetailsFilters()
+	}
+
+	enriched := make([]EnrichedMovie, len(sources))
+	err := forEachConcurrently(ctx, len(sources), opts.Concurrency, func(i int) {
+		em := &enriched[i]
+		em.Source = sources[i]
+		movieID, err := resolve(ctx, &em.Source)
+		if err != nil {
+			em.Err = err
+			return
+		}
+
+		em.Movie, em.Err = c.cachedMovieDetails(ctx, movieID, filters)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return enriched, nil
+}
+
+// EnrichSiteMoviesWithContext is the same as the EnrichSiteMovies method but
+// requires a context.Context argument to be passed, this context is then passed
+// to the http.NewRequestWithContext calls used for making the network requests.
+func (c *Client) EnrichSiteMoviesWithContext(ctx context.Context, movies []SiteMovie, opts *EnrichOptions) (
+	[]EnrichedMovie, error,
+) {
+	sources := make([]SiteMovieBase, len(movies))
+	for i := range movies {
+		sources[i] = movies[i].SiteMovieBase
+	}
+
+	return c.enrichMovies(ctx, sources, c.resolveSiteMovieID, opts)
+}
+
+// EnrichSiteMovies method resolves the provided scraped movie cards i.e. those
+// returned by the TrendingMovies and HomePageContent methods, into their full
+// MovieDetails, each movie slug is first resolved to its movie ID and the details
+// for that ID are then fetched, both these steps are cached by the client, with
+// the details being fetched again once they are ten minutes old. The
+// returned slice is in the same order as the provided movies, a movie which could
+// not be resolved will have its Err field set, an error is only returned for
+// invalid options or a cancelled context.
+func (c *Client) EnrichSiteMovies(movies []SiteMovie, opts *EnrichOptions) ([]EnrichedMovie, error) {
+	return c.EnrichSiteMoviesWithContext(context.Background(), movies, opts)
+}
+
+// EnrichSiteUpcomingMoviesWithContext is the same as the EnrichSiteUpcomingMovies
+// method but requires a context.Context argument to be passed, this context is
+// then passed to the http.NewRequestWithContext calls used for making the network
+// requests.
+func (c *Client) EnrichSiteUpcomingMoviesWithContext(
+	ctx context.Context, movies []SiteUpcomingMovie, opts *EnrichOptions,
+) ([]EnrichedMovie, error) {
+	sources := make([]SiteMovieBase, len(movies))
+	for i := range movies {
+		sources[i] = movies[i].SiteMovieBase
+	}
+
+	return c.enrichMovies(ctx, sources, c.resolveSiteUpcomingMovieID, opts)
+}
+
+// EnrichSiteUpcomingMovies method is the same as the EnrichSiteMovies method but
+// for the upcoming movie cards returned by the HomePageContent method, since these
+// carry no movie slug, they are resolved using the IMDb code found in their link.
+// Upcoming movies are often not yet available on YTS in which case the Err field
+// of the corresponding EnrichedMovie will wrap ErrMovieNotFound.
+func (c *Client) EnrichSiteUpcomingMovies(movies []SiteUpcomingMovie, opts *EnrichOptions) (
+	[]EnrichedMovie, error,
+) {
+	return c.EnrichSiteUpcomingMoviesWithContext(context.Background(), movies, opts)
+}
@@ -0,0 +1,188 @@
+package yts_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func createEnrichTestServer(t *testing.T, requestCount *int32) *url.URL {
+	t.Helper()
+	const testdataDir = "enrich_movies"
+	server := createTestServer(
+		t,
+		testHTTPHandlerConfig{
+			filename:   path.Join(testdataDir, "movie_page.html"),
+			pattern:    "/movies/",
+			statusCode: http.StatusOK,
+		},
+		defaultHandlerConfig(t, "movie_details.json", testdataDir, "movie_details.json"),
+		defaultHandlerConfig(t, "list_movies.json", testdataDir, "list_movies.json"),
+	)
+
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requestCount, 1)
+		handler.ServeHTTP(w, r)
+	})
+
+	t.Cleanup(server.Close)
+	serverURL, _ := url.Parse(server.URL)
+	return serverURL
+}
+
+func TestClient_EnrichSiteMoviesWithContext(t *testing.T) {
+	const methodName = "Client.EnrichSiteMovies"
+
+	var (
+		requestCount int32
+		serverURL    = createEnrichTestServer(t, &requestCount)
+		clientCfg    = yts.DefaultClientConfig()
+	)
+
+	clientCfg.SiteURL = *serverURL
+	clientCfg.APIBaseURL = *serverURL
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+
+	movies := []yts.SiteMovie{
+		{SiteMovieBase: yts.SiteMovieBase{Slug: "oppenheimer-2023", Title: "Oppenheimer"}},
+		{SiteMovieBase: yts.SiteMovieBase{Slug: "", Title: "No Slug"}},
+		{SiteMovieBase: yts.SiteMovieBase{Slug: "oppenheimer-2023", Title: "Oppenheimer"}},
+	}
+
+	got, err := c.EnrichSiteMoviesWithContext(context.Background(), movies, yts.DefaultEnrichOptions())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, len(got), len(movies))
+
+	wantMovie := &yts.MovieDetails{
+		MoviePartial: yts.MoviePartial{
+			ID:       57427,
+			ImdbCode: "tt15398776",
+			Slug:     "oppenheimer-2023",
+			Title:    "Oppenheimer",
+		},
+	}
+
+	for _, i := range []int{0, 2} {
+		assertError(t, methodName, got[i].Err, nil)
+		assertEqual(t, methodName, got[i].Movie, wantMovie)
+		assertEqual(t, methodName, got[i].Source, movies[i].SiteMovieBase)
+	}
+
+	assertError(t, methodName, got[1].Err, yts.ErrValidationFailure)
+	assertEqual(t, methodName, got[1].Movie, (*yts.MovieDetails)(nil))
+
+	countBefore := atomic.LoadInt32(&requestCount)
+	_, err = c.EnrichSiteMoviesWithContext(context.Background(), movies[:1], nil)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, atomic.LoadInt32(&requestCount), countBefore)
+}
+
+func TestClient_EnrichSiteMoviesWithContext_CacheExpiry(t *testing.T) {
+	const methodName = "Client.EnrichSiteMovies"
+
+	var (
+		requestCount int32
+		serverURL    = createEnrichTestServer(t, &requestCount)
+		clientCfg    = yts.DefaultClientConfig()
+		now          = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	clientCfg.SiteURL = *serverURL
+	clientCfg.APIBaseURL = *serverURL
+	clientCfg.Clock = func() time.Time { return now }
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+
+	movies := []yts.SiteMovie{
+		{SiteMovieBase: yts.SiteMovieBase{Slug: "oppenheimer-2023", Title: "Oppenheimer"}},
+	}
+
+	_, err := c.EnrichSiteMoviesWithContext(context.Background(), movies, nil)
+	assertError(t, methodName, err, nil)
+
+	countBefore := atomic.LoadInt32(&requestCount)
+	now = now.Add(time.Minute)
+	_, err = c.EnrichSiteMoviesWithContext(context.Background(), movies, nil)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, atomic.LoadInt32(&requestCount), countBefore)
+
+	// Expired details are fetched again, while the slug stays resolved.
+	now = now.Add(time.Hour)
+	_, err = c.EnrichSiteMoviesWithContext(context.Background(), movies, nil)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, atomic.LoadInt32(&requestCount), countBefore+1)
+}
+
+func TestClient_EnrichSiteMoviesWithContext_Validation(t *testing.T) {
+	const methodName = "Client.EnrichSiteMovies"
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	movies := []yts.SiteMovie{
+		{SiteMovieBase: yts.SiteMovieBase{Slug: "oppenheimer-2023"}},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		opts    *yts.EnrichOptions
+		wantErr error
+	}{
+		{
+			name:    "returns error for concurrency less than 1",
+			ctx:     context.Background(),
+			opts:    &yts.EnrichOptions{Concurrency: 0},
+			wantErr: yts.ErrValidationFailure,
+		},
+		{
+			name:    "returns error when context is cancelled",
+			ctx:     cancelledCtx,
+			opts:    yts.DefaultEnrichOptions(),
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := yts.NewClient()
+			got, err := c.EnrichSiteMoviesWithContext(tt.ctx, movies, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, ([]yts.EnrichedMovie)(nil))
+		})
+	}
+}
+
+func TestClient_EnrichSiteUpcomingMoviesWithContext(t *testing.T) {
+	const methodName = "Client.EnrichSiteUpcomingMovies"
+
+	var (
+		requestCount int32
+		serverURL    = createEnrichTestServer(t, &requestCount)
+		clientCfg    = yts.DefaultClientConfig()
+	)
+
+	clientCfg.SiteURL = *serverURL
+	clientCfg.APIBaseURL = *serverURL
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+
+	movies := []yts.SiteUpcomingMovie{
+		{SiteMovieBase: yts.SiteMovieBase{Link: "https://www.imdb.com/title/tt15398776/"}},
+		{SiteMovieBase: yts.SiteMovieBase{Link: "https://www.imdb.com/title/tt0101507/"}},
+		{SiteMovieBase: yts.SiteMovieBase{Link: "https://yts.mx/movies/oppenheimer-2023"}},
+	}
+
+	got, err := c.EnrichSiteUpcomingMoviesWithContext(context.Background(), movies, nil)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, len(got), len(movies))
+
+	assertError(t, methodName, got[0].Err, nil)
+	assertEqual(t, methodName, got[0].Movie.ID, 57427)
+	assertError(t, methodName, got[1].Err, yts.ErrMovieNotFound)
+	assertError(t, methodName, got[2].Err, yts.ErrValidationFailure)
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A column is a named value of the rows written by a Writer, the torrent is nil
+// unless the row was written for a torrent of a record with TorrentsPerRow.
+type column[T any] struct {
+	name  string
+	value func(record T, torrent *yts.Torrent) any
+}
+
+func field[T any](name string, value func(record T) any) column[T] {
+	return column[T]{name, func(record T, _ *yts.Torrent) any { return value(record) }}
+}
+
+func partialColumns[T any](partial func(record T) *yts.MoviePartial) []column[T] {
+	return []column[T]{
+		field("id", func(r T) any { return partial(r).ID }),
+		field("url", func(r T) any { return partial(r).URL }),
+		field("imdb_code", func(r T) any { return partial(r).ImdbCode }),
+		field("title", func(r T) any { return partial(r).Title }),
+		field("title_english", func(r T) any { return partial(r).TitleEnglish }),
+		field("title_long", func(r T) any { return partial(r).TitleLong }),
+		field("slug", func(r T) any { return partial(r).Slug }),
+		field("year", func(r T) any { return partial(r).Year }),
+		field("rating", func(r T) any { return partial(r).Rating }),
+		field("runtime", func(r T) any { return partial(r).Runtime }),
+		field("genres", func(r T) any { return partial(r).Genres }),
+		field("description_full", func(r T) any { return partial(r).DescriptionFull }),
+		field("yt_trailer_code", func(r T) any { return partial(r).YtTrailerCode }),
+		field("language", func(r T) any { return partial(r).Language }),
+		field("mpa_rating", func(r T) any { return partial(r).MpaRating }),
+		field("background_image", func(r T) any { return partial(r).BackgroundImage }),
+		field("background_image_original", func(r T) any { return partial(r).BackgroundImageOriginal }),
+		field("small_cover_image", func(r T) any { return partial(r).SmallCoverImage }),
+		field("medium_cover_image", func(r T) any { return partial(r).MediumCoverImage }),
+		field("large_cover_image", func(r T) any { return partial(r).LargeCoverImage }),
+		field("date_uploaded", func(r T) any { return partial(r).DateUploaded }),
+		field("date_uploaded_unix", func(r T) any { return partial(r).DateUploadedUnix }),
+	}
+}
+
+func movieColumns() []column[yts.Movie] {
+	partial := func(m yts.Movie) *yts.MoviePartial { return &m.MoviePartial }
+	return append(partialColumns(partial),
+		field("summary", func(m yts.Movie) any { return m.Summary }),
+		field("synopsis", func(m yts.Movie) any { return m.Synopsis }),
+		field("state", func(m yts.Movie) any { return m.State }),
+	)
+}
+
+func movieDetailsColumns() []column[yts.MovieDetails] {
+	partial := func(m yts.MovieDetails) *yts.MoviePartial { return &m.MoviePartial }
+	return append(partialColumns(partial),
+		field("like_count", func(m yts.MovieDetails) any { return m.LikeCount }),
+		field("description_intro", func(m yts.MovieDetails) any { return m.DescriptionIntro }),
+		field("medium_screenshot_image1", func(m yts.MovieDetails) any { return m.MediumScreenshotImage1 }),
+		field("medium_screenshot_image2", func(m yts.MovieDetails) any { return m.MediumScreenshotImage2 }),
+		field("medium_screenshot_image3", func(m yts.MovieDetails) any { return m.MediumScreenshotImage3 }),
+		field("large_screenshot_image1", func(m yts.MovieDetails) any { return m.LargeScreenshotImage1 }),
+		field("large_screenshot_image2", func(m yts.MovieDetails) any { return m.LargeScreenshotImage2 }),
+		field("large_screenshot_image3", func(m yts.MovieDetails) any { return m.LargeScreenshotImage3 }),
+		field("cast", func(m yts.MovieDetails) any { return m.Cast }),
+	)
+}
+
+func siteMovieColumns() []column[yts.SiteMovie] {
+	return []column[yts.SiteMovie]{
+		field("slug", func(m yts.SiteMovie) any { return m.Slug }),
+		field("title", func(m yts.SiteMovie) any { return m.Title }),
+		field("year", func(m yts.SiteMovie) any { return m.Year }),
+		field("link", func(m yts.SiteMovie) any { return m.Link }),
+		field("image", func(m yts.SiteMovie) any { return m.Image }),
+		field("genres", func(m yts.SiteMovie) any { return m.Genres }),
+		field("rating", func(m yts.SiteMovie) any { return m.Rating }),
+	}
+}
+
+func commentColumns() []column[yts.SiteMovieComment] {
+	return []column[yts.SiteMovieComment]{
+		field("id", func(c yts.SiteMovieComment) any { return c.ID }),
+		field("parent_id", func(c yts.SiteMovieComment) any { return c.ParentID }),
+		field("author", func(c yts.SiteMovieComment) any { return c.Author }),
+		field("author_profile_url", func(c yts.SiteMovieComment) any { return c.AuthorProfileURL }),
+		field("avatar_url", func(c yts.SiteMovieComment) any { return c.AvatarURL }),
+		field("timestamp", func(c yts.SiteMovieComment) any { return c.Timestamp }),
+		field("posted_at", func(c yts.SiteMovieComment) any { return c.PostedAt }),
+		field("content", func(c yts.SiteMovieComment) any { return c.Content }),
+		field("like_count", func(c yts.SiteMovieComment) any { return c.LikeCount }),
+	}
+}
+
+// A torrentField is a field of a yts.Torrent flattened into the columns of a row,
+// either as "torrent_<name>" with TorrentsPerRow or as "torrent_<quality>_<name>"
+// with TorrentsPerQuality.
+type torrentField struct {
+	name  string
+	value func(t *yts.Torrent) any
+}
+
+var torrentFields = []torrentField{
+	{"quality", func(t *yts.Torrent) any { return t.Quality }},
+	{"type", func(t *yts.Torrent) any { return t.Type }},
+	{"video_codec", func(t *yts.Torrent) any { return t.VideoCodec }},
+	{"size", func(t *yts.Torrent) any { return t.Size }},
+	{"size_bytes", func(t *yts.Torrent) any { return t.SizeBytes }},
+	{"seeds", func(t *yts.Torrent) any { return t.Seeds }},
+	{"peers", func(t *yts.Torrent) any { return t.Peers }},
+	{"hash", func(t *yts.Torrent) any { return t.Hash }},
+	{"url", func(t *yts.Torrent) any { return t.URL }},
+	{"date_uploaded", func(t *yts.Torrent) any { return t.DateUploaded }},
+}
+
+func perRowTorrentColumns[T any]() []column[T] {
+	columns := make([]column[T], 0, len(torrentFields))
+	for _, f := range torrentFields {
+		value := f.value
+		columns = append(columns, column[T]{
+			name: "torrent_" + f.name,
+			value: func(_ T, torrent *yts.Torrent) any {
+				if torrent == nil {
+					return nil
+				}
+				return value(torrent)
+			},
+		})
+	}
+	return columns
+}
+
+func perQualityTorrentColumns[T any](torrents func(record T) []yts.Torrent,
+	qualities []yts.Quality) []column[T] {
+	columns := make([]column[T], 0, len(qualities)*len(torrentFields))
+	for _, quality := range qualities {
+		quality := quality
+		for _, f := range torrentFields {
+			if f.name == "quality" {
+				continue
+			}
+
+			value := f.value
+			columns = append(columns, column[T]{
+				name: fmt.Sprintf("torrent_%s_%s", quality, f.name),
+				value: func(record T, _ *yts.Torrent) any {
+					torrent := bestTorrent(torrents(record), quality)
+					if torrent == nil {
+						return nil
+					}
+					return value(torrent)
+				},
+			})
+		}
+	}
+	return columns
+}
+
+// bestTorrent returns the torrent of the provided quality with the most seeds, or
+// nil when none of the torrents are of that quality.
+func bestTorrent(torrents []yts.Torrent, quality yts.Quality) *yts.Torrent {
+	var best *yts.Torrent
+	for i := range torrents {
+		torrent := &torrents[i]
+		if torrent.Quality == quality && (best == nil || best.Seeds < torrent.Seeds) {
+			best = torrent
+		}
+	}
+	return best
+}
+
+// formatCSV formats the value of a column as a CSV field, lists are joined with
+// ", " and zero times are left empty.
+func formatCSV(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []yts.Genre:
+		genres := make([]string, 0, len(v))
+		for _, genre := range v {
+			genres = append(genres, string(genre))
+		}
+		return strings.Join(genres, ", ")
+	case []yts.Cast:
+		cast := make([]string, 0, len(v))
+		for _, c := range v {
+			if c.CharacterName == "" {
+				cast = append(cast, c.Name)
+				continue
+			}
+			cast = append(cast, fmt.Sprintf("%s as %s", c.Name, c.CharacterName))
+		}
+		return strings.Join(cast, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatJSON returns the value of a column as encoded in JSON Lines, zero times
+// are encoded as null.
+func formatJSON(value any) any {
+	if t, ok := value.(time.Time); ok && t.IsZero() {
+		return nil
+	}
+	return value
+}
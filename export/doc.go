@@ -0,0 +1,41 @@
+/*
+Package export writes movies and comments returned by a yts.Client to CSV and JSON
+Lines files for analysis in spreadsheets and data tools.
+
+A Writer is created for the record type being exported, yts.Movie,
+yts.MovieDetails, yts.SiteMovie or yts.SiteMovieComment, and writes a row per
+record with the Columns selected by its Options, or every column available when
+none are selected.
+
+	w, err := export.NewMovieWriter(os.Stdout, &export.Options{
+		Format:   export.FormatCSV,
+		Columns:  []string{"title", "year", "rating", "torrent_quality", "torrent_hash"},
+		Torrents: export.TorrentsPerRow,
+	})
+	...
+	err = w.Write(response.Data.Movies...)
+	...
+	err = w.Flush()
+
+The torrents of movies are flattened into either a row per torrent with
+TorrentsPerRow, or into a set of columns per quality with TorrentsPerQuality, such
+as "torrent_720p_seeds" and "torrent_1080p_seeds", which keeps a row per movie.
+
+Records are written as they arrive, so a Writer can be fed one page of results at
+a time. The WriteSearchResults and WriteComments functions do exactly that, walking
+every page of search results or comments and writing each page as it is received.
+
+	filters := yts.DefaultSearchMoviesFilters("batman")
+	count, err := export.WriteSearchResults(ctx, client, filters, w)
+
+Movies can be exported for media servers as well, WriteNFO writes the Kodi movie
+.nfo document of a yts.MovieDetails, which Jellyfin reads too, while NFOPathsForVideo
+and NFOPathsForFolder return the paths at which the .nfo, poster and fanart files of
+a movie are looked for.
+
+	paths := export.NFOPathsForVideo("Oppenheimer (2023)/Oppenheimer (2023).mp4")
+	file, err := os.Create(paths.NFO)
+	...
+	err = export.WriteNFO(file, &response.Data.Movie)
+*/
+package export
@@ -0,0 +1,209 @@
+package export
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// youTubeTrailerURL is the URL of a YouTube trailer playable by Kodi through its
+// YouTube add-on, which Jellyfin recognizes as a YouTube trailer as well.
+const youTubeTrailerURL = "plugin://plugin.video.youtube/play/?video_id="
+
+// An NFO is the Kodi movie .nfo document for a movie, which is read by Kodi and
+// Jellyfin alike as the metadata of a movie file.
+type NFO struct {
+	XMLName   xml.Name      `xml:"movie"`
+	Title     string        `xml:"title"`
+	Ratings   *NFORatings   `xml:"ratings,omitempty"`
+	Outline   string        `xml:"outline,omitempty"`
+	Plot      string        `xml:"plot,omitempty"`
+	Runtime   int           `xml:"runtime,omitempty"`
+	Thumbs    []NFOThumb    `xml:"thumb"`
+	Fanart    *NFOFanart    `xml:"fanart,omitempty"`
+	MPAA      string        `xml:"mpaa,omitempty"`
+	UniqueIDs []NFOUniqueID `xml:"uniqueid"`
+	Genres    []string      `xml:"genre"`
+	Year      int           `xml:"year,omitempty"`
+	Trailer   string        `xml:"trailer,omitempty"`
+	Actors    []NFOActor    `xml:"actor"`
+}
+
+// An NFORatings holds the ratings of an NFO.
+type NFORatings struct {
+	Ratings []NFORating `xml:"rating"`
+}
+
+// An NFORating is a rating of an NFO, the rating of YTS movies being their IMDb
+// rating out of 10.
+type NFORating struct {
+	Name    string  `xml:"name,attr"`
+	Max     int     `xml:"max,attr"`
+	Default bool    `xml:"default,attr"`
+	Value   float64 `xml:"value"`
+}
+
+// An NFOThumb is an image of an NFO, such as the poster of the movie.
+type NFOThumb struct {
+	Aspect string `xml:"aspect,attr,omitempty"`
+	URL    string `xml:",chardata"`
+}
+
+// An NFOFanart holds the backdrop images of an NFO.
+type NFOFanart struct {
+	Thumbs []NFOThumb `xml:"thumb"`
+}
+
+// An NFOUniqueID is an ID of the movie of an NFO with an external database, the
+// IMDb code being the default one used by scrapers.
+type NFOUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr,omitempty"`
+	ID      string `xml:",chardata"`
+}
+
+// An NFOActor is a cast member of the movie of an NFO, listed in Order.
+type NFOActor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role,omitempty"`
+	Order int    `xml:"order"`
+	Thumb string `xml:"thumb,omitempty"`
+}
+
+// NewNFO creates the *NFO for the provided movie, with the DescriptionFull of the
+// movie as its plot, the IMDb code and YTS ID of the movie as its unique IDs, the
+// largest cover image available as its poster and the YtTrailerCode of the movie as
+// a YouTube trailer.
+func NewNFO(movie *yts.MovieDetails) *NFO {
+	nfo := &NFO{
+		Title:   movie.Title,
+		Outline: movie.DescriptionIntro,
+		Plot:    movie.DescriptionFull,
+		Runtime: movie.Runtime,
+		MPAA:    movie.MpaRating,
+		Year:    movie.Year,
+	}
+
+	if movie.Rating != 0 {
+		nfo.Ratings = &NFORatings{
+			Ratings: []NFORating{{Name: "imdb", Max: 10, Default: true, Value: movie.Rating}},
+		}
+	}
+
+	if poster := firstNonEmpty(movie.LargeCoverImage, movie.MediumCoverImage,
+		movie.SmallCoverImage); poster != "" {
+		nfo.Thumbs = []NFOThumb{{Aspect: "poster", URL: poster}}
+	}
+
+	if fanart := firstNonEmpty(movie.BackgroundImageOriginal,
+		movie.BackgroundImage); fanart != "" {
+		nfo.Fanart = &NFOFanart{Thumbs: []NFOThumb{{URL: fanart}}}
+	}
+
+	if movie.ImdbCode != "" {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, NFOUniqueID{Type: "imdb", Default: true, ID: movie.ImdbCode})
+	}
+	if movie.ID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, NFOUniqueID{Type: "yts", ID: strconv.Itoa(movie.ID)})
+	}
+
+	for _, genre := range movie.Genres {
+		nfo.Genres = append(nfo.Genres, string(genre))
+	}
+
+	if movie.YtTrailerCode != "" {
+		nfo.Trailer = youTubeTrailerURL + movie.YtTrailerCode
+	}
+
+	for i, cast := range movie.Cast {
+		nfo.Actors = append(nfo.Actors, NFOActor{
+			Name:  cast.Name,
+			Role:  cast.CharacterName,
+			Order: i,
+			Thumb: cast.URLSmallImage,
+		})
+	}
+
+	return nfo
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// WriteTo implements the io.WriterTo interface, writing the NFO as an indented
+// XML document.
+func (n *NFO) WriteTo(w io.Writer) (int64, error) {
+	payload, err := xml.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	document := xml.Header + string(payload) + "\n"
+	written, err := io.WriteString(w, document)
+	return int64(written), err
+}
+
+// WriteNFO writes the NFO created by NewNFO for the provided movie to w.
+func WriteNFO(w io.Writer, movie *yts.MovieDetails) error {
+	_, err := NewNFO(movie).WriteTo(w)
+	return err
+}
+
+// NFOPaths are the paths at which Kodi and Jellyfin look for the NFO, poster and
+// fanart of a movie. YTS images are JPEG images, hence the ".jpg" extensions.
+type NFOPaths struct {
+	NFO    string
+	Poster string
+	Fanart string
+}
+
+// NFOPathsForVideo returns the NFOPaths of the movie with the provided video file,
+// the files are named after the video file, so that "Oppenheimer (2023).mp4" has
+// "Oppenheimer (2023).nfo", "Oppenheimer (2023)-poster.jpg" and
+// "Oppenheimer (2023)-fanart.jpg" alongside it.
+func NFOPathsForVideo(videoPath string) NFOPaths {
+	base := strings.TrimSuffix(videoPath, filepath.Ext(videoPath))
+	return NFOPaths{
+		NFO:    base + ".nfo",
+		Poster: base + "-poster.jpg",
+		Fanart: base + "-fanart.jpg",
+	}
+}
+
+// NFOPathsForFolder returns the NFOPaths of the movie stored in the provided folder
+// of its own, the files being "movie.nfo", "poster.jpg" and "fanart.jpg".
+func NFOPathsForFolder(dir string) NFOPaths {
+	return NFOPaths{
+		NFO:    filepath.Join(dir, "movie.nfo"),
+		Poster: filepath.Join(dir, "poster.jpg"),
+		Fanart: filepath.Join(dir, "fanart.jpg"),
+	}
+}
+
+// NFOFolderName returns the name of the folder conventionally holding the provided
+// movie, such as "Oppenheimer (2023)", with the characters disallowed in file names
+// by common file systems removed.
+func NFOFolderName(movie *yts.MoviePartial) string {
+	title := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) || r < ' ' {
+			return -1
+		}
+		return r
+	}, movie.Title)
+
+	title = strings.TrimRight(strings.TrimSpace(title), ".")
+	if movie.Year == 0 {
+		return title
+	}
+	return title + " (" + strconv.Itoa(movie.Year) + ")"
+}
@@ -0,0 +1,145 @@
+package export_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/export"
+)
+
+func TestWriteNFO(t *testing.T) {
+	const methodName = "WriteNFO"
+
+	movie := &yts.MovieDetails{DescriptionIntro: "Intro & more."}
+	movie.ID = 57427
+	movie.ImdbCode = "tt15398776"
+	movie.Title = "Oppenheimer"
+	movie.Year = 2023
+	movie.Rating = 8.4
+	movie.Runtime = 181
+	movie.Genres = []yts.Genre{yts.GenreBiography, yts.GenreDrama}
+	movie.DescriptionFull = "The story of J. Robert Oppenheimer."
+	movie.YtTrailerCode = "uYPbbksJxIg"
+	movie.MpaRating = "R"
+	movie.MediumCoverImage = "https://img.yts.mx/medium-cover.jpg"
+	movie.LargeCoverImage = "https://img.yts.mx/large-cover.jpg"
+	movie.BackgroundImage = "https://img.yts.mx/background.jpg"
+	movie.Cast = []yts.Cast{
+		{Name: "Cillian Murphy", CharacterName: "J. Robert Oppenheimer", URLSmallImage: "https://img.yts.mx/cillian.jpg"},
+		{Name: "Emily Blunt"},
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<movie>
+  <title>Oppenheimer</title>
+  <ratings>
+    <rating name="imdb" max="10" default="true">
+      <value>8.4</value>
+    </rating>
+  </ratings>
+  <outline>Intro &amp; more.</outline>
+  <plot>The story of J. Robert Oppenheimer.</plot>
+  <runtime>181</runtime>
+  <thumb aspect="poster">https://img.yts.mx/large-cover.jpg</thumb>
+  <fanart>
+    <thumb>https://img.yts.mx/background.jpg</thumb>
+  </fanart>
+  <mpaa>R</mpaa>
+  <uniqueid type="imdb" default="true">tt15398776</uniqueid>
+  <uniqueid type="yts">57427</uniqueid>
+  <genre>Biography</genre>
+  <genre>Drama</genre>
+  <year>2023</year>
+  <trailer>plugin://plugin.video.youtube/play/?video_id=uYPbbksJxIg</trailer>
+  <actor>
+    <name>Cillian Murphy</name>
+    <role>J. Robert Oppenheimer</role>
+    <order>0</order>
+    <thumb>https://img.yts.mx/cillian.jpg</thumb>
+  </actor>
+  <actor>
+    <name>Emily Blunt</name>
+    <order>1</order>
+  </actor>
+</movie>
+`
+
+	got := bytes.Buffer{}
+	assertError(t, methodName, export.WriteNFO(&got, movie), nil)
+	assertEqual(t, methodName, got.String(), want)
+
+	got.Reset()
+	assertError(t, methodName, export.WriteNFO(&got, &yts.MovieDetails{}), nil)
+	assertEqual(t, methodName, got.String(), "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<movie>\n  <title></title>\n</movie>\n")
+}
+
+func TestNFOPaths(t *testing.T) {
+	const methodName = "NFOPaths"
+	tests := []struct {
+		name string
+		got  export.NFOPaths
+		want export.NFOPaths
+	}{
+		{
+			name: "returns paths named after video file",
+			got:  export.NFOPathsForVideo(filepath.Join("movies", "Oppenheimer (2023).mp4")),
+			want: export.NFOPaths{
+				NFO:    filepath.Join("movies", "Oppenheimer (2023).nfo"),
+				Poster: filepath.Join("movies", "Oppenheimer (2023)-poster.jpg"),
+				Fanart: filepath.Join("movies", "Oppenheimer (2023)-fanart.jpg"),
+			},
+		},
+		{
+			name: "returns paths within movie folder",
+			got:  export.NFOPathsForFolder("Oppenheimer (2023)"),
+			want: export.NFOPaths{
+				NFO:    filepath.Join("Oppenheimer (2023)", "movie.nfo"),
+				Poster: filepath.Join("Oppenheimer (2023)", "poster.jpg"),
+				Fanart: filepath.Join("Oppenheimer (2023)", "fanart.jpg"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, methodName, tt.got, tt.want)
+		})
+	}
+}
+
+func TestNFOFolderName(t *testing.T) {
+	const methodName = "NFOFolderName"
+	tests := []struct {
+		name  string
+		title string
+		year  int
+		want  string
+	}{
+		{
+			name:  "returns title with year",
+			title: "Oppenheimer",
+			year:  2023,
+			want:  "Oppenheimer (2023)",
+		},
+		{
+			name:  "removes disallowed characters",
+			title: "Mission: Impossible - Dead Reckoning Part One",
+			year:  2023,
+			want:  "Mission Impossible - Dead Reckoning Part One (2023)",
+		},
+		{
+			name:  "returns title without year",
+			title: "What If...?",
+			want:  "What If",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movie := &yts.MoviePartial{Title: tt.title, Year: tt.year}
+			assertEqual(t, methodName, export.NFOFolderName(movie), tt.want)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"context"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A MovieSearcher is implemented by *yts.Client and is used by WriteSearchResults
+// for walking the pages of the "/api/v2/list_movies.json" endpoint.
+type MovieSearcher interface {
+	SearchMoviesWithContext(ctx context.Context, filters *yts.SearchMoviesFilters) (
+		*yts.SearchMoviesResponse, error,
+	)
+}
+
+// A CommentsGetter is implemented by *yts.Client and is used by WriteComments for
+// walking the pages of the comments of a movie.
+type CommentsGetter interface {
+	MovieCommentsWithContext(ctx context.Context, movieSlug string, page int) (
+		*yts.MovieCommentsResponse, error,
+	)
+}
+
+// WriteSearchResults writes the movies of every page of search results for the
+// provided filters to w, starting from the Page of the filters, as soon as each
+// page is received. The number of movies written is returned, along with the
+// movies written before the first error encountered. The filters are not modified.
+func WriteSearchResults(ctx context.Context, searcher MovieSearcher,
+	filters *yts.SearchMoviesFilters, w *Writer[yts.Movie]) (int, error) {
+	pageFilters := *filters
+	if pageFilters.Page < 1 {
+		pageFilters.Page = 1
+	}
+
+	written := 0
+	for ; ; pageFilters.Page++ {
+		response, err := searcher.SearchMoviesWithContext(ctx, &pageFilters)
+		if err != nil {
+			return written, err
+		}
+
+		data := response.Data
+		if err := w.Write(data.Movies...); err != nil {
+			return written, err
+		}
+
+		written += len(data.Movies)
+		if len(data.Movies) == 0 || data.MovieCount <= pageFilters.Page*data.Limit {
+			return written, nil
+		}
+	}
+}
+
+// WriteComments writes every page of the comments of the movie with the provided
+// slug to w as soon as each page is received. The number of comments written is
+// returned, replies excluded, along with the comments written before the first
+// error encountered.
+func WriteComments(ctx context.Context, getter CommentsGetter, movieSlug string,
+	w *Writer[yts.SiteMovieComment]) (int, error) {
+	written := 0
+	for page := 1; ; page++ {
+		response, err := getter.MovieCommentsWithContext(ctx, movieSlug, page)
+		if err != nil {
+			return written, err
+		}
+
+		data := response.Data
+		if err := w.Write(data.Comments...); err != nil {
+			return written, err
+		}
+
+		written += len(data.Comments)
+		if !data.CommentsMore || len(data.Comments) == 0 {
+			return written, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/export"
+	"github.com/atifcppprogrammer/yflicks-yts/ytstest"
+)
+
+func TestWriteSearchResults(t *testing.T) {
+	const methodName = "WriteSearchResults"
+
+	s := ytstest.NewServer(nil)
+	t.Cleanup(s.Close)
+
+	tests := []struct {
+		name      string
+		page      int
+		wantCount int
+		wantSlugs string
+		wantPages int
+	}{
+		{
+			name:      "writes every page",
+			page:      1,
+			wantCount: 3,
+			wantSlugs: "slug\noppenheimer-2023\nthe-dark-knight-2008\nsuperbad-2007\n",
+			wantPages: 2,
+		},
+		{
+			name:      "writes from the page of the filters",
+			page:      2,
+			wantCount: 1,
+			wantSlugs: "slug\nsuperbad-2007\n",
+			wantPages: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.ClearFaults()
+			requests := s.Requests(ytstest.ListMoviesPath)
+
+			filters := yts.DefaultSearchMoviesFilters("")
+			filters.Limit, filters.Page = 2, tt.page
+
+			got := bytes.Buffer{}
+			w, _ := export.NewMovieWriter(&got, &export.Options{Columns: []string{"slug"}})
+			count, err := export.WriteSearchResults(context.Background(), s.Client(), filters, w)
+			assertError(t, methodName, err, nil)
+			assertError(t, methodName, w.Flush(), nil)
+
+			assertEqual(t, methodName, count, tt.wantCount)
+			assertEqual(t, methodName, got.String(), tt.wantSlugs)
+			assertEqual(t, methodName, s.Requests(ytstest.ListMoviesPath)-requests, tt.wantPages)
+			assertEqual(t, methodName, filters.Page, tt.page)
+		})
+	}
+}
+
+func TestWriteComments(t *testing.T) {
+	const methodName = "WriteComments"
+
+	dataset := ytstest.DefaultDataset()
+	for i := 0; i < 45; i++ {
+		dataset.Movies[2].Comments = append(dataset.Movies[2].Comments, yts.SiteMovieComment{
+			ID:        i + 1,
+			Author:    fmt.Sprintf("user%d", i),
+			Content:   "content",
+			Timestamp: "1 year ago",
+		})
+	}
+
+	s := ytstest.NewServer(dataset)
+	t.Cleanup(s.Close)
+
+	got := bytes.Buffer{}
+	w, _ := export.NewCommentWriter(&got, &export.Options{Columns: []string{"author"}})
+	count, err := export.WriteComments(context.Background(), s.Client(), "superbad-2007", w)
+	assertError(t, methodName, err, nil)
+	assertError(t, methodName, w.Flush(), nil)
+
+	rows := strings.Split(strings.TrimSpace(got.String()), "\n")
+	assertEqual(t, methodName, count, 45)
+	assertEqual(t, methodName, len(rows), 46)
+	assertEqual(t, methodName, rows[45], "user44")
+	assertEqual(t, methodName, s.Requests(ytstest.CommentsPath), 2)
+
+	s.SetFault(ytstest.CommentsPath, ytstest.Fault{StatusCode: 500})
+	_, err = export.WriteComments(context.Background(), s.Client(), "superbad-2007", w)
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+}
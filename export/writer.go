@@ -0,0 +1,326 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A Format is the file format written by a Writer.
+type Format int
+
+const (
+	// FormatCSV writes a header row of column names followed by a row per record.
+	FormatCSV Format = iota
+
+	// FormatJSONLines writes a JSON object per row, keyed by column name.
+	FormatJSONLines
+)
+
+// A TorrentLayout determines how the torrents of movies are flattened into rows.
+type TorrentLayout int
+
+const (
+	// TorrentsOmitted writes a row per movie without any torrent columns.
+	TorrentsOmitted TorrentLayout = iota
+
+	// TorrentsPerRow writes a row per torrent of a movie, repeating the columns of
+	// the movie, with the "torrent_quality", "torrent_type", "torrent_video_codec",
+	// "torrent_size", "torrent_size_bytes", "torrent_seeds", "torrent_peers",
+	// "torrent_hash", "torrent_url" and "torrent_date_uploaded" columns. A movie
+	// without torrents is written as a single row with empty torrent columns.
+	TorrentsPerRow
+
+	// TorrentsPerQuality writes a row per movie with the torrent columns repeated
+	// for every one of the Qualities, such as "torrent_1080p_hash", holding the
+	// torrent of that quality with the most seeds.
+	TorrentsPerQuality
+)
+
+// ErrInvalidOptions is reported when a Writer is created with invalid Options, the
+// error description will carry further details.
+var ErrInvalidOptions = errors.New("invalid_export_options")
+
+// Options configure the rows written by a Writer.
+type Options struct {
+	// The Format of the written rows, FormatCSV being the zero value.
+	Format Format
+
+	// The names of the columns written for every row and their order, when empty
+	// every column available for the record type and TorrentLayout is written.
+	Columns []string
+
+	// The TorrentLayout for the torrents of movies, it is ignored by the writers of
+	// records without torrents.
+	Torrents TorrentLayout
+
+	// The qualities for which torrent columns are written with TorrentsPerQuality,
+	// when empty DefaultQualities() is used.
+	Qualities []yts.Quality
+}
+
+// DefaultOptions returns the default *Options used for creating a Writer, which
+// write every column as CSV with the torrents omitted.
+func DefaultOptions() *Options {
+	return &Options{
+		Format:   FormatCSV,
+		Torrents: TorrentsOmitted,
+	}
+}
+
+// DefaultQualities returns the qualities for which torrent columns are written
+// with TorrentsPerQuality when the Qualities of the Options are empty.
+func DefaultQualities() []yts.Quality {
+	return []yts.Quality{
+		yts.Quality480p,
+		yts.Quality720p,
+		yts.Quality1080p,
+		yts.Quality1080pX265,
+		yts.Quality2160p,
+		yts.Quality3D,
+	}
+}
+
+func (o *Options) validate() error {
+	switch {
+	case o.Format < FormatCSV || FormatJSONLines < o.Format:
+		return fmt.Errorf("format must be one of FormatCSV or FormatJSONLines")
+	case o.Torrents < TorrentsOmitted || TorrentsPerQuality < o.Torrents:
+		return fmt.Errorf("torrents must be one of TorrentsOmitted, TorrentsPerRow or TorrentsPerQuality")
+	}
+	return nil
+}
+
+// A Writer writes records of type T as rows to an io.Writer, the rows are
+// buffered and Flush must be called once every record has been written. Records
+// may be written over any number of calls to Write, such as once per page of
+// results, so exports of any size are streamed rather than held in memory.
+type Writer[T any] struct {
+	columns       []column[T]
+	torrents      func(record T) []yts.Torrent
+	flatten       func(record T) []T
+	perRow        bool
+	csv           *csv.Writer
+	jsonLines     *bufio.Writer
+	headerWritten bool
+}
+
+// NewMovieWriter creates a *Writer for the movies of the list_movies.json and
+// movie_suggestions.json endpoints. A nil opts uses DefaultOptions().
+func NewMovieWriter(w io.Writer, opts *Options) (*Writer[yts.Movie], error) {
+	torrents := func(m yts.Movie) []yts.Torrent { return m.Torrents }
+	return newWriter(w, opts, movieColumns(), torrents, nil)
+}
+
+// NewMovieDetailsWriter creates a *Writer for the movies of the movie_details.json
+// endpoint. A nil opts uses DefaultOptions().
+func NewMovieDetailsWriter(w io.Writer, opts *Options) (*Writer[yts.MovieDetails], error) {
+	torrents := func(m yts.MovieDetails) []yts.Torrent { return m.Torrents }
+	return newWriter(w, opts, movieDetailsColumns(), torrents, nil)
+}
+
+// NewSiteMovieWriter creates a *Writer for the movies scraped from the YTS website,
+// such as trending movies. A nil opts uses DefaultOptions().
+func NewSiteMovieWriter(w io.Writer, opts *Options) (*Writer[yts.SiteMovie], error) {
+	return newWriter(w, opts, siteMovieColumns(), nil, nil)
+}
+
+// NewCommentWriter creates a *Writer for the comments of a movie, the replies of a
+// comment are written as rows of their own following the comment, with their
+// "parent_id" column holding the ID of the comment. A nil opts uses
+// DefaultOptions().
+func NewCommentWriter(w io.Writer, opts *Options) (*Writer[yts.SiteMovieComment], error) {
+	return newWriter(w, opts, commentColumns(), nil, flattenComment)
+}
+
+func flattenComment(comment yts.SiteMovieComment) []yts.SiteMovieComment {
+	comments := []yts.SiteMovieComment{comment}
+	for _, reply := range comment.Replies {
+		comments = append(comments, flattenComment(reply)...)
+	}
+	return comments
+}
+
+func newWriter[T any](w io.Writer, opts *Options, columns []column[T],
+	torrents func(record T) []yts.Torrent, flatten func(record T) []T) (*Writer[T], error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	writer := &Writer[T]{torrents: torrents, flatten: flatten}
+	if torrents != nil {
+		switch opts.Torrents {
+		case TorrentsPerRow:
+			writer.perRow = true
+			columns = append(columns, perRowTorrentColumns[T]()...)
+		case TorrentsPerQuality:
+			qualities := opts.Qualities
+			if len(qualities) == 0 {
+				qualities = DefaultQualities()
+			}
+			columns = append(columns, perQualityTorrentColumns(torrents, qualities)...)
+		}
+	}
+
+	selected, err := selectColumns(columns, opts.Columns)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	writer.columns = selected
+	if opts.Format == FormatCSV {
+		writer.csv = csv.NewWriter(w)
+	} else {
+		writer.jsonLines = bufio.NewWriter(w)
+	}
+
+	return writer, nil
+}
+
+func selectColumns[T any](columns []column[T], names []string) ([]column[T], error) {
+	if len(names) == 0 {
+		return columns, nil
+	}
+
+	byName := make(map[string]column[T], len(columns))
+	for _, c := range columns {
+		byName[c.name] = c
+	}
+
+	selected := make([]column[T], 0, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, c)
+	}
+
+	return selected, nil
+}
+
+// Columns returns the names of the columns written for every row.
+func (w *Writer[T]) Columns() []string {
+	names := make([]string, 0, len(w.columns))
+	for _, c := range w.columns {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// Write writes the rows of the provided records, the CSV header row is written
+// along with the rows of the first call.
+func (w *Writer[T]) Write(records ...T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		flattened := []T{record}
+		if w.flatten != nil {
+			flattened = w.flatten(record)
+		}
+
+		for _, r := range flattened {
+			if err := w.writeRecord(r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer[T]) writeRecord(record T) error {
+	if !w.perRow || len(w.torrents(record)) == 0 {
+		return w.writeRow(record, nil)
+	}
+
+	torrents := w.torrents(record)
+	for i := range torrents {
+		if err := w.writeRow(record, &torrents[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer[T]) writeHeader() error {
+	if w.csv == nil || w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+	return w.csv.Write(w.Columns())
+}
+
+func (w *Writer[T]) writeRow(record T, torrent *yts.Torrent) error {
+	if w.csv != nil {
+		fields := make([]string, 0, len(w.columns))
+		for _, c := range w.columns {
+			fields = append(fields, formatCSV(c.value(record, torrent)))
+		}
+		return w.csv.Write(fields)
+	}
+
+	row := bytes.Buffer{}
+	row.WriteByte('{')
+	for i, c := range w.columns {
+		if i != 0 {
+			row.WriteByte(',')
+		}
+
+		key, _ := marshalJSON(c.name)
+		value, err := marshalJSON(formatJSON(c.value(record, torrent)))
+		if err != nil {
+			return fmt.Errorf("column %q: %w", c.name, err)
+		}
+
+		row.Write(key)
+		row.WriteByte(':')
+		row.Write(value)
+	}
+	row.WriteString("}\n")
+
+	_, err := w.jsonLines.Write(row.Bytes())
+	return err
+}
+
+// Flush writes any buffered rows to the underlying io.Writer, a CSV export without
+// any records consists of the header row alone.
+func (w *Writer[T]) Flush() error {
+	if w.csv == nil {
+		return w.jsonLines.Flush()
+	}
+
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// marshalJSON encodes the provided value without escaping HTML characters, leaving
+// the URLs of exported movies readable.
+func marshalJSON(value any) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
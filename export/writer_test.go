@@ -0,0 +1,247 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/export"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+func testMovies() []yts.Movie {
+	oppenheimer := yts.Movie{Summary: "The story of J. Robert Oppenheimer."}
+	oppenheimer.ID = 57427
+	oppenheimer.Title = "Oppenheimer"
+	oppenheimer.Year = 2023
+	oppenheimer.Rating = 8.4
+	oppenheimer.Genres = []yts.Genre{yts.GenreBiography, yts.GenreDrama}
+	oppenheimer.Torrents = []yts.Torrent{
+		{Quality: yts.Quality720p, Type: "bluray", Seeds: 100, Hash: "A"},
+		{Quality: yts.Quality1080p, Type: "web", Seeds: 50, Hash: "B"},
+		{Quality: yts.Quality1080p, Type: "bluray", Seeds: 200, Hash: "C"},
+	}
+
+	superbad := yts.Movie{}
+	superbad.ID = 1675
+	superbad.Title = "Superbad, \"McLovin\""
+	superbad.Year = 2007
+	return []yts.Movie{oppenheimer, superbad}
+}
+
+func TestMovieWriter(t *testing.T) {
+	const methodName = "Writer.Write"
+	tests := []struct {
+		name string
+		opts *export.Options
+		want string
+	}{
+		{
+			name: "writes selected columns as csv",
+			opts: &export.Options{Columns: []string{"id", "title", "genres", "rating"}},
+			want: "id,title,genres,rating\n" +
+				"57427,Oppenheimer,\"Biography, Drama\",8.4\n" +
+				"1675,\"Superbad, \"\"McLovin\"\"\",,0\n",
+		},
+		{
+			name: "writes a row per torrent",
+			opts: &export.Options{
+				Columns:  []string{"id", "torrent_quality", "torrent_type", "torrent_hash"},
+				Torrents: export.TorrentsPerRow,
+			},
+			want: "id,torrent_quality,torrent_type,torrent_hash\n" +
+				"57427,720p,bluray,A\n" +
+				"57427,1080p,web,B\n" +
+				"57427,1080p,bluray,C\n" +
+				"1675,,,\n",
+		},
+		{
+			name: "writes columns per quality",
+			opts: &export.Options{
+				Torrents:  export.TorrentsPerQuality,
+				Qualities: []yts.Quality{yts.Quality1080p, yts.Quality2160p},
+				Columns:   []string{"id", "torrent_1080p_hash", "torrent_1080p_seeds", "torrent_2160p_hash"},
+			},
+			want: "id,torrent_1080p_hash,torrent_1080p_seeds,torrent_2160p_hash\n" +
+				"57427,C,200,\n" +
+				"1675,,,\n",
+		},
+		{
+			name: "writes json lines",
+			opts: &export.Options{
+				Format:   export.FormatJSONLines,
+				Columns:  []string{"id", "title", "genres", "torrent_720p_hash"},
+				Torrents: export.TorrentsPerQuality,
+			},
+			want: `{"id":57427,"title":"Oppenheimer","genres":["Biography","Drama"],"torrent_720p_hash":"A"}` + "\n" +
+				`{"id":1675,"title":"Superbad, \"McLovin\"","genres":null,"torrent_720p_hash":null}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bytes.Buffer{}
+			w, err := export.NewMovieWriter(&got, tt.opts)
+			assertError(t, methodName, err, nil)
+
+			for _, movie := range testMovies() {
+				assertError(t, methodName, w.Write(movie), nil)
+			}
+			assertError(t, methodName, w.Flush(), nil)
+			assertEqual(t, methodName, got.String(), tt.want)
+		})
+	}
+}
+
+func TestNewMovieWriter(t *testing.T) {
+	const methodName = "NewMovieWriter"
+	tests := []struct {
+		name        string
+		opts        *export.Options
+		wantColumns int
+		wantErr     error
+	}{
+		{
+			name:        "returns writer with every column by default",
+			opts:        nil,
+			wantColumns: 25,
+			wantErr:     nil,
+		},
+		{
+			name:        "returns writer with torrent columns per quality",
+			opts:        &export.Options{Torrents: export.TorrentsPerQuality},
+			wantColumns: 25 + 6*9,
+			wantErr:     nil,
+		},
+		{
+			name:    "returns error for unknown column",
+			opts:    &export.Options{Columns: []string{"title", "torrent_hash"}},
+			wantErr: export.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for unknown format",
+			opts:    &export.Options{Format: export.FormatJSONLines + 1},
+			wantErr: export.ErrInvalidOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := export.NewMovieWriter(&bytes.Buffer{}, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+			if err == nil {
+				assertEqual(t, methodName, len(w.Columns()), tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestMovieDetailsWriter(t *testing.T) {
+	const methodName = "Writer.Write"
+
+	movie := yts.MovieDetails{LikeCount: 213}
+	movie.Title = "Oppenheimer"
+	movie.Cast = []yts.Cast{
+		{Name: "Cillian Murphy", CharacterName: "J. Robert Oppenheimer"},
+		{Name: "Emily Blunt"},
+	}
+
+	got := bytes.Buffer{}
+	w, _ := export.NewMovieDetailsWriter(&got, &export.Options{
+		Columns: []string{"title", "like_count", "cast"},
+	})
+	assertError(t, methodName, w.Write(movie), nil)
+	assertError(t, methodName, w.Flush(), nil)
+	assertEqual(t, methodName, got.String(), "title,like_count,cast\n"+
+		"Oppenheimer,213,\"Cillian Murphy as J. Robert Oppenheimer, Emily Blunt\"\n")
+}
+
+func TestSiteMovieWriter(t *testing.T) {
+	const methodName = "Writer.Write"
+
+	movie := yts.SiteMovie{Rating: yts.Rating{Value: 7.5, Scale: 10}}
+	movie.Slug = "superbad-2007"
+	movie.Genres = []yts.Genre{yts.GenreComedy}
+
+	got := bytes.Buffer{}
+	w, _ := export.NewSiteMovieWriter(&got, &export.Options{
+		Format:   export.FormatJSONLines,
+		Columns:  []string{"slug", "genres", "rating"},
+		Torrents: export.TorrentsPerRow,
+	})
+	assertError(t, methodName, w.Write(movie), nil)
+	assertError(t, methodName, w.Flush(), nil)
+	assertEqual(t, methodName, strings.TrimSpace(got.String()),
+		`{"slug":"superbad-2007","genres":["Comedy"],"rating":"7.5 / 10"}`)
+}
+
+func TestCommentWriter(t *testing.T) {
+	const methodName = "Writer.Write"
+
+	postedAt := time.Date(2024, 4, 30, 9, 46, 0, 0, time.UTC)
+	comments := []yts.SiteMovieComment{
+		{ID: 1, Author: "aaron2023", PostedAt: postedAt, Replies: []yts.SiteMovieComment{
+			{ID: 2, ParentID: 1, Author: "AmanS666"},
+		}},
+		{ID: 3, Author: "gotham"},
+	}
+
+	tests := []struct {
+		name string
+		opts *export.Options
+		want string
+	}{
+		{
+			name: "writes replies as rows following their comment",
+			opts: &export.Options{Columns: []string{"id", "parent_id", "author", "posted_at"}},
+			want: "id,parent_id,author,posted_at\n" +
+				"1,0,aaron2023,2024-04-30T09:46:00Z\n" +
+				"2,1,AmanS666,\n" +
+				"3,0,gotham,\n",
+		},
+		{
+			name: "writes zero times as null",
+			opts: &export.Options{Format: export.FormatJSONLines, Columns: []string{"id", "posted_at"}},
+			want: `{"id":1,"posted_at":"2024-04-30T09:46:00Z"}` + "\n" +
+				`{"id":2,"posted_at":null}` + "\n" +
+				`{"id":3,"posted_at":null}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bytes.Buffer{}
+			w, err := export.NewCommentWriter(&got, tt.opts)
+			assertError(t, methodName, err, nil)
+			assertError(t, methodName, w.Write(comments...), nil)
+			assertError(t, methodName, w.Flush(), nil)
+			assertEqual(t, methodName, got.String(), tt.want)
+		})
+	}
+}
+
+func TestWriter_Flush(t *testing.T) {
+	const methodName = "Writer.Flush"
+
+	got := bytes.Buffer{}
+	w, _ := export.NewSiteMovieWriter(&got, &export.Options{Columns: []string{"slug", "title"}})
+	assertError(t, methodName, w.Flush(), nil)
+	assertEqual(t, methodName, got.String(), "slug,title\n")
+}
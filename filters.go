@@ -216,6 +216,18 @@ func (f *SearchMoviesFilters) validateFilters() error {
 	)
 }
 
+// Validate validates the provided filter values in the same manner as the
+// SearchMovies method, an ErrFilterValidationFailure is returned in the event
+// validation fails. This allows filters received from elsewhere, such as the query
+// string of a request, to be rejected before any network request is made.
+func (f *SearchMoviesFilters) Validate() error {
+	if err := f.validateFilters(); err != nil {
+		return wrapErr(ErrFilterValidationFailure, err)
+	}
+
+	return nil
+}
+
 func (f *SearchMoviesFilters) getQueryString() (string, error) {
 	if err := f.validateFilters(); err != nil {
 		return "", err
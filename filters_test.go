@@ -33,3 +33,39 @@ func TestDefaultMovieDetailsFilters(t *testing.T) {
 
 	assertEqual(t, "DefaultMovieDetailsFilters", got, want)
 }
+
+func TestSearchMoviesFilters_Validate(t *testing.T) {
+	const methodName = "SearchMoviesFilters.Validate"
+
+	invalidLimit := yts.DefaultSearchMoviesFilters("")
+	invalidLimit.Limit = 51
+
+	invalidGenre := yts.DefaultSearchMoviesFilters("")
+	invalidGenre.Genre = "Cartoon"
+
+	tests := []struct {
+		name    string
+		filters *yts.SearchMoviesFilters
+		wantErr error
+	}{
+		{
+			name:    "returns nil for default filters",
+			filters: yts.DefaultSearchMoviesFilters("oppenheimer"),
+		},
+		{
+			name:    "returns error for limit above maximum",
+			filters: invalidLimit,
+			wantErr: yts.ErrFilterValidationFailure,
+		},
+		{
+			name:    "returns error for unknown genre",
+			filters: invalidGenre,
+			wantErr: yts.ErrFilterValidationFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertError(t, methodName, tt.filters.Validate(), tt.wantErr)
+		})
+	}
+}
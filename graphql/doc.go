@@ -0,0 +1,37 @@
+/*
+Package graphql exposes the movies, torrents and scraped content of a *yts.Client
+through a GraphQL endpoint, letting clients select the fields they need across the
+YTS API and the YTS website in a single request.
+
+	schema := graphql.NewSchema(yts.NewClient())
+	http.Handle("/graphql", schema)
+
+	response := schema.Execute(ctx, &graphql.Request{
+		Query: `{ movie(slug: "oppenheimer-2023") { title director { name } reviews { rating } } }`,
+	})
+
+The package implements the subset of GraphQL needed for querying the schema below:
+query operations with variables, aliases, fragments and the @skip and @include
+directives. Mutations, subscriptions and introspection are not supported, the
+schema is instead available in the schema definition language from Schema.SDL.
+
+	type Query {
+	  movie(id: Int, slug: String, imdbId: String): Movie
+	  search(query: String = "", limit: Int = 20, page: Int = 1, ...): SearchResult
+	  trending: [SiteMovie]
+	}
+
+Movie fields provided by the list_movies.json endpoint are resolved without further
+requests, likeCount, descriptionIntro and cast are resolved with the details of the
+movie ID. The director, reviews and comments of a movie are scraped from its
+yts.MoviePage, which is fetched once per slug and request however many movies or
+fields select it. Each of these fields is scraped on its own, so that a movie without
+reviews still resolves its director and comments, and comments are only requested
+from YTS when selected.
+
+Fields are resolved concurrently with at most four requests made to YTS at a time. A
+field whose resolution fails is set to null and reported in the Errors of the
+Response, whose Unwrap method gives access to the sentinel errors of the yts
+package.
+*/
+package graphql
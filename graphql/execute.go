@@ -0,0 +1,413 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A Request is a GraphQL request as sent in the body of a POST request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// A Location points at the line and column of a field in the query of a Request.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// An Error is an error reported in the errors of a Response, Path holds the response
+// keys and list indices leading to the field whose resolution failed, it is empty
+// for errors preventing the execution of the request.
+type Error struct {
+	Message   string     `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+	Path      []any      `json:"path,omitempty"`
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error the Error was created from, allowing errors.Is to match
+// the sentinel errors of the yts package.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// A Response is the result of executing a Request, Data is nil when the request
+// could not be executed, in which case Errors explains why.
+type Response struct {
+	Data   *Object  `json:"data"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// An Object is the result of executing a selection set, it is marshalled to a JSON
+// object whose keys follow the order of the selection set.
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+func newObject(size int) *Object {
+	return &Object{keys: make([]string, 0, size), values: make(map[string]any, size)}
+}
+
+func (o *Object) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Keys returns the keys of the object in the order of the selection set.
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// Get returns the value held for key, nested selection sets are held as *Object and
+// lists as []any.
+func (o *Object) Get(key string) any {
+	return o.values[key]
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i != 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, _ := marshalJSON(key)
+		value, err := marshalJSON(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// marshalJSON is json.Marshal without the escaping of HTML characters, which would
+// make values such as magnet links unreadable.
+func marshalJSON(value any) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// requestError creates a Response for a request which could not be executed.
+func requestError(err error) *Response {
+	return &Response{Errors: []*Error{{Message: err.Error(), err: err}}}
+}
+
+// An executor executes a single operation, collecting the field errors reported
+// by resolvers which may run concurrently.
+type executor struct {
+	fragments map[string]*fragment
+	variables map[string]any
+
+	mu     sync.Mutex
+	errors []*Error
+}
+
+func (e *executor) addError(err error, node *fieldNode, path []any) {
+	gqlErr := &Error{
+		Message:   err.Error(),
+		Locations: []Location{{node.line, node.col}},
+		Path:      append([]any(nil), path...),
+		err:       err,
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, gqlErr)
+}
+
+// selectOperation returns the operation of doc to execute, which must be named by
+// name unless doc holds a single operation.
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) != 1 {
+			return nil, fmt.Errorf("operation name is required for documents with several operations")
+		}
+		return doc.operations[0], nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// coerceVariables applies the defaults of the variable definitions of op to the
+// provided variables, reporting the required variables which were not provided.
+func coerceVariables(op *operation, provided map[string]any) (map[string]any, error) {
+	variables := make(map[string]any, len(op.variables))
+	for _, def := range op.variables {
+		value, ok := provided[def.name]
+		switch {
+		case !ok && def.hasDefault:
+			variables[def.name] = def.defaultValue
+		case (!ok || value == nil) && def.typ.nonNull:
+			return nil, fmt.Errorf("variable $%s of type %s is required", def.name, typeRefString(def.typ))
+		case ok:
+			variables[def.name] = value
+		}
+	}
+	return variables, nil
+}
+
+func typeRefString(t *typeRef) string {
+	name := t.name
+	if t.elem != nil {
+		name = "[" + typeRefString(t.elem) + "]"
+	}
+	if t.nonNull {
+		name += "!"
+	}
+	return name
+}
+
+// resolveValue replaces the variables referenced by a literal value with their
+// values, enum values are kept as is.
+func (e *executor) resolveValue(value any) any {
+	switch v := value.(type) {
+	case variableRef:
+		return e.variables[string(v)]
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = e.resolveValue(item)
+		}
+		return resolved
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = e.resolveValue(item)
+		}
+		return resolved
+	default:
+		return value
+	}
+}
+
+// coerceArgs coerces the arguments provided for node to the argument definitions
+// of the field, applying their defaults.
+func (e *executor) coerceArgs(field *fieldDef, node *fieldNode) (map[string]any, error) {
+	provided := make(map[string]any, len(node.args))
+	for _, arg := range node.args {
+		provided[arg.name] = e.resolveValue(arg.value)
+	}
+
+	args := make(map[string]any, len(field.args))
+	for _, def := range field.args {
+		value, ok := provided[def.name]
+		if !ok || value == nil {
+			value = def.defaultValue
+		}
+
+		coerced, err := coerceInput(def.typ, value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q of field %q: %w", def.name, field.name, err)
+		}
+		args[def.name] = coerced
+		delete(provided, def.name)
+	}
+
+	if len(provided) != 0 {
+		name := sortedKeys(provided)[0]
+		return nil, fmt.Errorf("unknown argument %q of field %q", name, field.name)
+	}
+
+	return args, nil
+}
+
+// included evaluates the @skip and @include directives.
+func (e *executor) included(directives []directive) bool {
+	for _, d := range directives {
+		for _, arg := range d.args {
+			value, _ := e.resolveValue(arg.value).(bool)
+			switch {
+			case d.name == "skip" && arg.name == "if" && value:
+				return false
+			case d.name == "include" && arg.name == "if" && !value:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// collectFields groups the fields of a selection set by their response key, in the
+// order they are first selected, expanding the fragments applying to object.
+func (e *executor) collectFields(object *objectType, selections []selection,
+	keys *[]string, fields map[string][]*fieldNode, visited map[string]bool) {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *fieldNode:
+			if !e.included(s.directives) {
+				continue
+			}
+
+			key := s.responseKey()
+			if _, ok := fields[key]; !ok {
+				*keys = append(*keys, key)
+			}
+			fields[key] = append(fields[key], s)
+		case *inlineFragment:
+			if !e.included(s.directives) || (s.typeCondition != "" && s.typeCondition != object.name) {
+				continue
+			}
+			e.collectFields(object, s.selections, keys, fields, visited)
+		case *fragmentSpread:
+			f, ok := e.fragments[s.name]
+			if !ok || visited[s.name] || !e.included(s.directives) || f.typeCondition != object.name {
+				continue
+			}
+			visited[s.name] = true
+			e.collectFields(object, f.selections, keys, fields, visited)
+		}
+	}
+}
+
+// executeSelections resolves the fields selected on object for source concurrently,
+// a field whose resolution fails is reported as an error and set to null.
+func (e *executor) executeSelections(ctx context.Context, object *objectType, source any,
+	selections []selection, path []any) *Object {
+	var (
+		keys   = make([]string, 0, len(selections))
+		fields = make(map[string][]*fieldNode)
+	)
+
+	e.collectFields(object, selections, &keys, fields, make(map[string]bool))
+
+	var (
+		values = make([]any, len(keys))
+		wg     sync.WaitGroup
+	)
+
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			values[i] = e.executeField(ctx, object, source, fields[key], append(path[:len(path):len(path)], key))
+		}(i, key)
+	}
+	wg.Wait()
+
+	result := newObject(len(keys))
+	for i, key := range keys {
+		result.set(key, values[i])
+	}
+	return result
+}
+
+func (e *executor) executeField(ctx context.Context, object *objectType, source any,
+	nodes []*fieldNode, path []any) any {
+	node := nodes[0]
+	if node.name == "__typename" {
+		return object.name
+	}
+
+	field, ok := object.byName[node.name]
+	if !ok {
+		e.addError(fmt.Errorf("cannot query field %q on type %q", node.name, object.name), node, path)
+		return nil
+	}
+
+	args, err := e.coerceArgs(field, node)
+	if err != nil {
+		e.addError(err, node, path)
+		return nil
+	}
+
+	value, err := field.resolve(ctx, source, args)
+	if err != nil {
+		e.addError(err, node, path)
+		return nil
+	}
+
+	value, err = e.completeValue(ctx, field.typ, nodes, value, path)
+	if err != nil {
+		e.addError(err, node, path)
+		return nil
+	}
+	return value
+}
+
+func (e *executor) completeValue(ctx context.Context, t *gqlType, nodes []*fieldNode,
+	value any, path []any) (any, error) {
+	if isNil(value) {
+		return nil, nil
+	}
+
+	switch t.kind {
+	case kindList:
+		return e.completeList(ctx, t, nodes, value, path)
+	case kindObject:
+		selections := make([]selection, 0)
+		for _, node := range nodes {
+			selections = append(selections, node.selections...)
+		}
+		if len(selections) == 0 {
+			return nil, fmt.Errorf("field %q of type %s must have a selection of subfields", nodes[0].name, t)
+		}
+		return e.executeSelections(ctx, t.object, value, selections, path), nil
+	default:
+		if len(nodes[0].selections) != 0 {
+			return nil, fmt.Errorf("field %q of type %s cannot have a selection of subfields", nodes[0].name, t)
+		}
+		return serialize(t.name, value)
+	}
+}
+
+func (e *executor) completeList(ctx context.Context, t *gqlType, nodes []*fieldNode,
+	value any, path []any) (any, error) {
+	items := reflect.ValueOf(value)
+	if items.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("field %q of type %s did not resolve to a list", nodes[0].name, t)
+	}
+
+	var (
+		completed = make([]any, items.Len())
+		wg        sync.WaitGroup
+	)
+
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i).Interface()
+		wg.Add(1)
+		go func(i int, item any) {
+			defer wg.Done()
+			itemPath := append(path[:len(path):len(path)], i)
+			v, err := e.completeValue(ctx, t.elem, nodes, item, itemPath)
+			if err != nil {
+				e.addError(err, nodes[0], itemPath)
+			}
+			completed[i] = v
+		}(i, item)
+	}
+	wg.Wait()
+
+	return completed, nil
+}
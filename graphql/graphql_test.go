@@ -0,0 +1,376 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/graphql"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+const (
+	movieDetailsJSON = `{"data":{"movie":{
+		"id":57427,"slug":"oppenheimer-2023","title":"Oppenheimer","title_long":"Oppenheimer (2023)",
+		"year":2023,"rating":8.4,"genres":["Biography","Drama"],"like_count":120,
+		"cast":[{"name":"Cillian Murphy","character_name":"J. Robert Oppenheimer","imdb_code":"0614165"}],
+		"torrents":[
+			{"hash":"AAAA","quality":"1080p","type":"web","seeds":10},
+			{"hash":"BBBB","quality":"1080p","type":"bluray","seeds":20}
+		]}}}`
+
+	searchMoviesJSON = `{"data":{"movie_count":2,"limit":2,"page_number":1,"movies":[
+		{"id":57427,"slug":"oppenheimer-2023","title":"Oppenheimer"},
+		{"id":57427,"slug":"oppenheimer-2023","title":"Oppenheimer (Duplicate)"}]}}`
+)
+
+// An upstream is a fake YTS server counting the requests received for each path.
+type upstream struct {
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func (u *upstream) count(path string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.requests[path]
+}
+
+func newUpstream(t *testing.T) (*yts.Client, *upstream) {
+	t.Helper()
+	return newUpstreamWithMoviePage(t, "ok_response")
+}
+
+// newUpstreamWithMoviePage creates a fake YTS server serving the movie page and
+// comments of the provided movie_additional_details fixture.
+func newUpstreamWithMoviePage(t *testing.T, fixture string) (*yts.Client, *upstream) {
+	t.Helper()
+	u := &upstream{requests: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock()
+		u.requests[r.URL.Path]++
+		u.mu.Unlock()
+
+		fixtures := path.Join("../testdata/movie_additional_details", fixture)
+		switch r.URL.Path {
+		case "/movie_details.json":
+			_, _ = w.Write([]byte(movieDetailsJSON))
+		case "/list_movies.json":
+			_, _ = w.Write([]byte(searchMoviesJSON))
+		case "/movies/oppenheimer-2023":
+			http.ServeFile(w, r, path.Join(fixtures, "movie_page.html"))
+		case "/ajax/comments/57427":
+			http.ServeFile(w, r, path.Join(fixtures, "comments.html"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	config := yts.DefaultClientConfig()
+	config.APIBaseURL = *serverURL
+	config.SiteURL = *serverURL
+	config.TorrentTrackers = []string{"udp://tracker.example.com:1337"}
+	client, _ := yts.NewClientWithConfig(&config)
+	return client, u
+}
+
+func marshal(t *testing.T, value any) string {
+	t.Helper()
+	body, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return string(body)
+}
+
+func TestSchema_Execute(t *testing.T) {
+	const methodName = "Schema.Execute"
+
+	tests := []struct {
+		name      string
+		request   graphql.Request
+		wantData  string
+		wantPaths [][]any
+	}{
+		{
+			name: "returns movie fields in selection order",
+			request: graphql.Request{Query: `{
+				movie(id: 57427) { title year genres rating __typename }
+			}`},
+			wantData: `{"movie":{"title":"Oppenheimer","year":2023,"genres":["Biography","Drama"],` +
+				`"rating":8.4,"__typename":"Movie"}}`,
+		},
+		{
+			name: "returns scraped director reviews and comments",
+			request: graphql.Request{Query: `{
+				movie(id: 57427) {
+					director { name }
+					reviews { author rating }
+					comments { author likeCount }
+					cast { name }
+				}
+			}`},
+			wantData: `{"movie":{"director":{"name":"Christopher Nolan"},` +
+				`"reviews":[{"author":"claszdsburrogato","rating":"7 / 10"},{"author":"Bonobo13579","rating":"7 / 10"},{"author":"MrDHWong","rating":"10 / 10"}],` +
+				`"comments":[{"author":"aaron2023","likeCount":0},{"author":"AmanS666","likeCount":1},{"author":"zorg2","likeCount":0}],` +
+				`"cast":[{"name":"Cillian Murphy"}]}}`,
+		},
+		{
+			name: "resolves variables aliases fragments and directives",
+			request: graphql.Request{
+				Query: `query Movie($id: Int!, $withYear: Boolean = false) {
+					first: movie(id: $id) { ...titles year @include(if: $withYear) }
+					second: movie(id: $id) { ... on Movie { slug } year @skip(if: true) }
+				}
+				fragment titles on Movie { title titleLong }`,
+				Variables: map[string]any{"id": float64(57427)},
+			},
+			wantData: `{"first":{"title":"Oppenheimer","titleLong":"Oppenheimer (2023)"},` +
+				`"second":{"slug":"oppenheimer-2023"}}`,
+		},
+		{
+			name: "returns search results",
+			request: graphql.Request{Query: `{
+				search(query: "oppenheimer", limit: 2) { movieCount movies { id title } }
+			}`},
+			wantData: `{"search":{"movieCount":2,"movies":[{"id":57427,"title":"Oppenheimer"},` +
+				`{"id":57427,"title":"Oppenheimer (Duplicate)"}]}}`,
+		},
+		{
+			name: "reports field errors and resolves other fields",
+			request: graphql.Request{Query: `{
+				a: movie { title }
+				b: movie(id: 57427) { title unknown }
+				c: search(limit: 100) { movieCount }
+			}`},
+			wantData:  `{"a":null,"b":{"title":"Oppenheimer","unknown":null},"c":null}`,
+			wantPaths: [][]any{{"a"}, {"b", "unknown"}, {"c"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newUpstream(t)
+			response := graphql.NewSchema(client).Execute(context.Background(), &tt.request)
+			assertEqual(t, methodName, marshal(t, response.Data), tt.wantData)
+
+			paths := make([][]any, 0)
+			for _, err := range response.Errors {
+				paths = append(paths, err.Path)
+			}
+
+			if tt.wantPaths == nil {
+				tt.wantPaths = [][]any{}
+			}
+			assertEqual(t, methodName, len(paths), len(tt.wantPaths))
+			for _, want := range tt.wantPaths {
+				found := false
+				for _, got := range paths {
+					found = found || reflect.DeepEqual(got, want)
+				}
+				if !found {
+					t.Errorf("%s() errors = %v, want an error for path %v", methodName, paths, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchema_ExecuteMagnets(t *testing.T) {
+	const methodName = "Schema.Execute"
+
+	client, _ := newUpstream(t)
+	response := graphql.NewSchema(client).Execute(context.Background(), &graphql.Request{
+		Query: `{ movie(id: 57427) { torrents { hash magnet } } }`,
+	})
+
+	torrents := response.Data.Get("movie").(*graphql.Object).Get("torrents").([]any)
+	assertEqual(t, methodName, len(torrents), 2)
+	for _, torrent := range torrents {
+		var (
+			hash   = torrent.(*graphql.Object).Get("hash").(string)
+			magnet = torrent.(*graphql.Object).Get("magnet").(string)
+		)
+
+		// both torrents share the 1080p quality, each magnet must use its own hash.
+		if !strings.HasPrefix(magnet, "magnet:?xt=urn:btih:"+hash+"&dn=Oppenheimer+%282023%29") {
+			t.Errorf("%s() magnet = %s, want the magnet of %s", methodName, magnet, hash)
+		}
+	}
+}
+
+func TestSchema_ExecuteErrors(t *testing.T) {
+	const methodName = "Schema.Execute"
+
+	client, _ := newUpstream(t)
+	schema := graphql.NewSchema(client)
+
+	response := schema.Execute(context.Background(), &graphql.Request{Query: `{ movie(slug: "a", id: 1) { title } }`})
+	assertEqual(t, methodName, len(response.Errors), 1)
+	assertError(t, methodName, response.Errors[0], yts.ErrValidationFailure)
+
+	response = schema.Execute(context.Background(), &graphql.Request{Query: `{ search(minimumRating: 100) { limit } }`})
+	assertEqual(t, methodName, len(response.Errors), 1)
+	assertError(t, methodName, response.Errors[0], yts.ErrFilterValidationFailure)
+
+	requestErrors := []graphql.Request{
+		{Query: `{ movie(id: 1) { title }`},
+		{Query: `mutation { movie(id: 1) { title } }`},
+		{Query: `query A { trending { slug } } query B { trending { slug } }`},
+		{Query: `query Movie($id: Int!) { movie(id: $id) { title } }`},
+	}
+	for _, request := range requestErrors {
+		response := schema.Execute(context.Background(), &request)
+		if response.Data != nil || len(response.Errors) != 1 {
+			t.Errorf("%s(%q) = %s, want a request error", methodName, request.Query, marshal(t, response))
+		}
+	}
+}
+
+func TestSchema_ExecuteMissingReviews(t *testing.T) {
+	const methodName = "Schema.Execute"
+
+	client, u := newUpstreamWithMoviePage(t, "missing_reviews")
+	response := graphql.NewSchema(client).Execute(context.Background(), &graphql.Request{Query: `{
+		movie(id: 57427) { director { name } reviews { author } reviewsMoreLink comments { author } }
+	}`})
+
+	// only the fields scraped from the missing reviews fail.
+	wantData := `{"movie":{"director":{"name":"Christopher Nolan"},"reviews":null,"reviewsMoreLink":null,` +
+		`"comments":[{"author":"aaron2023"},{"author":"AmanS666"},{"author":"zorg2"}]}}`
+	assertEqual(t, methodName, marshal(t, response.Data), wantData)
+	assertEqual(t, methodName, len(response.Errors), 2)
+	for _, err := range response.Errors {
+		assertError(t, methodName, err, yts.ErrContentRetrievalFailure)
+	}
+	assertEqual(t, methodName, u.count("/movies/oppenheimer-2023"), 1)
+
+	// the comments are only fetched when selected.
+	client, u = newUpstream(t)
+	response = graphql.NewSchema(client).Execute(context.Background(), &graphql.Request{
+		Query: `{ movie(id: 57427) { director { name } } }`,
+	})
+	assertEqual(t, methodName, len(response.Errors), 0)
+	assertEqual(t, methodName, u.count("/ajax/comments/57427"), 0)
+}
+
+func TestSchema_ExecuteBatching(t *testing.T) {
+	const methodName = "Schema.Execute"
+
+	client, u := newUpstream(t)
+	response := graphql.NewSchema(client).Execute(context.Background(), &graphql.Request{Query: `{
+		search { movies { director { name } reviews { author } comments { id } likeCount } }
+		movie(id: 57427) { director { name } comments(page: 1) { id } }
+	}`})
+
+	assertEqual(t, methodName, len(response.Errors), 0)
+	assertEqual(t, methodName, u.count("/movies/oppenheimer-2023"), 1)
+	assertEqual(t, methodName, u.count("/ajax/comments/57427"), 1)
+	assertEqual(t, methodName, u.count("/movie_details.json"), 1)
+}
+
+func TestSchema_ServeHTTP(t *testing.T) {
+	const methodName = "Schema.ServeHTTP"
+
+	client, _ := newUpstream(t)
+	schema := graphql.NewSchema(client)
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "executes post requests",
+			method:     http.MethodPost,
+			target:     "/graphql",
+			body:       `{"query":"query($id: Int) { movie(id: $id) { slug } }","variables":{"id":57427}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"movie":{"slug":"oppenheimer-2023"}}}`,
+		},
+		{
+			name:       "executes get requests",
+			method:     http.MethodGet,
+			target:     "/graphql?query=" + url.QueryEscape(`{ movie(id: 57427) { year } }`),
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data":{"movie":{"year":2023}}}`,
+		},
+		{
+			name:       "returns bad request for malformed body",
+			method:     http.MethodPost,
+			target:     "/graphql",
+			body:       `{"query":`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"data":null,"errors":[{"message":"request body must be a JSON object: unexpected end of JSON input"}]}`,
+		},
+		{
+			name:       "returns bad request for syntax errors",
+			method:     http.MethodGet,
+			target:     "/graphql?query=" + url.QueryEscape(`{ movie(id: ) }`),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"data":null,"errors":[{"message":"syntax error at 1:13: unexpected \")\""}]}`,
+		},
+		{
+			name:       "returns method not allowed for put",
+			method:     http.MethodPut,
+			target:     "/graphql",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   `{"data":null,"errors":[{"message":"method PUT is not allowed"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			schema.ServeHTTP(recorder, request)
+
+			assertEqual(t, methodName, recorder.Code, tt.wantStatus)
+			assertEqual(t, methodName, recorder.Header().Get("Content-Type"), "application/json; charset=utf-8")
+			assertEqual(t, methodName, strings.TrimSpace(recorder.Body.String()), tt.wantBody)
+		})
+	}
+}
+
+func TestSchema_SDL(t *testing.T) {
+	const methodName = "Schema.SDL"
+
+	sdl := graphql.NewSchema(yts.NewClient()).SDL()
+	for _, want := range []string{
+		"type Query {",
+		"  movie(id: Int, slug: String, imdbId: String): Movie\n",
+		"  comments(page: Int = 1): [Comment]\n",
+		"type Comment {",
+		"  replies: [Comment]\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("%s() = %s, want it to contain %q", methodName, sdl, want)
+		}
+	}
+	assertEqual(t, methodName, strings.Count(sdl, "type Comment {"), 1)
+}
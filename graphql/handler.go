@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxRequestBodySize is the maximum size of the body of a POST request.
+const maxRequestBodySize = 1 << 20
+
+// ServeHTTP implements the http.Handler interface, serving GraphQL requests sent as
+// the JSON body of a POST request or as the query, operationName and variables query
+// parameters of a GET request. Requests which could not be executed are responded
+// to with status 400, any other request with status 200.
+func (s *Schema) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		request *Request
+		err     error
+	)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		request, err = requestFromQuery(r)
+	case http.MethodPost:
+		request, err = requestFromBody(r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		writeResponse(w, http.StatusMethodNotAllowed, requestError(fmt.Errorf("method %s is not allowed", r.Method)))
+		return
+	}
+
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, requestError(err))
+		return
+	}
+
+	response := s.Execute(r.Context(), request)
+	status := http.StatusOK
+	if response.Data == nil {
+		status = http.StatusBadRequest
+	}
+
+	writeResponse(w, status, response)
+}
+
+func requestFromQuery(r *http.Request) (*Request, error) {
+	query := r.URL.Query()
+	request := &Request{
+		Query:         query.Get("query"),
+		OperationName: query.Get("operationName"),
+	}
+
+	if raw := query.Get("variables"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &request.Variables); err != nil {
+			return nil, fmt.Errorf("variables must be a JSON object: %s", err)
+		}
+	}
+
+	return request, nil
+}
+
+func requestFromBody(r *http.Request) (*Request, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("reading request body: %s", err)
+	case maxRequestBodySize < len(body):
+		return nil, fmt.Errorf("request body exceeds %d bytes", maxRequestBodySize)
+	}
+
+	request := &Request{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return nil, fmt.Errorf("request body must be a JSON object: %s", err)
+	}
+	return request, nil
+}
+
+func writeResponse(w http.ResponseWriter, status int, response *Response) {
+	body, err := marshalJSON(response)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body, _ = marshalJSON(requestError(err))
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
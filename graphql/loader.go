@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+)
+
+// A call is a load in progress or completed for a key of a loader, done is closed
+// once value and err are set.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// A loader memoizes the values fetched for its keys for the duration of a request,
+// so that fields resolving the same key, such as the director and reviews of a
+// movie which are scraped from the same page, share a single fetch. Concurrent
+// loads of the same key wait for the first one, fetches of distinct keys are
+// limited by the semaphore shared by the loaders of the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, key K) (V, error)
+	sem   chan struct{}
+
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+func newLoader[K comparable, V any](sem chan struct{}, fetch func(context.Context, K) (V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, sem: sem, calls: make(map[K]*call[V])}
+}
+
+// load returns the value for key, fetching it unless it has already been loaded.
+// A context error is returned when ctx is done before the value is available.
+func (l *loader[K, V]) load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	c, ok := l.calls[key]
+	if !ok {
+		c = &call[V]{done: make(chan struct{})}
+		l.calls[key] = c
+		go l.run(ctx, key, c)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) run(ctx context.Context, key K, c *call[V]) {
+	defer close(c.done)
+	select {
+	case l.sem <- struct{}{}:
+		defer func() { <-l.sem }()
+	case <-ctx.Done():
+		c.err = ctx.Err()
+		return
+	}
+
+	c.value, c.err = l.fetch(ctx, key)
+}
@@ -0,0 +1,693 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// byteOrderMark is ignored like whitespace at the start of a document.
+const byteOrderMark = "\uFEFF"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+// A lexer splits a GraphQL document into tokens, commas and comments are ignored
+// as the specification treats them as whitespace.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at %d:%d: %s", l.line, l.col, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) advance(n int) {
+	for _, r := range l.src[l.pos : l.pos+n] {
+		if r == '\n' {
+			l.line, l.col = l.line+1, 1
+		} else {
+			l.col++
+		}
+	}
+	l.pos += n
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			l.advance(end)
+		case strings.HasPrefix(l.src[l.pos:], byteOrderMark):
+			l.advance(len(byteOrderMark))
+		default:
+			return
+		}
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	tok := token{line: l.line, col: l.col}
+	if len(l.src) <= l.pos {
+		return tok, nil
+	}
+
+	rest := l.src[l.pos:]
+	switch c := rest[0]; {
+	case strings.HasPrefix(rest, "..."):
+		tok.kind, tok.text = tokenPunct, "..."
+	case strings.ContainsRune("!$():=@[]{}|&", rune(c)):
+		tok.kind, tok.text = tokenPunct, rest[:1]
+	case isNameStart(c):
+		end := 1
+		for end < len(rest) && (isNameStart(rest[end]) || isDigit(rest[end])) {
+			end++
+		}
+		tok.kind, tok.text = tokenName, rest[:end]
+	case c == '-' || isDigit(c):
+		return l.number(tok)
+	case c == '"':
+		return l.string(tok)
+	default:
+		r, _ := utf8.DecodeRuneInString(rest)
+		return tok, l.errorf("unexpected character %q", r)
+	}
+
+	l.advance(len(tok.text))
+	return tok, nil
+}
+
+func (l *lexer) number(tok token) (token, error) {
+	var (
+		rest = l.src[l.pos:]
+		end  = 0
+	)
+
+	if rest[end] == '-' {
+		end++
+	}
+
+	digits := func() {
+		for end < len(rest) && isDigit(rest[end]) {
+			end++
+		}
+	}
+
+	digits()
+	tok.kind = tokenInt
+	if end < len(rest) && rest[end] == '.' {
+		end++
+		tok.kind = tokenFloat
+		digits()
+	}
+
+	if end < len(rest) && (rest[end] == 'e' || rest[end] == 'E') {
+		end++
+		tok.kind = tokenFloat
+		if end < len(rest) && (rest[end] == '+' || rest[end] == '-') {
+			end++
+		}
+		digits()
+	}
+
+	tok.text = rest[:end]
+	if tok.text == "-" || (end < len(rest) && (isNameStart(rest[end]) || rest[end] == '.')) {
+		return tok, l.errorf("invalid number %q", rest[:min(end+1, len(rest))])
+	}
+
+	l.advance(end)
+	return tok, nil
+}
+
+func (l *lexer) string(tok token) (token, error) {
+	rest := l.src[l.pos:]
+	if strings.HasPrefix(rest, `"""`) {
+		end := strings.Index(rest[3:], `"""`)
+		if end < 0 {
+			return tok, l.errorf("unterminated block string")
+		}
+		tok.kind, tok.text = tokenString, strings.TrimSpace(rest[3:3+end])
+		l.advance(end + 6)
+		return tok, nil
+	}
+
+	end := 1
+	for ; end < len(rest) && rest[end] != '"'; end++ {
+		switch rest[end] {
+		case '\\':
+			end++
+		case '\n':
+			return tok, l.errorf("unterminated string")
+		}
+	}
+
+	if len(rest) <= end {
+		return tok, l.errorf("unterminated string")
+	}
+
+	// GraphQL string escapes are a subset of the JSON ones, which strconv.Unquote
+	// handles, apart from "\/" which is rewritten beforehand.
+	text, err := strconv.Unquote(strings.ReplaceAll(rest[:end+1], `\/`, "/"))
+	if err != nil {
+		return tok, l.errorf("invalid string %s", rest[:end+1])
+	}
+
+	tok.kind, tok.text = tokenString, text
+	l.advance(end + 1)
+	return tok, nil
+}
+
+type (
+	// A variableRef is a value referring to a variable of the operation.
+	variableRef string
+
+	// An enumValue is a bare name used as a value, such as DESC.
+	enumValue string
+)
+
+type argument struct {
+	name  string
+	value any
+}
+
+type directive struct {
+	name string
+	args []argument
+}
+
+// A selection is one of *fieldNode, *fragmentSpread or *inlineFragment.
+type selection interface{}
+
+type fieldNode struct {
+	alias      string
+	name       string
+	args       []argument
+	directives []directive
+	selections []selection
+	line       int
+	col        int
+}
+
+func (f *fieldNode) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []directive
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []directive
+	selections    []selection
+}
+
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+type variableDef struct {
+	name         string
+	typ          *typeRef
+	defaultValue any
+	hasDefault   bool
+}
+
+type operation struct {
+	kind       string
+	name       string
+	variables  []variableDef
+	directives []directive
+	selections []selection
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	selections    []selection
+}
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+// parse parses a GraphQL document made of operations and fragment definitions,
+// type system definitions are not supported.
+func parse(src string) (*document, error) {
+	p := &parser{lexer: &lexer{src: src, line: 1, col: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenName, "fragment"):
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, fmt.Errorf("fragment %q is defined more than once", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		}
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("document does not contain any operation")
+	}
+
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	p.tok = tok
+	return err
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at %d:%d: %s", p.tok.line, p.tok.col, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek(kind tokenKind, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+// skip consumes the current token when it matches and reports whether it did.
+func (p *parser) skip(kind tokenKind, text string) (bool, error) {
+	if !p.peek(kind, text) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if !p.peek(kind, text) {
+		return p.errorf("expected %q, found %q", text, p.tok.text)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.errorf("expected name, found %q", p.tok.text)
+	}
+	name := p.tok.text
+	return name, p.advance()
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: "query"}
+	if p.peek(tokenPunct, "{") {
+		selections, err := p.parseSelectionSet()
+		op.selections = selections
+		return op, err
+	}
+
+	kind, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "query", "mutation", "subscription":
+		op.kind = kind
+	default:
+		return nil, fmt.Errorf("syntax error: unexpected %q", kind)
+	}
+
+	if p.tok.kind == tokenName {
+		op.name = p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if op.variables, err = p.parseVariableDefs(); err != nil {
+		return nil, err
+	}
+
+	if op.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	op.selections, err = p.parseSelectionSet()
+	return op, err
+}
+
+func (p *parser) parseVariableDefs() ([]variableDef, error) {
+	if ok, err := p.skip(tokenPunct, "("); !ok || err != nil {
+		return nil, err
+	}
+
+	defs := make([]variableDef, 0)
+	for !p.peek(tokenPunct, ")") {
+		if err := p.expect(tokenPunct, "$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		def := variableDef{name: name, typ: typ}
+		if ok, err := p.skip(tokenPunct, "="); err != nil {
+			return nil, err
+		} else if ok {
+			if def.defaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+			def.hasDefault = true
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, p.advance()
+}
+
+func (p *parser) parseType() (*typeRef, error) {
+	typ := &typeRef{}
+	if ok, err := p.skip(tokenPunct, "["); err != nil {
+		return nil, err
+	} else if ok {
+		if typ.elem, err = p.parseType(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenPunct, "]"); err != nil {
+			return nil, err
+		}
+	} else if typ.name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+
+	nonNull, err := p.skip(tokenPunct, "!")
+	typ.nonNull = nonNull
+	return typ, err
+}
+
+func (p *parser) parseDirectives() ([]directive, error) {
+	directives := make([]directive, 0)
+	for p.peek(tokenPunct, "@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		args, err := p.parseArguments()
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, directive{name, args})
+	}
+	return directives, nil
+}
+
+func (p *parser) parseArguments() ([]argument, error) {
+	if ok, err := p.skip(tokenPunct, "("); !ok || err != nil {
+		return nil, err
+	}
+
+	args := make([]argument, 0)
+	for !p.peek(tokenPunct, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, argument{name, value})
+	}
+
+	return args, p.advance()
+}
+
+// parseValue parses a value literal, variables are not allowed within constant
+// values such as the default values of variables.
+func (p *parser) parseValue(constant bool) (any, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenPunct && tok.text == "$" && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return variableRef(name), err
+	case tok.kind == tokenPunct && tok.text == "[":
+		return p.parseListValue(constant)
+	case tok.kind == tokenPunct && tok.text == "{":
+		return p.parseObjectValue(constant)
+	case tok.kind == tokenInt:
+		value, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return nil, p.errorf("invalid integer %q", tok.text)
+		}
+		return value, p.advance()
+	case tok.kind == tokenFloat:
+		value, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid float %q", tok.text)
+		}
+		return value, p.advance()
+	case tok.kind == tokenString:
+		return tok.text, p.advance()
+	case tok.kind == tokenName:
+		values := map[string]any{"true": true, "false": false, "null": nil}
+		value, ok := values[tok.text]
+		if !ok {
+			value = enumValue(tok.text)
+		}
+		return value, p.advance()
+	default:
+		return nil, p.errorf("unexpected %q", tok.text)
+	}
+}
+
+func (p *parser) parseListValue(constant bool) (any, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0)
+	for !p.peek(tokenPunct, "]") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.errorf("unterminated list")
+		}
+
+		value, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, p.advance()
+}
+
+func (p *parser) parseObjectValue(constant bool) (any, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+	for !p.peek(tokenPunct, "}") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		if values[name], err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+	}
+	return values, p.advance()
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if err := p.expect(tokenPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	selections := make([]selection, 0)
+	for !p.peek(tokenPunct, "}") {
+		if p.tok.kind == tokenEOF {
+			return nil, p.errorf("unterminated selection set")
+		}
+
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+
+	return selections, p.advance()
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	if ok, err := p.skip(tokenPunct, "..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.parseFragmentSelection()
+	}
+
+	field := &fieldNode{line: p.tok.line, col: p.tok.col}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	field.name = name
+	if ok, err := p.skip(tokenPunct, ":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.alias = name
+		if field.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if field.args, err = p.parseArguments(); err != nil {
+		return nil, err
+	}
+
+	if field.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if p.peek(tokenPunct, "{") {
+		field.selections, err = p.parseSelectionSet()
+	}
+
+	return field, err
+}
+
+func (p *parser) parseFragmentSelection() (selection, error) {
+	if p.tok.kind == tokenName && p.tok.text != "on" {
+		spread := &fragmentSpread{name: p.tok.text}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		directives, err := p.parseDirectives()
+		spread.directives = directives
+		return spread, err
+	}
+
+	inline := &inlineFragment{}
+	if ok, err := p.skip(tokenName, "on"); err != nil {
+		return nil, err
+	} else if ok {
+		if inline.typeCondition, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if inline.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	inline.selections, err = p.parseSelectionSet()
+	return inline, err
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+
+	typeCondition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	return &fragment{name, typeCondition, selections}, err
+}
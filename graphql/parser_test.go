@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const methodName = "parse"
+
+	doc, err := parse(`
+		# comments and commas are ignored
+		query Search($term: String = "dark", $limit: [Int!]!) @cached {
+			alias: search(query: $term, limit: 2.5e1, genre: DRAMA, x: [1, {a: null}], y: """ block """) {
+				...on SearchResult { movieCount }
+				...page @skip(if: false)
+			}
+		}
+		fragment page on SearchResult { pageNumber, limit }`)
+	if err != nil {
+		t.Fatalf("%s() error = %v", methodName, err)
+	}
+
+	op := doc.operations[0]
+	if op.kind != "query" || op.name != "Search" || len(op.variables) != 2 {
+		t.Fatalf("%s() operation = %+v, want query Search with 2 variables", methodName, op)
+	}
+
+	if got := typeRefString(op.variables[1].typ); got != "[Int!]!" {
+		t.Errorf("%s() variable type = %s, want [Int!]!", methodName, got)
+	}
+
+	field := op.selections[0].(*fieldNode)
+	wantArgs := []argument{
+		{"query", variableRef("term")},
+		{"limit", 25.0},
+		{"genre", enumValue("DRAMA")},
+		{"x", []any{1, map[string]any{"a": nil}}},
+		{"y", "block"},
+	}
+	if field.alias != "alias" || !reflect.DeepEqual(field.args, wantArgs) {
+		t.Errorf("%s() field = %+v, want alias with args %v", methodName, field, wantArgs)
+	}
+
+	if _, ok := doc.fragments["page"]; !ok || len(field.selections) != 2 {
+		t.Errorf("%s() fragments = %v, want fragment page spread in alias", methodName, doc.fragments)
+	}
+
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{`{ movie(id: 1) { title }`, "syntax error at 1:25"},
+		{`{ movie(id: 01x) }`, "invalid number"},
+		{`{ movie(slug: "unterminated) }`, "unterminated string"},
+		{`query ($id: Int = $other) { movie }`, "syntax error at 1:19"},
+		{`fragment a on Movie { id }`, "document does not contain any operation"},
+		{`{ a } fragment b on Movie { id } fragment b on Movie { id }`, "defined more than once"},
+		{`{ movie(id: 1) ; }`, "unexpected character ';'"},
+	}
+	for _, tt := range tests {
+		_, err := parse(tt.src)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s(%q) error = %v, want it to contain %q", methodName, tt.src, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// maxConcurrentFetches is the maximum number of requests made to YTS concurrently
+// while executing a single GraphQL request.
+const maxConcurrentFetches = 4
+
+// A Schema executes GraphQL requests against the movies, torrents and scraped
+// content of YTS, see the package documentation for the types it defines.
+type Schema struct {
+	client *yts.Client
+	query  *objectType
+}
+
+// NewSchema creates a *Schema resolving fields using the provided client.
+func NewSchema(client *yts.Client) *Schema {
+	s := &Schema{client: client}
+	s.query = s.buildQueryType()
+	return s
+}
+
+// SDL returns the schema in the GraphQL schema definition language.
+func (s *Schema) SDL() string {
+	b := &strings.Builder{}
+	s.query.sdl(b, make(map[string]bool))
+	return b.String()
+}
+
+// Execute executes the operation of the provided request. Fields whose resolution
+// fails are set to null and reported in the Errors of the returned Response, the
+// other fields of the request are still resolved.
+func (s *Schema) Execute(ctx context.Context, r *Request) *Response {
+	doc, err := parse(r.Query)
+	if err != nil {
+		return requestError(err)
+	}
+
+	op, err := selectOperation(doc, r.OperationName)
+	if err != nil {
+		return requestError(err)
+	}
+
+	if op.kind != "query" {
+		return requestError(fmt.Errorf("%s operations are not supported", op.kind))
+	}
+
+	variables, err := coerceVariables(op, r.Variables)
+	if err != nil {
+		return requestError(err)
+	}
+
+	e := &executor{fragments: doc.fragments, variables: variables}
+	ctx = context.WithValue(ctx, loadersKey{}, s.newLoaders())
+	data := e.executeSelections(ctx, s.query, nil, op.selections, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+type loadersKey struct{}
+
+type commentsKey struct {
+	moviePage *yts.MoviePage
+	page      int
+}
+
+// The loaders of a request, the movie page of a slug is fetched once by pages for
+// the director, reviews and comments of the movie, which are then scraped from it
+// independently so that each of these fields only fails on its own.
+type loaders struct {
+	details  *loader[int, *yts.MovieDetails]
+	slugs    *loader[string, int]
+	pages    *loader[string, *yts.MoviePage]
+	comments *loader[commentsKey, []yts.SiteMovieComment]
+}
+
+func (s *Schema) newLoaders() *loaders {
+	sem := make(chan struct{}, maxConcurrentFetches)
+	return &loaders{
+		details: newLoader(sem, func(ctx context.Context, id int) (*yts.MovieDetails, error) {
+			response, err := s.client.MovieDetailsWithContext(ctx, id, yts.DefaultMovieDetailsFilters())
+			if err != nil {
+				return nil, err
+			}
+			return &response.Data.Movie, nil
+		}),
+		slugs: newLoader(sem, s.client.ResolveMovieSlugToIDWithContext),
+		pages: newLoader(sem, s.client.MoviePageWithContext),
+		comments: newLoader(sem, func(ctx context.Context, key commentsKey) ([]yts.SiteMovieComment, error) {
+			response, err := key.moviePage.CommentsWithContext(ctx, key.page)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data.Comments, nil
+		}),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// sourceField creates a field resolved from the source of its object, which must be
+// of type S, using get.
+func sourceField[S any](name string, typ *gqlType, get func(S) any) *fieldDef {
+	return &fieldDef{
+		name: name,
+		typ:  typ,
+		resolve: func(_ context.Context, source any, _ map[string]any) (any, error) {
+			return get(source.(S)), nil
+		},
+	}
+}
+
+// optional returns nil for the zero value of a scraped field, which is absent from
+// the page it was scraped from, rather than an empty value.
+func optional[T comparable](value T) any {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return value
+}
+
+// optionalRating returns nil for the zero yts.Rating, ratings are not compared with
+// optional as their Raw text makes equal ratings unequal under ==.
+func optionalRating(rating yts.Rating) any {
+	if rating.IsZero() {
+		return nil
+	}
+	return rating
+}
+
+func (s *Schema) buildQueryType() *objectType {
+	var (
+		movie      = s.buildMovieType()
+		query      = newObjectType("Query", "")
+		searchType = newObjectType("SearchResult", "A page of the results of a movie search.")
+		siteMovie  = newObjectType("SiteMovie", "A movie card shown on the YTS website.")
+	)
+
+	searchType.addField(sourceField("movieCount", intType, func(d *yts.SearchMoviesData) any { return d.MovieCount }))
+	searchType.addField(sourceField("limit", intType, func(d *yts.SearchMoviesData) any { return d.Limit }))
+	searchType.addField(sourceField("pageNumber", intType, func(d *yts.SearchMoviesData) any { return d.PageNumber }))
+	searchType.addField(sourceField("movies", listOf(movie.gqlType()), func(d *yts.SearchMoviesData) any {
+		movies := make([]*yts.MoviePartial, len(d.Movies))
+		for i := range d.Movies {
+			movies[i] = &d.Movies[i].MoviePartial
+		}
+		return movies
+	}))
+
+	siteMovie.addField(sourceField("slug", stringType, func(m yts.SiteMovie) any { return m.Slug }))
+	siteMovie.addField(sourceField("title", stringType, func(m yts.SiteMovie) any { return m.Title }))
+	siteMovie.addField(sourceField("year", intType, func(m yts.SiteMovie) any { return m.Year }))
+	siteMovie.addField(sourceField("link", stringType, func(m yts.SiteMovie) any { return m.Link }))
+	siteMovie.addField(sourceField("image", stringType, func(m yts.SiteMovie) any { return m.Image }))
+	siteMovie.addField(sourceField("genres", listOf(stringType), func(m yts.SiteMovie) any { return m.Genres }))
+	siteMovie.addField(sourceField("rating", stringType, func(m yts.SiteMovie) any { return optionalRating(m.Rating) }))
+	siteMovie.addField(&fieldDef{
+		name:        "movie",
+		typ:         movie.gqlType(),
+		description: "The movie details, the movie slug is resolved to its ID first.",
+		resolve: func(ctx context.Context, source any, _ map[string]any) (any, error) {
+			return s.movieBySlug(ctx, source.(yts.SiteMovie).Slug)
+		},
+	})
+
+	query.addField(&fieldDef{
+		name:        "movie",
+		typ:         movie.gqlType(),
+		description: "A movie looked up by exactly one of its YTS ID, slug or IMDb ID.",
+		args: []*argDef{
+			{name: "id", typ: intType},
+			{name: "slug", typ: stringType},
+			{name: "imdbId", typ: stringType},
+		},
+		resolve: s.resolveMovie,
+	})
+
+	query.addField(&fieldDef{
+		name:        "search",
+		typ:         searchType.gqlType(),
+		description: "Searches movies, the arguments mirror the list_movies.json filters.",
+		args: []*argDef{
+			{name: "query", typ: stringType, defaultValue: ""},
+			{name: "limit", typ: intType, defaultValue: 20},
+			{name: "page", typ: intType, defaultValue: 1},
+			{name: "quality", typ: stringType, defaultValue: string(yts.QualityAll)},
+			{name: "minimumRating", typ: intType, defaultValue: 0},
+			{name: "genre", typ: stringType, defaultValue: string(yts.GenreAll)},
+			{name: "sortBy", typ: stringType, defaultValue: string(yts.SortByDateAdded)},
+			{name: "orderBy", typ: stringType, defaultValue: string(yts.OrderByDesc)},
+		},
+		resolve: s.resolveSearch,
+	})
+
+	query.addField(&fieldDef{
+		name:        "trending",
+		typ:         listOf(siteMovie.gqlType()),
+		description: "The movies trending on the YTS website.",
+		resolve: func(ctx context.Context, _ any, _ map[string]any) (any, error) {
+			response, err := s.client.TrendingMoviesWithContext(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data.Movies, nil
+		},
+	})
+
+	return query
+}
+
+func (s *Schema) resolveMovie(ctx context.Context, _ any, args map[string]any) (any, error) {
+	provided := 0
+	for _, name := range []string{"id", "slug", "imdbId"} {
+		if args[name] != nil {
+			provided++
+		}
+	}
+
+	if provided != 1 {
+		err := fmt.Errorf("exactly one of the id, slug or imdbId arguments must be provided")
+		return nil, fmt.Errorf("%w: %s", yts.ErrValidationFailure, err)
+	}
+
+	switch {
+	case args["id"] != nil:
+		details, err := loadersFrom(ctx).details.load(ctx, args["id"].(int))
+		if err != nil {
+			return nil, err
+		}
+		return &details.MoviePartial, nil
+	case args["slug"] != nil:
+		return s.movieBySlug(ctx, args["slug"].(string))
+	default:
+		response, err := s.client.MovieByIMDbIDWithContext(ctx, args["imdbId"].(string))
+		if err != nil {
+			return nil, err
+		}
+		return &response.Data.Movie.MoviePartial, nil
+	}
+}
+
+func (s *Schema) movieBySlug(ctx context.Context, slug string) (*yts.MoviePartial, error) {
+	l := loadersFrom(ctx)
+	id, err := l.slugs.load(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := l.details.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &details.MoviePartial, nil
+}
+
+func (s *Schema) resolveSearch(ctx context.Context, _ any, args map[string]any) (any, error) {
+	filters := yts.DefaultSearchMoviesFilters(args["query"].(string))
+	filters.Limit = args["limit"].(int)
+	filters.Page = args["page"].(int)
+	filters.Quality = yts.Quality(args["quality"].(string))
+	filters.MinimumRating = args["minimumRating"].(int)
+	filters.Genre = yts.Genre(args["genre"].(string))
+	filters.SortBy = yts.SortBy(args["sortBy"].(string))
+	filters.OrderBy = yts.OrderBy(args["orderBy"].(string))
+
+	response, err := s.client.SearchMoviesWithContext(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// A torrent is the source of the Torrent type, the movie being needed for creating
+// the magnet link of the torrent.
+type torrent struct {
+	movie   *yts.MoviePartial
+	torrent yts.Torrent
+}
+
+// GetTorrentInfo implements the yts.TorrentInfoGetter interface, only providing the
+// torrent so that movies with several torrents of the same quality get the magnet
+// link of the right torrent.
+func (t torrent) GetTorrentInfo() *yts.TorrentInfo {
+	return &yts.TorrentInfo{MovieTitle: t.movie.TitleLong, Torrents: []yts.Torrent{t.torrent}}
+}
+
+func (s *Schema) buildMovieType() *objectType {
+	var (
+		movie    = newObjectType("Movie", "A movie of the YTS API.")
+		torrentT = newObjectType("Torrent", "A torrent of a movie.")
+		cast     = newObjectType("Cast", "A cast member of a movie.")
+		director = newObjectType("Director", "The director scraped from the movie page.")
+		review   = newObjectType("Review", "A review scraped from the movie page.")
+		comment  = newObjectType("Comment", "A comment scraped from the movie page.")
+	)
+
+	type mp = *yts.MoviePartial
+	movie.addField(sourceField("id", intType, func(m mp) any { return m.ID }))
+	movie.addField(sourceField("url", stringType, func(m mp) any { return m.URL }))
+	movie.addField(sourceField("imdbCode", stringType, func(m mp) any { return m.ImdbCode }))
+	movie.addField(sourceField("title", stringType, func(m mp) any { return m.Title }))
+	movie.addField(sourceField("titleEnglish", stringType, func(m mp) any { return m.TitleEnglish }))
+	movie.addField(sourceField("titleLong", stringType, func(m mp) any { return m.TitleLong }))
+	movie.addField(sourceField("slug", stringType, func(m mp) any { return m.Slug }))
+	movie.addField(sourceField("year", intType, func(m mp) any { return m.Year }))
+	movie.addField(sourceField("rating", floatType, func(m mp) any { return m.Rating }))
+	movie.addField(sourceField("runtime", intType, func(m mp) any { return m.Runtime }))
+	movie.addField(sourceField("genres", listOf(stringType), func(m mp) any { return m.Genres }))
+	movie.addField(sourceField("descriptionFull", stringType, func(m mp) any { return m.DescriptionFull }))
+	movie.addField(sourceField("ytTrailerCode", stringType, func(m mp) any { return m.YtTrailerCode }))
+	movie.addField(sourceField("language", stringType, func(m mp) any { return m.Language }))
+	movie.addField(sourceField("mpaRating", stringType, func(m mp) any { return m.MpaRating }))
+	movie.addField(sourceField("backgroundImage", stringType, func(m mp) any { return m.BackgroundImage }))
+	movie.addField(sourceField("smallCoverImage", stringType, func(m mp) any { return m.SmallCoverImage }))
+	movie.addField(sourceField("mediumCoverImage", stringType, func(m mp) any { return m.MediumCoverImage }))
+	movie.addField(sourceField("largeCoverImage", stringType, func(m mp) any { return m.LargeCoverImage }))
+	movie.addField(sourceField("dateUploaded", stringType, func(m mp) any { return m.DateUploaded }))
+	movie.addField(sourceField("dateUploadedUnix", intType, func(m mp) any { return m.DateUploadedUnix }))
+	movie.addField(sourceField("torrents", listOf(torrentT.gqlType()), func(m mp) any {
+		torrents := make([]torrent, len(m.Torrents))
+		for i, t := range m.Torrents {
+			torrents[i] = torrent{m, t}
+		}
+		return torrents
+	}))
+
+	// The fields only provided by the movie_details.json endpoint, movies returned
+	// by searches are completed with the details of their ID.
+	details := func(name string, typ *gqlType, get func(*yts.MovieDetails) any) *fieldDef {
+		return &fieldDef{
+			name: name,
+			typ:  typ,
+			resolve: func(ctx context.Context, source any, _ map[string]any) (any, error) {
+				d, err := loadersFrom(ctx).details.load(ctx, source.(mp).ID)
+				if err != nil {
+					return nil, err
+				}
+				return get(d), nil
+			},
+		}
+	}
+
+	movie.addField(details("likeCount", intType, func(d *yts.MovieDetails) any { return d.LikeCount }))
+	movie.addField(details("descriptionIntro", stringType, func(d *yts.MovieDetails) any { return d.DescriptionIntro }))
+	movie.addField(details("cast", listOf(cast.gqlType()), func(d *yts.MovieDetails) any { return d.Cast }))
+
+	// The fields scraped from the movie page, which is fetched once per slug.
+	page := func(name string, typ *gqlType, description string,
+		get func(*yts.MoviePage) (any, error)) *fieldDef {
+		return &fieldDef{
+			name:        name,
+			typ:         typ,
+			description: description,
+			resolve: func(ctx context.Context, source any, _ map[string]any) (any, error) {
+				moviePage, err := loadersFrom(ctx).pages.load(ctx, source.(mp).Slug)
+				if err != nil {
+					return nil, err
+				}
+				return get(moviePage)
+			},
+		}
+	}
+
+	movie.addField(page("director", director.gqlType(), "The director scraped from the movie page.",
+		func(p *yts.MoviePage) (any, error) {
+			response, err := p.Director()
+			if err != nil {
+				return nil, err
+			}
+			return response.Data.Director, nil
+		}))
+	movie.addField(page("reviews", listOf(review.gqlType()), "The reviews shown on the movie page.",
+		func(p *yts.MoviePage) (any, error) {
+			response, err := p.Reviews()
+			if err != nil {
+				return nil, err
+			}
+			return response.Data.Reviews, nil
+		}))
+	movie.addField(page("reviewsMoreLink", stringType, "The link to the page listing every review.",
+		func(p *yts.MoviePage) (any, error) {
+			response, err := p.Reviews()
+			if err != nil {
+				return nil, err
+			}
+			return optional(response.Data.ReviewsMoreLink), nil
+		}))
+	movie.addField(&fieldDef{
+		name:        "comments",
+		typ:         listOf(comment.gqlType()),
+		description: "A page of the comments of the movie.",
+		args:        []*argDef{{name: "page", typ: intType, defaultValue: 1}},
+		resolve: func(ctx context.Context, source any, args map[string]any) (any, error) {
+			l := loadersFrom(ctx)
+			moviePage, err := l.pages.load(ctx, source.(mp).Slug)
+			if err != nil {
+				return nil, err
+			}
+			return l.comments.load(ctx, commentsKey{moviePage, args["page"].(int)})
+		},
+	})
+
+	torrentT.addField(sourceField("url", stringType, func(t torrent) any { return t.torrent.URL }))
+	torrentT.addField(sourceField("hash", stringType, func(t torrent) any { return t.torrent.Hash }))
+	torrentT.addField(sourceField("quality", stringType, func(t torrent) any { return t.torrent.Quality }))
+	torrentT.addField(sourceField("type", stringType, func(t torrent) any { return t.torrent.Type }))
+	torrentT.addField(sourceField("videoCodec", stringType, func(t torrent) any { return t.torrent.VideoCodec }))
+	torrentT.addField(sourceField("seeds", intType, func(t torrent) any { return t.torrent.Seeds }))
+	torrentT.addField(sourceField("peers", intType, func(t torrent) any { return t.torrent.Peers }))
+	torrentT.addField(sourceField("size", stringType, func(t torrent) any { return t.torrent.Size }))
+	torrentT.addField(sourceField("sizeBytes", intType, func(t torrent) any { return t.torrent.SizeBytes }))
+	torrentT.addField(sourceField("dateUploaded", stringType, func(t torrent) any { return t.torrent.DateUploaded }))
+	torrentT.addField(sourceField("magnet", stringType, func(t torrent) any {
+		return s.client.MagnetLinks(t)[t.torrent.Quality]
+	}))
+
+	cast.addField(sourceField("name", stringType, func(c yts.Cast) any { return c.Name }))
+	cast.addField(sourceField("characterName", stringType, func(c yts.Cast) any { return c.CharacterName }))
+	cast.addField(sourceField("imdbCode", stringType, func(c yts.Cast) any { return c.ImdbCode }))
+	cast.addField(sourceField("imageUrl", stringType, func(c yts.Cast) any { return optional(c.URLSmallImage) }))
+
+	director.addField(sourceField("name", stringType, func(d yts.SiteMovieDirector) any { return d.Name }))
+	director.addField(sourceField("imageUrl", stringType, func(d yts.SiteMovieDirector) any {
+		return optional(d.URLSmallImage)
+	}))
+
+	review.addField(sourceField("author", stringType, func(r yts.SiteMovieReview) any { return r.Author }))
+	review.addField(sourceField("title", stringType, func(r yts.SiteMovieReview) any { return r.Title }))
+	review.addField(sourceField("content", stringType, func(r yts.SiteMovieReview) any { return r.Content }))
+	review.addField(sourceField("rating", stringType, func(r yts.SiteMovieReview) any { return optionalRating(r.Rating) }))
+	review.addField(sourceField("date", stringType, func(r yts.SiteMovieReview) any { return optional(r.Date) }))
+
+	comment.addField(sourceField("id", intType, func(c yts.SiteMovieComment) any { return c.ID }))
+	comment.addField(sourceField("author", stringType, func(c yts.SiteMovieComment) any { return c.Author }))
+	comment.addField(sourceField("content", stringType, func(c yts.SiteMovieComment) any { return c.Content }))
+	comment.addField(sourceField("timestamp", stringType, func(c yts.SiteMovieComment) any { return c.Timestamp }))
+	comment.addField(sourceField("postedAt", stringType, func(c yts.SiteMovieComment) any {
+		if c.PostedAt.IsZero() {
+			return nil
+		}
+		return c.PostedAt.Format(time.RFC3339)
+	}))
+	comment.addField(sourceField("likeCount", intType, func(c yts.SiteMovieComment) any { return c.LikeCount }))
+	comment.addField(sourceField("replies", listOf(comment.gqlType()), func(c yts.SiteMovieComment) any {
+		return c.Replies
+	}))
+
+	return movie
+}
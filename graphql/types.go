@@ -0,0 +1,256 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type typeKind int
+
+const (
+	kindScalar typeKind = iota
+	kindObject
+	kindList
+)
+
+// A gqlType is a type of the schema, the named types being the built in scalars
+// and objects, lists wrap the type of their elements in elem.
+type gqlType struct {
+	kind    typeKind
+	name    string
+	object  *objectType
+	elem    *gqlType
+	nonNull bool
+}
+
+var (
+	intType    = &gqlType{kind: kindScalar, name: "Int"}
+	floatType  = &gqlType{kind: kindScalar, name: "Float"}
+	stringType = &gqlType{kind: kindScalar, name: "String"}
+)
+
+func listOf(elem *gqlType) *gqlType {
+	return &gqlType{kind: kindList, elem: elem}
+}
+
+func (t *gqlType) String() string {
+	name := t.name
+	if t.kind == kindList {
+		name = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		name += "!"
+	}
+	return name
+}
+
+// A resolveFunc resolves the value of a field for the source value of its parent
+// object, args holding the coerced arguments of the field.
+type resolveFunc func(ctx context.Context, source any, args map[string]any) (any, error)
+
+type argDef struct {
+	name         string
+	typ          *gqlType
+	defaultValue any
+}
+
+type fieldDef struct {
+	name        string
+	typ         *gqlType
+	args        []*argDef
+	resolve     resolveFunc
+	description string
+}
+
+type objectType struct {
+	name        string
+	description string
+	fields      []*fieldDef
+	byName      map[string]*fieldDef
+}
+
+func newObjectType(name, description string) *objectType {
+	return &objectType{name: name, description: description, byName: make(map[string]*fieldDef)}
+}
+
+func (o *objectType) addField(f *fieldDef) {
+	o.fields = append(o.fields, f)
+	o.byName[f.name] = f
+}
+
+func (o *objectType) gqlType() *gqlType {
+	return &gqlType{kind: kindObject, name: o.name, object: o}
+}
+
+// sdl writes the object type in the schema definition language, along with the
+// object types reachable from it which have not been written yet.
+func (o *objectType) sdl(b *strings.Builder, written map[string]bool) {
+	if written[o.name] {
+		return
+	}
+	written[o.name] = true
+
+	writeDescription(b, "", o.description)
+	fmt.Fprintf(b, "type %s {\n", o.name)
+	for _, f := range o.fields {
+		writeDescription(b, "  ", f.description)
+		b.WriteString("  " + f.name)
+		if len(f.args) != 0 {
+			args := make([]string, 0, len(f.args))
+			for _, arg := range f.args {
+				text := arg.name + ": " + arg.typ.String()
+				if arg.defaultValue != nil {
+					text += " = " + formatValue(arg.defaultValue)
+				}
+				args = append(args, text)
+			}
+			b.WriteString("(" + strings.Join(args, ", ") + ")")
+		}
+		b.WriteString(": " + f.typ.String() + "\n")
+	}
+	b.WriteString("}\n")
+
+	for _, f := range o.fields {
+		t := f.typ
+		for t.kind == kindList {
+			t = t.elem
+		}
+		if t.kind == kindObject && !written[t.name] {
+			b.WriteString("\n")
+			t.object.sdl(b, written)
+		}
+	}
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		fmt.Fprintf(b, "%s%q\n", indent, description)
+	}
+}
+
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(value)
+}
+
+// coerceInput coerces an input value, resolved from a literal or a variable, to the
+// scalar type t. Integral floats are accepted for Int as JSON variables are decoded
+// into float64 values.
+func coerceInput(t *gqlType, value any) (any, error) {
+	if value == nil {
+		if t.nonNull {
+			return nil, fmt.Errorf("expected a non null %s", t)
+		}
+		return nil, nil
+	}
+
+	if t.kind == kindList {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+
+		coerced := make([]any, len(values))
+		for i, v := range values {
+			var err error
+			if coerced[i], err = coerceInput(t.elem, v); err != nil {
+				return nil, err
+			}
+		}
+		return coerced, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		switch t.name {
+		case "Int":
+			return v, nil
+		case "Float":
+			return float64(v), nil
+		}
+	case float64:
+		switch {
+		case t.name == "Float":
+			return v, nil
+		case t.name == "Int" && v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32:
+			return int(v), nil
+		}
+	case string:
+		if t.name == "String" {
+			return v, nil
+		}
+	case bool:
+		if t.name == "Boolean" {
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected a value of type %s, found %s", t, formatInput(value))
+}
+
+func formatInput(value any) string {
+	switch v := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case enumValue:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// serialize converts a resolved value to the JSON value of the scalar type named
+// name, values of types derived from the built in kinds such as yts.Quality are
+// accepted.
+func serialize(name string, value any) (any, error) {
+	if s, ok := value.(fmt.Stringer); ok && name == "String" {
+		return s.String(), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case name == "Int" && v.CanInt():
+		return v.Int(), nil
+	case name == "Float" && v.CanFloat():
+		return v.Float(), nil
+	case name == "Float" && v.CanInt():
+		return float64(v.Int()), nil
+	case name == "String" && v.Kind() == reflect.String:
+		return v.String(), nil
+	case name == "Boolean" && v.Kind() == reflect.Bool:
+		return v.Bool(), nil
+	}
+
+	return nil, fmt.Errorf("cannot serialize %T as %s", value, name)
+}
+
+// isNil reports whether a resolved value is nil, including nil pointers and slices
+// held by the interface.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,133 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	objectsDir = "objects"
+	entriesDir = "entries"
+)
+
+// An entry records the cached image of a URL, the image itself is stored as an
+// object named after the SHA-256 hash of its content, so that an image served at
+// several URLs is stored once.
+type entry struct {
+	URL          string    `json:"url"`
+	Object       string    `json:"object"`
+	ContentType  string    `json:"content_type,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ValidatedAt  time.Time `json:"validated_at"`
+}
+
+func hashString(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func (f *Fetcher) entryPath(imageURL string) string {
+	return filepath.Join(f.dir, entriesDir, hashString(imageURL)+".json")
+}
+
+func (f *Fetcher) objectPath(object string) string {
+	return filepath.Join(f.dir, objectsDir, object[:2], object)
+}
+
+// loadEntry returns the cached entry of the provided URL, or nil when the URL has
+// not been cached or its object is missing.
+func (f *Fetcher) loadEntry(imageURL string) (*entry, error) {
+	payload, err := os.ReadFile(f.entryPath(imageURL))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &entry{}
+	if err := json.Unmarshal(payload, e); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.entryPath(imageURL), err)
+	}
+
+	if _, err := os.Stat(f.objectPath(e.Object)); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return e, nil
+}
+
+func (f *Fetcher) saveEntry(e *entry) error {
+	payload, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFile(f.entryPath(e.URL), func(w io.Writer) error {
+		_, err := w.Write(payload)
+		return err
+	})
+}
+
+// saveObject stores the image read from r and returns the name of its object, the
+// object is named after the hash of the image and the extension ext.
+func (f *Fetcher) saveObject(r io.Reader, ext string) (string, error) {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return "", err
+	}
+
+	temp, err := os.CreateTemp(f.dir, ".image-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(temp.Name())
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(temp, hash), r); err != nil {
+		temp.Close()
+		return "", err
+	}
+	if err := temp.Close(); err != nil {
+		return "", err
+	}
+
+	object := hex.EncodeToString(hash.Sum(nil)) + ext
+	path := f.objectPath(object)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	return object, os.Rename(temp.Name(), path)
+}
+
+// writeFile atomically writes the file at path with the content written by write,
+// creating its directory if need be.
+func writeFile(path string, write func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if err := write(temp); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
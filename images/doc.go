@@ -0,0 +1,26 @@
+/*
+Package images downloads the cover, background and screenshot images of YTS movies
+into an on-disk cache, for applications displaying movies offline or handing the
+images to media servers.
+
+A Fetcher returns the path of the cached file of an image, downloading the image
+only when it has not been cached yet or when it has changed. Images are stored
+under the "objects" directory of the cache, named after the SHA-256 hash of their
+content so that an image served at several URLs is stored once, while the
+"entries" directory maps every URL to its image along with the ETag and
+Last-Modified headers of its response. Once the MaxAge of the Options has passed a
+cached image is revalidated with a conditional request.
+
+	fetcher, err := images.New("cache/images", images.DefaultOptions())
+	...
+	path, err := fetcher.Fetch(siteMovie.Image)
+	...
+	movieImages, err := fetcher.MovieImages(&response.Data.Movie)
+
+MovieImages fetches the cover, background and screenshots of a movie in the sizes
+selected by the Options, falling back to the closest size available, while the
+number of images downloaded at once is limited by the Concurrency of the Options.
+The CoverURL, BackgroundURL and ScreenshotURLs functions perform the same size
+selection without fetching anything.
+*/
+package images
@@ -0,0 +1,304 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	// DefaultConcurrency is the value of the Concurrency field for the Options
+	// instance returned by the DefaultOptions() function.
+	DefaultConcurrency = 4
+
+	// DefaultMaxAge is the value of the MaxAge field for the Options instance
+	// returned by the DefaultOptions() function.
+	DefaultMaxAge = 24 * time.Hour
+
+	// DefaultRequestTimeout is the timeout of the *http.Client used by a Fetcher
+	// when the HTTPClient field of its Options is nil.
+	DefaultRequestTimeout = time.Minute
+)
+
+// ErrInvalidOptions is reported when a Fetcher is created with invalid Options,
+// the error description will carry further details.
+var ErrInvalidOptions = errors.New("invalid_images_options")
+
+// Options configure the behavior of a Fetcher.
+type Options struct {
+	// The maximum number of images downloaded at once, by every method of the
+	// Fetcher combined.
+	Concurrency int
+
+	// The duration for which a cached image is used without being revalidated, a
+	// zero MaxAge revalidates cached images upon every fetch.
+	MaxAge time.Duration
+
+	// The sizes of the cover, background and screenshot images fetched by the
+	// MovieImages method, when the image of a size is missing from a movie the
+	// closest size available is fetched instead.
+	CoverSize      Size
+	BackgroundSize Size
+	ScreenshotSize Size
+
+	// The *http.Client used for downloading images, when nil an *http.Client with
+	// the DefaultRequestTimeout is used.
+	HTTPClient *http.Client
+
+	// The function used for retrieving the current time when deciding whether a
+	// cached image is to be revalidated, when nil time.Now is used.
+	Clock func() time.Time
+}
+
+// DefaultOptions returns the default *Options used for creating a Fetcher, which
+// fetch medium covers, regular backgrounds and medium screenshots.
+func DefaultOptions() *Options {
+	return &Options{
+		Concurrency:    DefaultConcurrency,
+		MaxAge:         DefaultMaxAge,
+		CoverSize:      SizeMedium,
+		BackgroundSize: SizeMedium,
+		ScreenshotSize: SizeMedium,
+	}
+}
+
+func (o *Options) validate() error {
+	sizes := []Size{o.CoverSize, o.BackgroundSize, o.ScreenshotSize}
+	switch {
+	case o.Concurrency < 1:
+		return fmt.Errorf("concurrency must be positive")
+	case o.MaxAge < 0:
+		return fmt.Errorf("max age must not be negative")
+	}
+
+	for _, size := range sizes {
+		if size < SizeSmall || SizeOriginal < size {
+			return fmt.Errorf("sizes must be one of SizeSmall, SizeMedium, SizeLarge or SizeOriginal")
+		}
+	}
+	return nil
+}
+
+// A Fetcher downloads images into an on-disk cache and returns the paths of the
+// cached files, it is safe for concurrent use.
+type Fetcher struct {
+	dir       string
+	opts      Options
+	semaphore chan struct{}
+
+	mu       sync.Mutex
+	inflight map[string]*fetchCall
+}
+
+// A fetchCall is a fetch of an image URL in progress, concurrent fetches of the
+// same URL wait for the first one rather than downloading the image again. The
+// canceled field records whether the fetch failed because the context of the first
+// fetch was done, in which case waiting fetches retry with their own context.
+type fetchCall struct {
+	done     chan struct{}
+	path     string
+	err      error
+	canceled bool
+}
+
+// New creates a *Fetcher caching images in the provided directory, which is
+// created upon the first download if need be. A nil opts uses DefaultOptions().
+func New(dir string, opts *Options) (*Fetcher, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if dir == "" {
+		err := fmt.Errorf("cache directory must not be empty")
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	fetcher := &Fetcher{
+		dir:       dir,
+		opts:      *opts,
+		semaphore: make(chan struct{}, opts.Concurrency),
+		inflight:  make(map[string]*fetchCall),
+	}
+
+	if fetcher.opts.HTTPClient == nil {
+		fetcher.opts.HTTPClient = &http.Client{Timeout: DefaultRequestTimeout}
+	}
+	if fetcher.opts.Clock == nil {
+		fetcher.opts.Clock = time.Now
+	}
+
+	return fetcher, nil
+}
+
+// FetchWithContext returns the path of the cached file of the image at the provided
+// URL. The image is downloaded when it has not been cached yet, and a cached image
+// older than the MaxAge of the Options is revalidated with the server using the
+// ETag and Last-Modified headers of its response, being downloaded again only when
+// it has changed. Concurrent fetches of the same URL share a single download, which
+// is retried by the waiting fetches should the context of the first one be done.
+func (f *Fetcher) FetchWithContext(ctx context.Context, imageURL string) (string, error) {
+	if imageURL == "" {
+		err := fmt.Errorf("image url must not be empty")
+		return "", fmt.Errorf("%w: %s", yts.ErrValidationFailure, err)
+	}
+
+	for {
+		f.mu.Lock()
+		call, ok := f.inflight[imageURL]
+		if !ok {
+			break
+		}
+		f.mu.Unlock()
+
+		select {
+		case <-call.done:
+			if !call.canceled {
+				return call.path, call.err
+			}
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	call := &fetchCall{done: make(chan struct{})}
+	f.inflight[imageURL] = call
+	f.mu.Unlock()
+
+	call.path, call.err = f.fetch(ctx, imageURL)
+	call.canceled = call.err != nil && ctx.Err() != nil
+	f.mu.Lock()
+	delete(f.inflight, imageURL)
+	f.mu.Unlock()
+	close(call.done)
+
+	return call.path, call.err
+}
+
+// Fetch wraps FetchWithContext using context.Background.
+func (f *Fetcher) Fetch(imageURL string) (string, error) {
+	return f.FetchWithContext(context.Background(), imageURL)
+}
+
+func (f *Fetcher) fetch(ctx context.Context, imageURL string) (string, error) {
+	cached, err := f.loadEntry(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	now := f.opts.Clock()
+	if cached != nil && now.Sub(cached.ValidatedAt) < f.opts.MaxAge {
+		return f.objectPath(cached.Object), nil
+	}
+
+	select {
+	case f.semaphore <- struct{}{}:
+		defer func() { <-f.semaphore }()
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", yts.ErrValidationFailure, err)
+	}
+
+	if cached != nil {
+		if cached.ETag != "" {
+			request.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			request.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	response, err := f.opts.HTTPClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if cached != nil && response.StatusCode == http.StatusNotModified {
+		cached.ValidatedAt = now
+		return f.objectPath(cached.Object), f.saveEntry(cached)
+	}
+
+	if response.StatusCode < 200 || 299 < response.StatusCode {
+		err := fmt.Errorf("received response with status code: %d", response.StatusCode)
+		return "", fmt.Errorf("%w: %s", yts.ErrUnexpectedHTTPResponseStatus, err)
+	}
+
+	contentType := response.Header.Get("Content-Type")
+	object, err := f.saveObject(response.Body, imageExtension(imageURL, contentType))
+	if err != nil {
+		return "", err
+	}
+
+	fetched := &entry{
+		URL:          imageURL,
+		Object:       object,
+		ContentType:  contentType,
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		ValidatedAt:  now,
+	}
+	if err := f.saveEntry(fetched); err != nil {
+		return "", err
+	}
+
+	return f.objectPath(object), nil
+}
+
+// imageExtensions are the extensions of common image types, the extensions
+// registered with the mime package vary between systems, ".jfif" being returned for
+// JPEG images on some.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// imageExtension returns the extension of the file of an image, that of the URL of
+// the image when present and the one registered for its content type otherwise.
+func imageExtension(imageURL, contentType string) string {
+	if parsed, err := url.Parse(imageURL); err == nil {
+		if ext := strings.ToLower(path.Ext(parsed.Path)); ext != "" && len(ext) <= 5 {
+			return ext
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if ext, ok := imageExtensions[mediaType]; ok {
+		return ext
+	}
+	if extensions, _ := mime.ExtensionsByType(mediaType); len(extensions) != 0 {
+		return extensions[0]
+	}
+
+	return ""
+}
+
+// Clear removes every cached image and entry from the cache directory.
+func (f *Fetcher) Clear() error {
+	for _, dir := range []string{objectsDir, entriesDir} {
+		if err := os.RemoveAll(filepath.Join(f.dir, dir)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package images_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/images"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+// An imageServer serves images by path with an ETag of their content, counting
+// downloads and conditional requests and the greatest number of concurrent ones.
+type imageServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	images      map[string]string
+	downloads   int
+	revalidated int
+	active      int32
+	maxActive   int32
+	delay       time.Duration
+}
+
+func newImageServer(t *testing.T, images map[string]string) *imageServer {
+	t.Helper()
+	s := &imageServer{images: images}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *imageServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	active := atomic.AddInt32(&s.active, 1)
+	defer atomic.AddInt32(&s.active, -1)
+	for {
+		maxActive := atomic.LoadInt32(&s.maxActive)
+		if active <= maxActive || atomic.CompareAndSwapInt32(&s.maxActive, maxActive, active) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	content, ok := s.images[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	etag := fmt.Sprintf("%q", content)
+	if r.Header.Get("If-None-Match") == etag {
+		s.revalidated++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	s.downloads++
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", "image/jpeg")
+	fmt.Fprint(w, content)
+}
+
+func (s *imageServer) setImage(path, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.images[path] = content
+}
+
+func (s *imageServer) counts() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.downloads, s.revalidated
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestNew(t *testing.T) {
+	const methodName = "New"
+	tests := []struct {
+		name    string
+		dir     string
+		opts    *images.Options
+		wantErr error
+	}{
+		{
+			name:    "returns error for empty directory",
+			dir:     "",
+			wantErr: images.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for non positive concurrency",
+			dir:     "cache",
+			opts:    &images.Options{Concurrency: 0},
+			wantErr: images.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for unknown size",
+			dir:     "cache",
+			opts:    &images.Options{Concurrency: 1, CoverSize: images.SizeOriginal + 1},
+			wantErr: images.ErrInvalidOptions,
+		},
+		{
+			name:    "returns no error for default options",
+			dir:     "cache",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := images.New(tt.dir, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+		})
+	}
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	const methodName = "Fetcher.Fetch"
+
+	var (
+		s   = newImageServer(t, map[string]string{"/cover.jpg": "cover", "/copy": "cover"})
+		now = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		dir = t.TempDir()
+	)
+
+	opts := images.DefaultOptions()
+	opts.MaxAge = time.Hour
+	opts.Clock = func() time.Time { return now }
+	fetcher, _ := images.New(dir, opts)
+
+	path, err := fetcher.Fetch(s.URL + "/cover.jpg")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, readFile(t, path), "cover")
+	assertEqual(t, methodName, filepath.Ext(path), ".jpg")
+
+	// The same content served at another URL is stored once.
+	copyPath, err := fetcher.Fetch(s.URL + "/copy")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, copyPath, path)
+
+	// Cached images are used without any request until the max age has passed.
+	cachedPath, _ := fetcher.Fetch(s.URL + "/cover.jpg")
+	assertEqual(t, methodName, cachedPath, path)
+	downloads, revalidated := s.counts()
+	assertEqual(t, methodName, []int{downloads, revalidated}, []int{2, 0})
+
+	now = now.Add(2 * time.Hour)
+	revalidatedPath, _ := fetcher.Fetch(s.URL + "/cover.jpg")
+	assertEqual(t, methodName, revalidatedPath, path)
+	downloads, revalidated = s.counts()
+	assertEqual(t, methodName, []int{downloads, revalidated}, []int{2, 1})
+
+	// A changed image is downloaded again once revalidated.
+	s.setImage("/cover.jpg", "new cover")
+	now = now.Add(2 * time.Hour)
+	changedPath, _ := fetcher.Fetch(s.URL + "/cover.jpg")
+	assertEqual(t, methodName, readFile(t, changedPath), "new cover")
+	assertEqual(t, methodName, readFile(t, copyPath), "cover")
+
+	_, err = fetcher.Fetch(s.URL + "/missing.jpg")
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+	_, err = fetcher.Fetch("")
+	assertError(t, methodName, err, yts.ErrValidationFailure)
+
+	assertError(t, "Fetcher.Clear", fetcher.Clear(), nil)
+	_, err = os.Stat(changedPath)
+	assertError(t, "Fetcher.Clear", err, os.ErrNotExist)
+}
+
+func TestFetcher_FetchWithContext(t *testing.T) {
+	const methodName = "Fetcher.FetchWithContext"
+
+	s := newImageServer(t, map[string]string{"/cover.jpg": "cover"})
+	s.delay = 100 * time.Millisecond
+	fetcher, _ := images.New(t.TempDir(), nil)
+
+	var (
+		wg          sync.WaitGroup
+		ctx, cancel = context.WithCancel(context.Background())
+		firstErr    error
+		path        string
+		err         error
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, firstErr = fetcher.FetchWithContext(ctx, s.URL+"/cover.jpg")
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	go func() {
+		defer wg.Done()
+		path, err = fetcher.FetchWithContext(context.Background(), s.URL+"/cover.jpg")
+	}()
+
+	// Canceling the first fetch does not fail the fetch waiting for its download.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assertError(t, methodName, firstErr, context.Canceled)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, readFile(t, path), "cover")
+}
+
+func TestFetcher_MovieImages(t *testing.T) {
+	const methodName = "Fetcher.MovieImages"
+
+	s := newImageServer(t, map[string]string{
+		"/small-cover.jpg":         "small cover",
+		"/medium-cover.jpg":        "medium cover",
+		"/background.jpg":          "background",
+		"/background-original.jpg": "background original",
+		"/medium-screenshot1.jpg":  "medium screenshot 1",
+		"/large-screenshot1.jpg":   "large screenshot 1",
+		"/medium-screenshot2.jpg":  "medium screenshot 2",
+	})
+	s.delay = 20 * time.Millisecond
+
+	movie := &yts.MovieDetails{
+		MediumScreenshotImage1: s.URL + "/medium-screenshot1.jpg",
+		LargeScreenshotImage1:  s.URL + "/large-screenshot1.jpg",
+		MediumScreenshotImage2: s.URL + "/medium-screenshot2.jpg",
+	}
+	movie.SmallCoverImage = s.URL + "/small-cover.jpg"
+	movie.MediumCoverImage = s.URL + "/medium-cover.jpg"
+	movie.BackgroundImage = s.URL + "/background.jpg"
+	movie.BackgroundImageOriginal = s.URL + "/background-original.jpg"
+
+	tests := []struct {
+		name            string
+		size            images.Size
+		wantCover       string
+		wantBackground  string
+		wantScreenshots []string
+	}{
+		{
+			name:            "returns medium images",
+			size:            images.SizeMedium,
+			wantCover:       "medium cover",
+			wantBackground:  "background",
+			wantScreenshots: []string{"medium screenshot 1", "medium screenshot 2"},
+		},
+		{
+			name:            "returns closest images to large size",
+			size:            images.SizeLarge,
+			wantCover:       "medium cover",
+			wantBackground:  "background original",
+			wantScreenshots: []string{"large screenshot 1", "medium screenshot 2"},
+		},
+		{
+			name:            "returns small images",
+			size:            images.SizeSmall,
+			wantCover:       "small cover",
+			wantBackground:  "background",
+			wantScreenshots: []string{"medium screenshot 1", "medium screenshot 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := images.DefaultOptions()
+			opts.Concurrency = 2
+			opts.CoverSize, opts.BackgroundSize, opts.ScreenshotSize = tt.size, tt.size, tt.size
+			fetcher, _ := images.New(t.TempDir(), opts)
+
+			got, err := fetcher.MovieImages(movie)
+			assertError(t, methodName, err, nil)
+			assertEqual(t, methodName, readFile(t, got.Cover), tt.wantCover)
+			assertEqual(t, methodName, readFile(t, got.Background), tt.wantBackground)
+
+			screenshots := make([]string, 0)
+			for _, path := range got.Screenshots {
+				screenshots = append(screenshots, readFile(t, path))
+			}
+			assertEqual(t, methodName, screenshots, tt.wantScreenshots)
+		})
+	}
+
+	if maxActive := atomic.LoadInt32(&s.maxActive); 2 < maxActive {
+		t.Errorf("%s() made %d concurrent requests, want at most 2", methodName, maxActive)
+	}
+
+	fetcher, _ := images.New(t.TempDir(), nil)
+	_, err := fetcher.MovieImages(&yts.MovieDetails{LargeScreenshotImage3: s.URL + "/missing.jpg"})
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+}
@@ -0,0 +1,158 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A Size is the size variant of an image of a movie.
+type Size int
+
+const (
+	// SizeSmall selects the small cover image of a movie.
+	SizeSmall Size = iota
+
+	// SizeMedium selects the medium cover image, the regular background image and the
+	// medium screenshots of a movie.
+	SizeMedium
+
+	// SizeLarge selects the large cover image and the large screenshots of a movie.
+	SizeLarge
+
+	// SizeOriginal selects the original background image of a movie, along with the
+	// largest cover and screenshots.
+	SizeOriginal
+)
+
+// variants holds the URL of an image for every Size, with the URL of the nearest
+// size standing in for the sizes which the kind of image does not come in.
+type variants [SizeOriginal + 1]string
+
+// closest returns the URL of the provided size, or that of the closest size when
+// the URL of the size is empty, preferring the larger of two equally close sizes.
+func (v variants) closest(size Size) string {
+	for distance := Size(0); distance <= SizeOriginal; distance++ {
+		if larger := size + distance; larger <= SizeOriginal && v[larger] != "" {
+			return v[larger]
+		}
+		if smaller := size - distance; SizeSmall <= smaller && v[smaller] != "" {
+			return v[smaller]
+		}
+	}
+	return ""
+}
+
+// CoverURL returns the URL of the cover image of the provided size for the movie,
+// or the closest size available.
+func CoverURL(movie *yts.MoviePartial, size Size) string {
+	return variants{
+		movie.SmallCoverImage,
+		movie.MediumCoverImage,
+		movie.LargeCoverImage,
+		movie.LargeCoverImage,
+	}.closest(size)
+}
+
+// BackgroundURL returns the URL of the background image of the provided size for
+// the movie, or the closest size available.
+func BackgroundURL(movie *yts.MoviePartial, size Size) string {
+	return variants{
+		movie.BackgroundImage,
+		movie.BackgroundImage,
+		movie.BackgroundImageOriginal,
+		movie.BackgroundImageOriginal,
+	}.closest(size)
+}
+
+// ScreenshotURLs returns the URLs of the screenshots of the provided size for the
+// movie, or the closest size available, screenshots missing from the movie are
+// left out.
+func ScreenshotURLs(movie *yts.MovieDetails, size Size) []string {
+	screenshots := []variants{
+		{movie.MediumScreenshotImage1, movie.MediumScreenshotImage1, movie.LargeScreenshotImage1, movie.LargeScreenshotImage1},
+		{movie.MediumScreenshotImage2, movie.MediumScreenshotImage2, movie.LargeScreenshotImage2, movie.LargeScreenshotImage2},
+		{movie.MediumScreenshotImage3, movie.MediumScreenshotImage3, movie.LargeScreenshotImage3, movie.LargeScreenshotImage3},
+	}
+
+	urls := make([]string, 0, len(screenshots))
+	for _, screenshot := range screenshots {
+		if url := screenshot.closest(size); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// MovieImages holds the paths of the cached image files of a movie, the path of an
+// image missing from the movie is empty.
+type MovieImages struct {
+	Cover       string
+	Background  string
+	Screenshots []string
+}
+
+// A fetchTarget is an image URL of a movie along with the field of the MovieImages
+// receiving the path of its cached file.
+type fetchTarget struct {
+	url  string
+	path *string
+}
+
+// MovieImagesWithContext fetches the cover, background and screenshot images of the
+// provided movie in the sizes of the Options concurrently, and returns the paths of
+// their cached files. The images of a yts.Movie are fetched by providing a
+// yts.MovieDetails with its MoviePartial, which lacks screenshots.
+func (f *Fetcher) MovieImagesWithContext(ctx context.Context, movie *yts.MovieDetails) (
+	*MovieImages, error,
+) {
+	screenshots := ScreenshotURLs(movie, f.opts.ScreenshotSize)
+	images := &MovieImages{Screenshots: make([]string, len(screenshots))}
+
+	targets := []fetchTarget{
+		{CoverURL(&movie.MoviePartial, f.opts.CoverSize), &images.Cover},
+		{BackgroundURL(&movie.MoviePartial, f.opts.BackgroundSize), &images.Background},
+	}
+	for i, url := range screenshots {
+		targets = append(targets, fetchTarget{url, &images.Screenshots[i]})
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, target := range targets {
+		if target.url == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(url string, path *string) {
+			defer wg.Done()
+			fetched, err := f.FetchWithContext(ctx, url)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			*path = fetched
+		}(target.url, target.path)
+	}
+
+	wg.Wait()
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return images, nil
+}
+
+// MovieImages wraps MovieImagesWithContext using context.Background.
+func (f *Fetcher) MovieImages(movie *yts.MovieDetails) (*MovieImages, error) {
+	return f.MovieImagesWithContext(context.Background(), movie)
+}
@@ -0,0 +1,222 @@
+package yts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// imagesPathPrefix is the path prefix of every image hosted by YTS, both on the
+// website and on its images subdomain.
+const imagesPathPrefix = "/assets/images/"
+
+// moviesImagesPathPrefix is the path prefix of the images of movies, the images of
+// a movie being stored in a directory of their own, such as
+// "/assets/images/movies/oppenheimer_2023/".
+const moviesImagesPathPrefix = imagesPathPrefix + "movies/"
+
+// An ImageVariant is the file name of an image of a movie, every movie has one of
+// each variant in its images directory.
+type ImageVariant string
+
+const (
+	ImageVariantSmallCover        ImageVariant = "small-cover.jpg"
+	ImageVariantMediumCover       ImageVariant = "medium-cover.jpg"
+	ImageVariantLargeCover        ImageVariant = "large-cover.jpg"
+	ImageVariantMediumScreenshot1 ImageVariant = "medium-screenshot1.jpg"
+	ImageVariantMediumScreenshot2 ImageVariant = "medium-screenshot2.jpg"
+	ImageVariantMediumScreenshot3 ImageVariant = "medium-screenshot3.jpg"
+	ImageVariantLargeScreenshot1  ImageVariant = "large-screenshot1.jpg"
+	ImageVariantLargeScreenshot2  ImageVariant = "large-screenshot2.jpg"
+	ImageVariantLargeScreenshot3  ImageVariant = "large-screenshot3.jpg"
+
+	// The background image of a movie, the YTS API returns the URL of this variant
+	// for both the BackgroundImage and BackgroundImageOriginal of a movie.
+	ImageVariantBackground ImageVariant = "background.jpg"
+)
+
+// ImageVariants returns every ImageVariant of the images of a movie.
+func ImageVariants() []ImageVariant {
+	return []ImageVariant{
+		ImageVariantSmallCover,
+		ImageVariantMediumCover,
+		ImageVariantLargeCover,
+		ImageVariantBackground,
+		ImageVariantMediumScreenshot1,
+		ImageVariantMediumScreenshot2,
+		ImageVariantMediumScreenshot3,
+		ImageVariantLargeScreenshot1,
+		ImageVariantLargeScreenshot2,
+		ImageVariantLargeScreenshot3,
+	}
+}
+
+func (v ImageVariant) valid() bool {
+	for _, variant := range ImageVariants() {
+		if v == variant {
+			return true
+		}
+	}
+	return false
+}
+
+// isYTSHost reports whether the provided host belongs to YTS, being one of the
+// provided configured hosts or a "yts" domain such as "yts.mx" or "img.yts.mx".
+func isYTSHost(host string, configured ...string) bool {
+	for _, c := range configured {
+		if c != "" && strings.EqualFold(host, c) {
+			return true
+		}
+	}
+
+	labels := strings.Split(strings.ToLower(host), ".")
+	return 2 <= len(labels) && labels[len(labels)-2] == "yts"
+}
+
+// imagePath returns the path of the provided YTS image, the image may be a relative
+// path, such as the src of a scraped image, or an absolute URL on any YTS host.
+func imagePath(imageURL string, hosts ...string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(imageURL))
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case parsed.Scheme != "" && parsed.Scheme != "http" && parsed.Scheme != "https":
+		return "", fmt.Errorf("image url must be an http url, you provided %q", imageURL)
+	case parsed.Host != "" && !isYTSHost(parsed.Hostname(), hosts...):
+		return "", fmt.Errorf("image url must be hosted by YTS, you provided %q", imageURL)
+	}
+
+	cleaned := path.Clean("/" + parsed.Path)
+	if !strings.HasPrefix(cleaned, imagesPathPrefix) {
+		return "", fmt.Errorf("image url path must start with %q, you provided %q", imagesPathPrefix, imageURL)
+	}
+
+	return cleaned, nil
+}
+
+// resolveScrapedImageURL returns the URL of a scraped image on the images subdomain
+// with the provided base URL, images which are not hosted by YTS are returned as is.
+func resolveScrapedImageURL(base *url.URL, image string) string {
+	if p, err := imagePath(image, base.Hostname()); err == nil {
+		return base.JoinPath(p).String()
+	}
+
+	if strings.HasPrefix(image, "/") {
+		return fmt.Sprintf("%s%s", base.String(), image)
+	}
+
+	return image
+}
+
+func (c *Client) imagePath(imageURL string) (string, error) {
+	p, err := imagePath(
+		imageURL,
+		c.config.SiteImageSubDomainURL.Hostname(),
+		c.config.SiteURL.Hostname(),
+		c.config.APIBaseURL.Hostname(),
+	)
+
+	if err != nil {
+		return "", wrapErr(ErrValidationFailure, err)
+	}
+
+	return p, nil
+}
+
+// NormalizeImageURL returns the URL of the provided YTS image on the images
+// subdomain of the client config. The image may be the relative path of a scraped
+// image or an absolute URL on any YTS host, such as the image URLs returned by
+// the YTS API, which point at the website rather than the images subdomain.
+func (c *Client) NormalizeImageURL(imageURL string) (string, error) {
+	p, err := c.imagePath(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	return c.config.SiteImageSubDomainURL.JoinPath(p).String(), nil
+}
+
+// ImageVariantURL derives the URL of the provided variant of a movie image from the
+// URL of any other image of the movie, such as the large cover of a movie from its
+// medium cover. The URL is normalized like NormalizeImageURL does.
+func (c *Client) ImageVariantURL(imageURL string, variant ImageVariant) (string, error) {
+	if !variant.valid() {
+		err := fmt.Errorf("unknown image variant %q", variant)
+		return "", wrapErr(ErrValidationFailure, err)
+	}
+
+	p, err := c.imagePath(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	// The images of a movie are the variants stored in a single directory under
+	// moviesImagesPathPrefix, such as "/assets/images/movies/oppenheimer_2023/".
+	dir, file := path.Split(p)
+	movieDir := strings.TrimPrefix(dir, moviesImagesPathPrefix)
+	if movieDir == dir || strings.Count(movieDir, "/") != 1 || !ImageVariant(file).valid() {
+		err := fmt.Errorf("image url must be the url of a movie image, you provided %q", imageURL)
+		return "", wrapErr(ErrValidationFailure, err)
+	}
+
+	return c.config.SiteImageSubDomainURL.JoinPath(dir, string(variant)).String(), nil
+}
+
+// An ImageVariantURLs is the return type of the ImageVariantURLs method of a
+// yts.Client.
+type ImageVariantURLs map[ImageVariant]string
+
+// ImageVariantURLs derives the URLs of every variant of a movie image from the URL
+// of any image of the movie, like ImageVariantURL does.
+func (c *Client) ImageVariantURLs(imageURL string) (ImageVariantURLs, error) {
+	urls := make(ImageVariantURLs)
+	for _, variant := range ImageVariants() {
+		variantURL, err := c.ImageVariantURL(imageURL, variant)
+		if err != nil {
+			return nil, err
+		}
+		urls[variant] = variantURL
+	}
+
+	return urls, nil
+}
+
+// ValidateImageURLWithContext is the same as the ValidateImageURL method but
+// requires a context.Context argument to be passed, this context is then passed to
+// the http.NewRequestWithContext call used for making the network request.
+func (c *Client) ValidateImageURLWithContext(ctx context.Context, imageURL string) error {
+	normalized, err := c.NormalizeImageURL(imageURL)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, normalized, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.netClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode < 200 || 299 < response.StatusCode {
+		sErr := fmt.Errorf("received response with status code: %d", response.StatusCode)
+		return wrapErr(ErrUnexpectedHTTPResponseStatus, sErr)
+	}
+
+	return nil
+}
+
+// ValidateImageURL normalizes the provided image URL like NormalizeImageURL does
+// and checks that the image exists on the images subdomain using a HEAD request,
+// ErrUnexpectedHTTPResponseStatus is returned for an image which does not exist.
+func (c *Client) ValidateImageURL(imageURL string) error {
+	return c.ValidateImageURLWithContext(context.Background(), imageURL)
+}
@@ -0,0 +1,172 @@
+package yts_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestClient_NormalizeImageURL(t *testing.T) {
+	const methodName = "Client.NormalizeImageURL"
+
+	config := yts.DefaultClientConfig()
+	subdomainURL, _ := url.Parse("https://img.yts.mx/")
+	config.SiteImageSubDomainURL = *subdomainURL
+	client, _ := yts.NewClientWithConfig(&config)
+
+	tests := []struct {
+		name     string
+		imageURL string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "returns relative path on images subdomain",
+			imageURL: "/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+			want:     "https://img.yts.mx/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+		},
+		{
+			name:     "returns api image url on images subdomain",
+			imageURL: "https://yts.mx/assets/images/movies/oppenheimer_2023/background.jpg",
+			want:     "https://img.yts.mx/assets/images/movies/oppenheimer_2023/background.jpg",
+		},
+		{
+			name:     "returns mirror image url on images subdomain",
+			imageURL: "//img.yts.lt/assets/images/actors/thumb/nm0634240.jpg?v=1",
+			want:     "https://img.yts.mx/assets/images/actors/thumb/nm0634240.jpg",
+		},
+		{
+			name:     "returns error for image not hosted by YTS",
+			imageURL: "https://m.media-amazon.com/assets/images/movies/poster.jpg",
+			wantErr:  yts.ErrValidationFailure,
+		},
+		{
+			name:     "returns error for path outside of images",
+			imageURL: "https://yts.mx/assets/images/../../movies/oppenheimer-2023",
+			wantErr:  yts.ErrValidationFailure,
+		},
+		{
+			name:     "returns error for non http url",
+			imageURL: "ftp://yts.mx/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+			wantErr:  yts.ErrValidationFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.NormalizeImageURL(tt.imageURL)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestClient_ImageVariantURL(t *testing.T) {
+	const methodName = "Client.ImageVariantURL"
+
+	client := yts.NewClient()
+	tests := []struct {
+		name     string
+		imageURL string
+		variant  yts.ImageVariant
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "returns large cover from medium cover",
+			imageURL: "/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+			variant:  yts.ImageVariantLargeCover,
+			want:     "https://img.yts.mx/assets/images/movies/oppenheimer_2023/large-cover.jpg",
+		},
+		{
+			name:     "returns screenshot from background",
+			imageURL: "https://yts.mx/assets/images/movies/oppenheimer_2023/background.jpg",
+			variant:  yts.ImageVariantLargeScreenshot2,
+			want:     "https://img.yts.mx/assets/images/movies/oppenheimer_2023/large-screenshot2.jpg",
+		},
+		{
+			name:     "returns error for image of an actor",
+			imageURL: "https://img.yts.mx/assets/images/actors/thumb/nm0634240.jpg",
+			variant:  yts.ImageVariantLargeCover,
+			wantErr:  yts.ErrValidationFailure,
+		},
+		{
+			name:     "returns error for unknown image of a movie",
+			imageURL: "https://img.yts.mx/assets/images/movies/oppenheimer_2023/poster.png",
+			variant:  yts.ImageVariantLargeCover,
+			wantErr:  yts.ErrValidationFailure,
+		},
+		{
+			name:     "returns error for unknown variant",
+			imageURL: "https://img.yts.mx/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+			variant:  yts.ImageVariant("huge-cover.jpg"),
+			wantErr:  yts.ErrValidationFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ImageVariantURL(tt.imageURL, tt.variant)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+
+	urls, err := client.ImageVariantURLs("/assets/images/movies/superbad_2007/small-cover.jpg")
+	assertError(t, "Client.ImageVariantURLs", err, nil)
+	assertEqual(t, "Client.ImageVariantURLs", len(urls), len(yts.ImageVariants()))
+	assertEqual(t, "Client.ImageVariantURLs", urls[yts.ImageVariantBackground],
+		"https://img.yts.mx/assets/images/movies/superbad_2007/background.jpg")
+}
+
+func TestClient_ValidateImageURLWithContext(t *testing.T) {
+	const methodName = "Client.ValidateImageURLWithContext"
+
+	methods := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path != "/assets/images/movies/oppenheimer_2023/medium-cover.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := yts.DefaultClientConfig()
+	serverURL, _ := url.Parse(server.URL)
+	config.SiteImageSubDomainURL = *serverURL
+	client, _ := yts.NewClientWithConfig(&config)
+
+	tests := []struct {
+		name     string
+		imageURL string
+		wantErr  error
+	}{
+		{
+			name:     "returns no error for existing image",
+			imageURL: "https://yts.mx/assets/images/movies/oppenheimer_2023/medium-cover.jpg",
+			wantErr:  nil,
+		},
+		{
+			name:     "returns error for missing image",
+			imageURL: "/assets/images/movies/oppenheimer_2023/large-cover.jpg",
+			wantErr:  yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:     "returns error for invalid image url",
+			imageURL: "/movies/oppenheimer-2023",
+			wantErr:  yts.ErrValidationFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.ValidateImageURL(tt.imageURL)
+			assertError(t, methodName, err, tt.wantErr)
+		})
+	}
+
+	assertEqual(t, methodName, methods, []string{http.MethodHead, http.MethodHead})
+}
@@ -0,0 +1,131 @@
+package yts
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var imdbIDRegex = regexp.MustCompile(`^tt\d{7,}$`)
+
+// IsIMDbID reports whether the provided string is an IMDb ID in the "tt0000000"
+// format accepted by the MovieByIMDbID method, such as "tt15398776".
+func IsIMDbID(s string) bool {
+	return imdbIDRegex.MatchString(s)
+}
+
+// imdbIDFromLink returns the IMDb ID found in the path of the provided link such
+// as "https://www.imdb.com/title/tt15398776/", or an empty string if there is none.
+func imdbIDFromLink(link string) string {
+	segments := strings.FieldsFunc(link, func(r rune) bool {
+		return r == '/' || r == '?' || r == '#'
+	})
+
+	for _, segment := range segments {
+		if imdbIDRegex.MatchString(segment) {
+			return segment
+		}
+	}
+
+	return ""
+}
+
+var validateIMDbIDRule = validation.Match(imdbIDRegex).Error(
+	"must be an IMDb ID in the \"tt0000000\" format",
+)
+
+type MovieByIMDbIDData struct {
+	Movie Movie `json:"movie"`
+}
+
+// A MovieByIMDbIDResponse holds the movie returned by the YTS API for an IMDb ID.
+type MovieByIMDbIDResponse struct {
+	Data MovieByIMDbIDData `json:"data"`
+}
+
+// MovieByIMDbIDWithContext is the same as the MovieByIMDbID method but requires a
+// context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (c *Client) MovieByIMDbIDWithContext(ctx context.Context, imdbID string) (
+	*MovieByIMDbIDResponse, error,
+) {
+	if err := validation.Validate(imdbID, validation.Required, validateIMDbIDRule); err != nil {
+		err = fmt.Errorf("imdb_id: %w", err)
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	response, err := c.SearchMoviesWithContext(ctx, DefaultSearchMoviesFilters(imdbID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, movie := range response.Data.Movies {
+		if movie.ImdbCode == imdbID {
+			return &MovieByIMDbIDResponse{MovieByIMDbIDData{movie}}, nil
+		}
+	}
+
+	err = fmt.Errorf("no movie found for IMDb ID %q", imdbID)
+	return nil, wrapErr(ErrMovieNotFound, err)
+}
+
+// MovieByIMDbID method returns the movie corresponding to the provided IMDb ID
+// e.g. "tt15398776", the ID is looked up using the "/api/v2/list_movies.json"
+// endpoint and the returned movies are checked for an exact match of their
+// ImdbCode field, in the event no movie matches an error wrapping ErrMovieNotFound
+// is returned.
+func (c *Client) MovieByIMDbID(imdbID string) (*MovieByIMDbIDResponse, error) {
+	return c.MovieByIMDbIDWithContext(context.Background(), imdbID)
+}
+
+// An IMDbMovie pairs an IMDb ID provided to the MoviesByIMDbIDs method with the
+// Movie it resolved to, in the event the IMDb ID could not be resolved the Movie
+// field is nil and the Err field carries the reason for this failure.
+type IMDbMovie struct {
+	IMDbID string `json:"imdb_id"`
+	Movie  *Movie `json:"movie"`
+	Err    error  `json:"-"`
+}
+
+// MoviesByIMDbIDsWithContext is the same as the MoviesByIMDbIDs method but
+// requires a context.Context argument to be passed, this context is then passed
+// to the http.NewRequestWithContext calls used for making the network requests.
+func (c *Client) MoviesByIMDbIDsWithContext(ctx context.Context, imdbIDs []string, concurrency int) (
+	[]IMDbMovie, error,
+) {
+	if concurrency < 1 {
+		err := fmt.Errorf("concurrency must be at least 1")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	movies := make([]IMDbMovie, len(imdbIDs))
+	err := forEachConcurrently(ctx, len(imdbIDs), concurrency, func(i int) {
+		im := &movies[i]
+		im.IMDbID = imdbIDs[i]
+		response, err := c.MovieByIMDbIDWithContext(ctx, im.IMDbID)
+		if err != nil {
+			im.Err = err
+			return
+		}
+
+		im.Movie = &response.Data.Movie
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// MoviesByIMDbIDs method resolves each of the provided IMDb IDs using the
+// MovieByIMDbID method, with at most concurrency lookups in flight at any time.
+// The returned slice is in the same order as the provided IMDb IDs, an IMDb ID
+// which could not be resolved will have its Err field set, an error is only
+// returned for an invalid concurrency or a cancelled context.
+func (c *Client) MoviesByIMDbIDs(imdbIDs []string, concurrency int) ([]IMDbMovie, error) {
+	return c.MoviesByIMDbIDsWithContext(context.Background(), imdbIDs, concurrency)
+}
@@ -0,0 +1,166 @@
+package yts_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func createMovieByIMDbIDTestServer(t *testing.T, statusCode int) *url.URL {
+	t.Helper()
+	handlerCfg := defaultHandlerConfig(t, "list_movies.json", "movie_by_imdb_id", "list_movies.json")
+	if statusCode != http.StatusOK {
+		handlerCfg = handlerConfigWithStatusCode(t, "list_movies.json", statusCode)
+	}
+
+	server := createTestServer(t, handlerCfg)
+	t.Cleanup(server.Close)
+	serverURL, _ := url.Parse(server.URL)
+	return serverURL
+}
+
+func TestClient_MovieByIMDbIDWithContext(t *testing.T) {
+	const methodName = "Client.MovieByIMDbID"
+
+	timedoutCtx, cancel := context.WithDeadline(
+		context.Background(), time.Now(),
+	)
+	defer cancel()
+
+	mockedOKResponse := &yts.MovieByIMDbIDResponse{
+		Data: yts.MovieByIMDbIDData{
+			Movie: yts.Movie{
+				MoviePartial: yts.MoviePartial{
+					ID:       57427,
+					ImdbCode: "tt15398776",
+					Title:    "Oppenheimer",
+					Slug:     "oppenheimer-2023",
+					Year:     2023,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		ctx        context.Context
+		imdbID     string
+		want       *yts.MovieByIMDbIDResponse
+		wantErr    error
+	}{
+		{
+			name:    "returns error for empty IMDb ID",
+			ctx:     context.Background(),
+			imdbID:  "",
+			wantErr: yts.ErrValidationFailure,
+		},
+		{
+			name:    "returns error for malformed IMDb ID",
+			ctx:     context.Background(),
+			imdbID:  "nm0000982",
+			wantErr: yts.ErrValidationFailure,
+		},
+		{
+			name:       "returns error when no movie matches IMDb ID exactly",
+			statusCode: http.StatusOK,
+			ctx:        context.Background(),
+			imdbID:     "tt0000001",
+			wantErr:    yts.ErrMovieNotFound,
+		},
+		{
+			name:       "returns error when request context times out",
+			statusCode: http.StatusOK,
+			ctx:        timedoutCtx,
+			imdbID:     "tt15398776",
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:       "returns error when response status is outside 2.x.x range",
+			statusCode: http.StatusNotFound,
+			ctx:        context.Background(),
+			imdbID:     "tt15398776",
+			wantErr:    yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:       "returns movie whose IMDb code matches exactly",
+			statusCode: http.StatusOK,
+			ctx:        context.Background(),
+			imdbID:     "tt15398776",
+			want:       mockedOKResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := yts.DefaultClientConfig()
+			if tt.statusCode != 0 {
+				clientCfg.APIBaseURL = *createMovieByIMDbIDTestServer(t, tt.statusCode)
+			}
+
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.MovieByIMDbIDWithContext(tt.ctx, tt.imdbID)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestClient_MoviesByIMDbIDsWithContext(t *testing.T) {
+	const methodName = "Client.MoviesByIMDbIDs"
+
+	clientCfg := yts.DefaultClientConfig()
+	clientCfg.APIBaseURL = *createMovieByIMDbIDTestServer(t, http.StatusOK)
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+
+	_, err := c.MoviesByIMDbIDsWithContext(context.Background(), []string{"tt15398776"}, 0)
+	assertError(t, methodName, err, yts.ErrValidationFailure)
+
+	imdbIDs := []string{"tt15398776", "tt0000001", "invalid", "tt15398776"}
+	got, err := c.MoviesByIMDbIDsWithContext(context.Background(), imdbIDs, 2)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, len(got), len(imdbIDs))
+
+	for i, imdbID := range imdbIDs {
+		assertEqual(t, methodName, got[i].IMDbID, imdbID)
+	}
+
+	for _, i := range []int{0, 3} {
+		assertError(t, methodName, got[i].Err, nil)
+		assertEqual(t, methodName, got[i].Movie.ID, 57427)
+	}
+
+	assertError(t, methodName, got[1].Err, yts.ErrMovieNotFound)
+	assertEqual(t, methodName, got[1].Movie, (*yts.Movie)(nil))
+	assertError(t, methodName, got[2].Err, yts.ErrValidationFailure)
+	assertEqual(t, methodName, got[2].Movie, (*yts.Movie)(nil))
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.MoviesByIMDbIDsWithContext(cancelledCtx, imdbIDs, 2)
+	assertError(t, methodName, err, context.Canceled)
+}
+
+func TestIsIMDbID(t *testing.T) {
+	const methodName = "IsIMDbID"
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "tt15398776", want: true},
+		{input: "tt0101507", want: true},
+		{input: "tt010150", want: false},
+		{input: "https://www.imdb.com/title/tt15398776/", want: false},
+		{input: "oppenheimer-2023", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assertEqual(t, methodName, yts.IsIMDbID(tt.input), tt.want)
+		})
+	}
+}
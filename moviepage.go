@@ -0,0 +1,112 @@
+package yts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// A MoviePage is the YTS page of a movie fetched by the MoviePage method, the
+// director, reviews and comments of the movie are scraped from it independently of
+// one another, so that content missing from the page, such as the reviews of a
+// movie which has none, only fails the method scraping that content. A MoviePage
+// is safe for concurrent use.
+type MoviePage struct {
+	client   *Client
+	document *goquery.Document
+}
+
+// MoviePageWithContext is the same as the MoviePage method but requires a
+// context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (c *Client) MoviePageWithContext(ctx context.Context, movieSlug string) (*MoviePage, error) {
+	if movieSlug == "" {
+		err := fmt.Errorf("provided movie slug cannot be an empty")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	pageURLString := fmt.Sprintf("%s/movies/%s", &c.config.SiteURL, movieSlug)
+	pageURL, _ := url.Parse(pageURLString)
+	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoviePage{c, document}, nil
+}
+
+// MoviePage method fetches the movie page corresponding to the provided movie slug
+// once, for scraping several kinds of content from it without fetching it again.
+func (c *Client) MoviePage(movieSlug string) (*MoviePage, error) {
+	return c.MoviePageWithContext(context.Background(), movieSlug)
+}
+
+// Director scrapes the director of the movie from the page, ErrContentRetrievalFailure
+// is returned when the page holds no director.
+func (mp *MoviePage) Director() (*MovieDirectorResponse, error) {
+	data, err := mp.client.scrapeMovieDirectorData(mp.document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &MovieDirectorResponse{*data}, nil
+}
+
+// Reviews scrapes the reviews of the movie and the link to the page listing every
+// review from the page, ErrContentRetrievalFailure is returned when the page holds
+// neither.
+func (mp *MoviePage) Reviews() (*MovieReviewsResponse, error) {
+	data, err := mp.client.scrapeMovieReviewsData(mp.document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &MovieReviewsResponse{*data}, nil
+}
+
+// CommentsWithContext is the same as the Comments method but requires a
+// context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (mp *MoviePage) CommentsWithContext(ctx context.Context, page int) (*MovieCommentsResponse, error) {
+	if page < 1 {
+		err := fmt.Errorf("provided comment page must be at least 1")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	meta, err := mp.client.scrapeMovieCommentsMetaData(mp.document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	var (
+		offset = (page - 1) * movieCommentsPerPage
+		isLast = meta.commentCount-offset <= movieCommentsPerPage
+	)
+
+	commentURLString := mp.client.getCommentsURL(meta.movieID, offset)
+	commentURL, _ := url.Parse(commentURLString)
+	commentDoc, err := mp.client.newDocumentRequestWithContext(ctx, commentURL)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := mp.client.scrapeMovieComments(commentDoc)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	data := MovieCommentsData{
+		CommentsMore: !isLast,
+		Comments:     comments,
+	}
+
+	return &MovieCommentsResponse{data}, nil
+}
+
+// Comments fetches the provided page of the comments of the movie, using the movie
+// ID and comment count found on the page, see the MovieComments method.
+func (mp *MoviePage) Comments(page int) (*MovieCommentsResponse, error) {
+	return mp.CommentsWithContext(context.Background(), page)
+}
@@ -0,0 +1,96 @@
+package yts_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestClient_MoviePageWithContext(t *testing.T) {
+	const (
+		methodName       = "Client.MoviePage"
+		testdataDir      = "movie_additional_details"
+		movieSlug        = "oppenheimer-2023"
+		commentsPattern  = "ajax/comments/57427"
+		moviePagePattern = "movies/oppenheimer-2023"
+	)
+
+	getHandlerCfgsFor := func(subDir string) []testHTTPHandlerConfig {
+		testdataSubDir := fmt.Sprintf("%s/%s", testdataDir, subDir)
+		return []testHTTPHandlerConfig{
+			defaultHandlerConfig(t, commentsPattern, testdataSubDir, "comments.html"),
+			defaultHandlerConfig(t, moviePagePattern, testdataSubDir, "movie_page.html"),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		subDir         string
+		movieSlug      string
+		commentsPage   int
+		wantErr        error
+		wantReviewsErr error
+		wantCommentErr error
+	}{
+		{
+			name:      "returns error when movie slug is an empty string",
+			subDir:    "ok_response",
+			movieSlug: "",
+			wantErr:   yts.ErrValidationFailure,
+		},
+		{
+			name:         "scrapes every field from movie page",
+			subDir:       "ok_response",
+			movieSlug:    movieSlug,
+			commentsPage: 1,
+		},
+		{
+			name:           "scrapes director and comments when reviews missing",
+			subDir:         "missing_reviews",
+			movieSlug:      movieSlug,
+			commentsPage:   1,
+			wantReviewsErr: yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:           "returns error when comments page is less than one",
+			subDir:         "ok_response",
+			movieSlug:      movieSlug,
+			commentsPage:   0,
+			wantCommentErr: yts.ErrValidationFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createTestServer(t, getHandlerCfgsFor(tt.subDir)...)
+			serverURL, _ := url.Parse(server.URL)
+			defer server.Close()
+
+			clientCfg := yts.DefaultClientConfig()
+			clientCfg.SiteURL = *serverURL
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+
+			page, err := c.MoviePageWithContext(context.Background(), tt.movieSlug)
+			assertError(t, methodName, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			director, err := page.Director()
+			assertError(t, "MoviePage.Director", err, nil)
+			assertEqual(t, "MoviePage.Director", director.Data.Director.Name, "Christopher Nolan")
+
+			_, err = page.Reviews()
+			assertError(t, "MoviePage.Reviews", err, tt.wantReviewsErr)
+
+			comments, err := page.Comments(tt.commentsPage)
+			assertError(t, "MoviePage.Comments", err, tt.wantCommentErr)
+			if tt.wantCommentErr == nil {
+				assertEqual(t, "MoviePage.Comments", len(comments.Data.Comments), 3)
+			}
+		})
+	}
+}
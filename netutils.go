@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -82,3 +83,32 @@ func (c *Client) getAPIEndpoint(path, query string) string {
 func (c *Client) getCommentsURL(movieID, offset int) string {
 	return fmt.Sprintf("%s/ajax/comments/%d?offset=%d", &c.config.SiteURL, movieID, offset)
 }
+
+// forEachConcurrently calls fn with every index from 0 to n-1, with at most
+// concurrency calls running at any time. No further calls are started once ctx is
+// done, in which case the error of ctx is returned after the running calls return.
+func forEachConcurrently(ctx context.Context, n, concurrency int, fn func(i int)) error {
+	var (
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, concurrency)
+	)
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,180 @@
+package yts
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxPersonMoviesPages caps the number of "/api/v2/list_movies.json" pages
+// requested by the MoviesByPerson method for a single person.
+const maxPersonMoviesPages = 20
+
+var personImdbCodeRegex = regexp.MustCompile(`^(?:nm)?(\d{7,})$`)
+
+// A PersonMovie represents a movie available on YTS in which a person appears
+// either as a cast member or director. The Movie field is set when the movie was
+// returned by the YTS API and the Site field is set when it was scraped from the
+// YTS website, at least one of the two is always set.
+type PersonMovie struct {
+	Slug  string     `json:"slug"`
+	Title string     `json:"title"`
+	Year  int        `json:"year"`
+	Movie *Movie     `json:"movie,omitempty"`
+	Site  *SiteMovie `json:"site,omitempty"`
+}
+
+type MoviesByPersonData struct {
+	Movies []PersonMovie `json:"movies"`
+}
+
+// A MoviesByPersonResponse holds the movies available on YTS for a person, these
+// are obtained using both the YTS API and the YTS website.
+type MoviesByPersonResponse struct {
+	Data MoviesByPersonData `json:"data"`
+}
+
+// normalizePerson returns the provided person identifier as a query term, IMDb
+// name codes with or without their "nm" prefix, the latter being the format used
+// by the Cast type, are converted into the "nm0000000" format.
+func normalizePerson(person string) string {
+	person = cleanString(person)
+	if matches := personImdbCodeRegex.FindStringSubmatch(person); matches != nil {
+		return "nm" + matches[1]
+	}
+
+	return person
+}
+
+func (c *Client) searchPersonMovies(ctx context.Context, queryTerm string) ([]Movie, error) {
+	const pageLimit = 50
+
+	var (
+		movies  = make([]Movie, 0)
+		filters = DefaultSearchMoviesFilters(queryTerm)
+	)
+
+	filters.Limit = pageLimit
+	for ; filters.Page <= maxPersonMoviesPages; filters.Page++ {
+		response, err := c.SearchMoviesWithContext(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		movies = append(movies, response.Data.Movies...)
+		if len(response.Data.Movies) < pageLimit || response.Data.MovieCount <= len(movies) {
+			break
+		}
+	}
+
+	return movies, nil
+}
+
+func (c *Client) browsePersonMovies(ctx context.Context, queryTerm string) ([]SiteMovie, error) {
+	pageURLString := fmt.Sprintf("%s/browse-movies/%s", &c.config.SiteURL, url.PathEscape(queryTerm))
+	pageURL, _ := url.Parse(pageURLString)
+	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Find(browseCSS).Length() == 0 {
+		return make([]SiteMovie, 0), nil
+	}
+
+	siteMovies, err := c.scrapeSiteMovies(document, browseCSS, "browse")
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return siteMovies, nil
+}
+
+func mergePersonMovies(movies []Movie, siteMovies []SiteMovie) []PersonMovie {
+	var (
+		merged      = make([]PersonMovie, 0, len(movies)+len(siteMovies))
+		indexBySlug = make(map[string]int)
+	)
+
+	for i := range movies {
+		movie := &movies[i]
+		if _, ok := indexBySlug[movie.Slug]; ok {
+			continue
+		}
+
+		merged = append(merged, PersonMovie{
+			Slug:  movie.Slug,
+			Title: movie.Title,
+			Year:  movie.Year,
+			Movie: movie,
+		})
+		indexBySlug[movie.Slug] = len(merged) - 1
+	}
+
+	for i := range siteMovies {
+		siteMovie := &siteMovies[i]
+		if j, ok := indexBySlug[siteMovie.Slug]; ok {
+			if merged[j].Site == nil {
+				merged[j].Site = siteMovie
+			}
+			continue
+		}
+
+		merged = append(merged, PersonMovie{
+			Slug:  siteMovie.Slug,
+			Title: siteMovie.Title,
+			Year:  siteMovie.Year,
+			Site:  siteMovie,
+		})
+		indexBySlug[siteMovie.Slug] = len(merged) - 1
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Year != merged[j].Year {
+			return merged[i].Year < merged[j].Year
+		}
+		return strings.ToLower(merged[i].Title) < strings.ToLower(merged[j].Title)
+	})
+
+	return merged
+}
+
+// MoviesByPersonWithContext is the same as the MoviesByPerson method but requires
+// a context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext calls used for making the network requests.
+func (c *Client) MoviesByPersonWithContext(ctx context.Context, person string) (
+	*MoviesByPersonResponse, error,
+) {
+	queryTerm := normalizePerson(person)
+	if queryTerm == "" {
+		err := fmt.Errorf("provided person cannot be empty")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	movies, err := c.searchPersonMovies(ctx, queryTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	siteMovies, err := c.browsePersonMovies(ctx, queryTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoviesByPersonResponse{
+		Data: MoviesByPersonData{mergePersonMovies(movies, siteMovies)},
+	}, nil
+}
+
+// MoviesByPerson method returns the movies available on YTS for the provided
+// person, who may be identified by their name or by their IMDb name code such as
+// "nm0000982" or the "0000982" format used by the Cast type. The person is looked
+// up with both the "/api/v2/list_movies.json" endpoint and the "/browse-movies"
+// page of the YTS website, the combined movies are deduplicated by their slug and
+// sorted by year in ascending order.
+func (c *Client) MoviesByPerson(person string) (*MoviesByPersonResponse, error) {
+	return c.MoviesByPersonWithContext(context.Background(), person)
+}
@@ -0,0 +1,160 @@
+package yts_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestClient_MoviesByPersonWithContext(t *testing.T) {
+	const (
+		methodName  = "Client.MoviesByPerson"
+		testdataDir = "movies_by_person"
+		imageURL    = "https://img.yts.mx/assets/images/movies/"
+	)
+
+	var (
+		jackieBrown = yts.Movie{
+			MoviePartial: yts.MoviePartial{
+				ID:       1021,
+				ImdbCode: "tt0119396",
+				Title:    "Jackie Brown",
+				Slug:     "jackie-brown-1997",
+				Year:     1997,
+			},
+		}
+		noCountry = yts.Movie{
+			MoviePartial: yts.MoviePartial{
+				ID:       6738,
+				ImdbCode: "tt0477348",
+				Title:    "No Country for Old Men",
+				Slug:     "no-country-for-old-men-2007",
+				Year:     2007,
+			},
+		}
+		noCountrySite = yts.SiteMovie{
+			SiteMovieBase: yts.SiteMovieBase{
+				Slug:   "no-country-for-old-men-2007",
+				Title:  "No Country for Old Men",
+				Year:   2007,
+				Link:   "https://yts.mx/movies/no-country-for-old-men-2007",
+				Image:  imageURL + "No_Country_for_Old_Men_2007/medium-cover.jpg",
+				Genres: []yts.Genre{yts.GenreCrime, yts.GenreDrama},
+			},
+			Rating: yts.Rating{Value: 8.2, Scale: 10},
+		}
+		sicarioSite = yts.SiteMovie{
+			SiteMovieBase: yts.SiteMovieBase{
+				Slug:   "sicario-2015",
+				Title:  "Sicario",
+				Year:   2015,
+				Link:   "https://yts.mx/movies/sicario-2015",
+				Image:  imageURL + "sicario_2015/medium-cover.jpg",
+				Genres: []yts.Genre{yts.GenreAction, yts.GenreCrime},
+			},
+			Rating: yts.Rating{Value: 7.6, Scale: 10},
+		}
+	)
+
+	tests := []struct {
+		name          string
+		person        string
+		listFile      string
+		browseFile    string
+		browseStatus  int
+		wantQueryTerm string
+		want          *yts.MoviesByPersonResponse
+		wantErr       error
+	}{
+		{
+			name:    "returns error for empty person",
+			person:  "  ",
+			wantErr: yts.ErrValidationFailure,
+		},
+		{
+			name:          "returns deduplicated movies sorted by year",
+			person:        "Josh Brolin",
+			listFile:      "list_movies.json",
+			browseFile:    "browse_movies.html",
+			browseStatus:  http.StatusOK,
+			wantQueryTerm: "Josh Brolin",
+			want: &yts.MoviesByPersonResponse{
+				Data: yts.MoviesByPersonData{
+					Movies: []yts.PersonMovie{
+						{Slug: jackieBrown.Slug, Title: jackieBrown.Title, Year: 1997, Movie: &jackieBrown},
+						{Slug: noCountry.Slug, Title: noCountry.Title, Year: 2007, Movie: &noCountry, Site: &noCountrySite},
+						{Slug: sicarioSite.Slug, Title: sicarioSite.Title, Year: 2015, Site: &sicarioSite},
+					},
+				},
+			},
+		},
+		{
+			name:          "normalizes cast IMDb code into name code",
+			person:        "0000982",
+			listFile:      "no_movies.json",
+			browseFile:    "no_results.html",
+			browseStatus:  http.StatusOK,
+			wantQueryTerm: "nm0000982",
+			want: &yts.MoviesByPersonResponse{
+				Data: yts.MoviesByPersonData{Movies: []yts.PersonMovie{}},
+			},
+		},
+		{
+			name:          "returns error when scraped movie is invalid",
+			person:        "nm0000982",
+			listFile:      "list_movies.json",
+			browseFile:    "missing_year.html",
+			browseStatus:  http.StatusOK,
+			wantQueryTerm: "nm0000982",
+			wantErr:       yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:          "returns error when browse page status is outside 2.x.x range",
+			person:        "Josh Brolin",
+			listFile:      "list_movies.json",
+			browseStatus:  http.StatusInternalServerError,
+			wantQueryTerm: "Josh Brolin",
+			wantErr:       yts.ErrUnexpectedHTTPResponseStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := yts.DefaultClientConfig()
+			var gotQueryTerm string
+			if tt.listFile != "" {
+				server := createTestServer(
+					t,
+					defaultHandlerConfig(t, "list_movies.json", testdataDir, tt.listFile),
+					testHTTPHandlerConfig{
+						filename:   path.Join(testdataDir, tt.browseFile),
+						pattern:    "/browse-movies/",
+						statusCode: tt.browseStatus,
+					},
+				)
+				defer server.Close()
+
+				handler := server.Config.Handler
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/list_movies.json" {
+						gotQueryTerm = r.URL.Query().Get("query_term")
+					}
+					handler.ServeHTTP(w, r)
+				})
+
+				serverURL, _ := url.Parse(server.URL)
+				clientCfg.SiteURL = *serverURL
+				clientCfg.APIBaseURL = *serverURL
+			}
+
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.MoviesByPersonWithContext(context.Background(), tt.person)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+			assertEqual(t, methodName, gotQueryTerm, tt.wantQueryTerm)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package yts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DefaultRatingScale is the scale used by the YTS API for the Rating field of a
+// MoviePartial, as well as by the YTS website for scraped ratings.
+const DefaultRatingScale = 10
+
+var ratingRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*\/\s*(\d+(?:\.\d+)?)$`)
+
+// A Rating represents a scraped rating such as "6.8 / 10" as its numeric Value and
+// the Scale it is expressed in. A Rating is marshalled to and from JSON as its raw
+// "value / scale" string so that previously marshalled payloads remain valid.
+type Rating struct {
+	Value float64
+	Scale float64
+
+	// The text the rating was parsed from when it differs from the format returned
+	// by the String method, such as "8/10" or "7.50 / 10", it is marshalled as is
+	// so that scraped ratings round-trip through JSON unchanged. Raw makes == depend
+	// on the source text, ratings should be compared with the Equal method instead.
+	Raw string
+}
+
+// ParseRating parses a rating in the "[0-9].[0-9] / [0-9]" format into a Rating, an
+// empty string is parsed into the zero Rating.
+func ParseRating(s string) (Rating, error) {
+	s = cleanString(s)
+	if s == "" {
+		return Rating{}, nil
+	}
+
+	matches := ratingRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return Rating{}, fmt.Errorf("expecting rating in %q format, got %q", "[0-9].[0-9] / 10", s)
+	}
+
+	value, _ := strconv.ParseFloat(matches[1], 64)
+	scale, _ := strconv.ParseFloat(matches[2], 64)
+	rating := Rating{Value: value, Scale: scale}
+	if rating.String() != s {
+		rating.Raw = s
+	}
+
+	return rating, rating.Validate()
+}
+
+// IsZero reports whether r is the zero Rating i.e. whether no rating was present.
+func (r Rating) IsZero() bool {
+	return r.Value == 0 && r.Scale == 0
+}
+
+// Equal reports whether r and other have the same Value and Scale, regardless of
+// the Raw text they were parsed from.
+func (r Rating) Equal(other Rating) bool {
+	return r.Value == other.Value && r.Scale == other.Scale
+}
+
+// Normalized returns the value of r on the DefaultRatingScale, which makes it
+// directly comparable with the Rating field of a MoviePartial.
+func (r Rating) Normalized() float64 {
+	switch r.Scale {
+	case 0:
+		return 0
+	case DefaultRatingScale:
+		return r.Value
+	}
+
+	return r.Value * DefaultRatingScale / r.Scale
+}
+
+// Compare returns -1, 0 or +1 depending on whether the normalized value of r is
+// less than, equal to or greater than that of other.
+func (r Rating) Compare(other Rating) int {
+	rv, ov := r.Normalized(), other.Normalized()
+	switch {
+	case rv < ov:
+		return -1
+	case rv > ov:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String returns r in the "value / scale" format used by the YTS website, the
+// zero Rating is returned as an empty string.
+func (r Rating) String() string {
+	if r.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"%s / %s",
+		strconv.FormatFloat(r.Value, 'f', -1, 64),
+		strconv.FormatFloat(r.Scale, 'f', -1, 64),
+	)
+}
+
+// Validate implements the validation.Validatable interface, the zero Rating is
+// considered valid.
+func (r Rating) Validate() error {
+	if r.IsZero() {
+		return nil
+	}
+
+	if r.Scale <= 0 {
+		return errors.New("rating scale must be positive")
+	}
+
+	if r.Value < 0 || r.Scale < r.Value {
+		return fmt.Errorf("rating value must be between 0 and %v", r.Scale)
+	}
+
+	return nil
+}
+
+// MarshalJSON marshals r as its Raw text when present, and as the string returned
+// by the String method otherwise.
+func (r Rating) MarshalJSON() ([]byte, error) {
+	if r.Raw != "" {
+		return json.Marshal(r.Raw)
+	}
+
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts both the "value / scale" string produced by MarshalJSON
+// and a plain JSON number, the latter being interpreted on the DefaultRatingScale.
+func (r *Rating) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*r = Rating{Value: number, Scale: DefaultRatingScale}
+		return r.Validate()
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	rating, err := ParseRating(str)
+	if err != nil {
+		return err
+	}
+
+	*r = rating
+	return nil
+}
+
+var requiredRatingRule = validation.By(func(value interface{}) error {
+	if rating, _ := value.(Rating); rating.IsZero() {
+		return validation.ErrRequired
+	}
+	return nil
+})
+
+func ratingLess(a, b Rating, order OrderBy) bool {
+	if order == OrderByAsc {
+		return a.Compare(b) < 0
+	}
+	return a.Compare(b) > 0
+}
+
+// SortSiteMoviesByRating sorts the provided movies in place by their normalized
+// rating in the provided order, movies with equal ratings keep their original
+// relative order.
+func SortSiteMoviesByRating(movies []SiteMovie, order OrderBy) {
+	sort.SliceStable(movies, func(i, j int) bool {
+		return ratingLess(movies[i].Rating, movies[j].Rating, order)
+	})
+}
+
+// FilterSiteMoviesByRating returns the provided movies whose normalized rating is
+// at least minimumRating, movies without a rating are excluded.
+func FilterSiteMoviesByRating(movies []SiteMovie, minimumRating float64) []SiteMovie {
+	filtered := make([]SiteMovie, 0)
+	for _, movie := range movies {
+		if !movie.Rating.IsZero() && minimumRating <= movie.Rating.Normalized() {
+			filtered = append(filtered, movie)
+		}
+	}
+	return filtered
+}
+
+// SortSiteMovieReviewsByRating sorts the provided reviews in place by their
+// normalized rating in the provided order, reviews with equal ratings keep their
+// original relative order.
+func SortSiteMovieReviewsByRating(reviews []SiteMovieReview, order OrderBy) {
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return ratingLess(reviews[i].Rating, reviews[j].Rating, order)
+	})
+}
+
+// FilterSiteMovieReviewsByRating returns the provided reviews whose normalized
+// rating is at least minimumRating.
+func FilterSiteMovieReviewsByRating(reviews []SiteMovieReview, minimumRating float64) []SiteMovieReview {
+	filtered := make([]SiteMovieReview, 0)
+	for _, review := range reviews {
+		if !review.Rating.IsZero() && minimumRating <= review.Rating.Normalized() {
+			filtered = append(filtered, review)
+		}
+	}
+	return filtered
+}
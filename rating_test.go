@@ -0,0 +1,184 @@
+package yts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestParseRating(t *testing.T) {
+	const methodName = "ParseRating"
+
+	tests := []struct {
+		name    string
+		input   string
+		want    yts.Rating
+		wantErr bool
+	}{
+		{
+			name:  "returns zero rating for empty string",
+			input: "",
+			want:  yts.Rating{},
+		},
+		{
+			name:  "parses decimal rating out of 10",
+			input: "6.8 / 10",
+			want:  yts.Rating{Value: 6.8, Scale: 10},
+		},
+		{
+			name:  "parses integer rating without spaces",
+			input: "7/10",
+			want:  yts.Rating{Value: 7, Scale: 10, Raw: "7/10"},
+		},
+		{
+			name:  "parses rating on a different scale",
+			input: " 85 / 100 ",
+			want:  yts.Rating{Value: 85, Scale: 100},
+		},
+		{
+			name:    "returns error for malformed rating",
+			input:   "__INVALID_RATING__",
+			wantErr: true,
+		},
+		{
+			name:    "returns error when value exceeds scale",
+			input:   "11 / 10",
+			want:    yts.Rating{Value: 11, Scale: 10},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yts.ParseRating(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s() error = %v, wantErr %v", methodName, err, tt.wantErr)
+			}
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestRating_Normalized(t *testing.T) {
+	var (
+		scraped = yts.Rating{Value: 34, Scale: 50}
+		partial = yts.MoviePartial{Rating: 6.8}
+	)
+
+	assertEqual(t, "Rating.Normalized", scraped.Normalized(), partial.Rating)
+	assertEqual(t, "Rating.Compare", scraped.Compare(yts.Rating{Value: 6.8, Scale: 10}), 0)
+	assertEqual(t, "Rating.Compare", scraped.Compare(yts.Rating{Value: 7, Scale: 10}), -1)
+	assertEqual(t, "Rating.Normalized", yts.Rating{}.Normalized(), 0.0)
+}
+
+func TestRating_Equal(t *testing.T) {
+	const methodName = "Rating.Equal"
+
+	spaced, _ := yts.ParseRating("7.5 / 10")
+	compact, _ := yts.ParseRating("7.5/10")
+	assertEqual(t, methodName, spaced.Equal(compact), true)
+	assertEqual(t, methodName, compact.Equal(yts.Rating{Value: 7.5, Scale: 10}), true)
+	assertEqual(t, methodName, compact.Equal(yts.Rating{Value: 15, Scale: 20}), false)
+	assertEqual(t, methodName, yts.Rating{}.Equal(yts.Rating{}), true)
+}
+
+func TestRating_JSON(t *testing.T) {
+	const methodName = "Rating.MarshalJSON"
+
+	movie := yts.SiteMovie{Rating: yts.Rating{Value: 6.8, Scale: 10}}
+	payload, err := json.Marshal(movie)
+	assertError(t, methodName, err, nil)
+
+	var fields map[string]any
+	_ = json.Unmarshal(payload, &fields)
+	assertEqual(t, methodName, fields["rating"], "6.8 / 10")
+
+	tests := []struct {
+		name    string
+		payload string
+		want    yts.Rating
+		wantErr bool
+	}{
+		{
+			name:    "unmarshals legacy rating string",
+			payload: `"7 / 10"`,
+			want:    yts.Rating{Value: 7, Scale: 10},
+		},
+		{
+			name:    "unmarshals plain number on default scale",
+			payload: `8.1`,
+			want:    yts.Rating{Value: 8.1, Scale: 10},
+		},
+		{
+			name:    "unmarshals empty string to zero rating",
+			payload: `""`,
+			want:    yts.Rating{},
+		},
+		{
+			name:    "returns error for malformed rating",
+			payload: `"great"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got yts.Rating
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rating.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assertEqual(t, "Rating.UnmarshalJSON", got, tt.want)
+		})
+	}
+
+	for _, payload := range []string{`"8/10"`, `"7.50 / 10"`, `"6.8 / 10"`, `""`} {
+		var rating yts.Rating
+		err := json.Unmarshal([]byte(payload), &rating)
+		assertError(t, methodName, err, nil)
+
+		got, err := json.Marshal(rating)
+		assertError(t, methodName, err, nil)
+		assertEqual(t, methodName, string(got), payload)
+	}
+}
+
+func TestSortAndFilterSiteMoviesByRating(t *testing.T) {
+	newMovie := func(title string, value float64) yts.SiteMovie {
+		return yts.SiteMovie{
+			SiteMovieBase: yts.SiteMovieBase{Title: title},
+			Rating:        yts.Rating{Value: value, Scale: 10},
+		}
+	}
+
+	var (
+		low     = newMovie("low", 4.2)
+		mid     = newMovie("mid", 6.8)
+		high    = newMovie("high", 8.5)
+		unrated = yts.SiteMovie{SiteMovieBase: yts.SiteMovieBase{Title: "unrated"}}
+		movies  = []yts.SiteMovie{mid, unrated, high, low}
+	)
+
+	yts.SortSiteMoviesByRating(movies, yts.OrderByDesc)
+	assertEqual(t, "SortSiteMoviesByRating", movies, []yts.SiteMovie{high, mid, low, unrated})
+
+	yts.SortSiteMoviesByRating(movies, yts.OrderByAsc)
+	assertEqual(t, "SortSiteMoviesByRating", movies, []yts.SiteMovie{unrated, low, mid, high})
+
+	got := yts.FilterSiteMoviesByRating(movies, 6.8)
+	assertEqual(t, "FilterSiteMoviesByRating", got, []yts.SiteMovie{mid, high})
+}
+
+func TestSortAndFilterSiteMovieReviewsByRating(t *testing.T) {
+	var (
+		seven   = yts.SiteMovieReview{Author: "a", Rating: yts.Rating{Value: 7, Scale: 10}}
+		ten     = yts.SiteMovieReview{Author: "b", Rating: yts.Rating{Value: 10, Scale: 10}}
+		three   = yts.SiteMovieReview{Author: "c", Rating: yts.Rating{Value: 3, Scale: 10}}
+		reviews = []yts.SiteMovieReview{seven, ten, three}
+	)
+
+	yts.SortSiteMovieReviewsByRating(reviews, yts.OrderByDesc)
+	assertEqual(t, "SortSiteMovieReviewsByRating", reviews, []yts.SiteMovieReview{ten, seven, three})
+
+	got := yts.FilterSiteMovieReviewsByRating(reviews, 5)
+	assertEqual(t, "FilterSiteMovieReviewsByRating", got, []yts.SiteMovieReview{ten, seven})
+}
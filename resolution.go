@@ -0,0 +1,296 @@
+package yts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// A MovieRef holds the identifiers of a single movie on YTS i.e. its YTS movie ID,
+// its slug and its IMDb code.
+type MovieRef struct {
+	ID       int    `json:"id"`
+	Slug     string `json:"slug,omitempty"`
+	ImdbCode string `json:"imdb_code,omitempty"`
+}
+
+func (mr *MovieRef) merge(other MovieRef) bool {
+	changed := false
+	if other.Slug != "" && other.Slug != mr.Slug {
+		mr.Slug = other.Slug
+		changed = true
+	}
+	if other.ImdbCode != "" && other.ImdbCode != mr.ImdbCode {
+		mr.ImdbCode = other.ImdbCode
+		changed = true
+	}
+	return changed
+}
+
+// A ResolutionStore persists the MovieRef instances held by a ResolutionIndex so
+// that they survive across program runs.
+type ResolutionStore interface {
+	// Load returns every MovieRef previously saved to the store, in the event a
+	// movie ID appears more than once the last MovieRef for it takes precedence.
+	Load() ([]MovieRef, error)
+
+	// Save persists the provided MovieRef, replacing any MovieRef previously saved
+	// for the same movie ID.
+	Save(ref MovieRef) error
+}
+
+// A ResolutionIndex maps movie slugs, YTS movie IDs and IMDb codes to one another,
+// it is safe for concurrent use. A yts.Client populates its ResolutionIndex with
+// every Movie and MovieDetails returned by the YTS API so that resolving between
+// these identifiers becomes a lookup whenever possible.
+type ResolutionIndex struct {
+	mu         sync.RWMutex
+	byID       map[int]MovieRef
+	slugToID   map[string]int
+	imdbToID   map[string]int
+	store      ResolutionStore
+	storeMutex sync.Mutex
+}
+
+// NewResolutionIndex creates a *ResolutionIndex backed by the provided store,
+// which is loaded immediately. A nil store creates an index which is only held in
+// memory.
+func NewResolutionIndex(store ResolutionStore) (*ResolutionIndex, error) {
+	ri := &ResolutionIndex{
+		byID:     make(map[int]MovieRef),
+		slugToID: make(map[string]int),
+		imdbToID: make(map[string]int),
+	}
+
+	if store == nil {
+		return ri, nil
+	}
+
+	refs, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		ri.addLocked(ref)
+	}
+
+	ri.store = store
+	return ri, nil
+}
+
+func (ri *ResolutionIndex) addLocked(ref MovieRef) (MovieRef, bool) {
+	existing, ok := ri.byID[ref.ID]
+	if !ok {
+		existing = MovieRef{ID: ref.ID}
+	}
+
+	changed := existing.merge(ref) || !ok
+	if !changed {
+		return existing, false
+	}
+
+	if previous, ok := ri.byID[ref.ID]; ok {
+		if previous.Slug != existing.Slug {
+			delete(ri.slugToID, previous.Slug)
+		}
+		if previous.ImdbCode != existing.ImdbCode {
+			delete(ri.imdbToID, previous.ImdbCode)
+		}
+	}
+
+	ri.byID[ref.ID] = existing
+	if existing.Slug != "" {
+		ri.slugToID[existing.Slug] = existing.ID
+	}
+	if existing.ImdbCode != "" {
+		ri.imdbToID[existing.ImdbCode] = existing.ID
+	}
+
+	return existing, true
+}
+
+// Add merges the provided MovieRef into the index, empty fields of ref leave the
+// corresponding identifiers already known for the movie untouched. A MovieRef
+// without a positive ID is ignored, an error is only returned in the event the
+// merged MovieRef could not be saved to the store of the index.
+func (ri *ResolutionIndex) Add(ref MovieRef) error {
+	if ref.ID <= 0 {
+		return nil
+	}
+
+	// The store mutex is held from merging until saving, so that MovieRef instances
+	// are saved in the order they were merged and the last one saved for a movie ID
+	// is always the latest, while lookups only wait for the merge.
+	ri.storeMutex.Lock()
+	defer ri.storeMutex.Unlock()
+
+	ri.mu.Lock()
+	merged, changed := ri.addLocked(ref)
+	ri.mu.Unlock()
+
+	if !changed || ri.store == nil {
+		return nil
+	}
+
+	return ri.store.Save(merged)
+}
+
+func (ri *ResolutionIndex) addMoviePartials(partials ...*MoviePartial) {
+	for _, mp := range partials {
+		ref := MovieRef{ID: mp.ID, Slug: mp.Slug, ImdbCode: mp.ImdbCode}
+		if err := ri.Add(ref); err != nil {
+			debug.Println(err)
+		}
+	}
+}
+
+func (ri *ResolutionIndex) addMovies(movies []Movie) {
+	for i := range movies {
+		ri.addMoviePartials(&movies[i].MoviePartial)
+	}
+}
+
+// LookupID returns the MovieRef for the provided YTS movie ID.
+func (ri *ResolutionIndex) LookupID(movieID int) (MovieRef, bool) {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	ref, ok := ri.byID[movieID]
+	return ref, ok
+}
+
+// LookupSlug returns the MovieRef for the provided movie slug.
+func (ri *ResolutionIndex) LookupSlug(movieSlug string) (MovieRef, bool) {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	movieID, ok := ri.slugToID[movieSlug]
+	if !ok {
+		return MovieRef{}, false
+	}
+	return ri.byID[movieID], true
+}
+
+// LookupIMDbCode returns the MovieRef for the provided IMDb code e.g. "tt15398776".
+func (ri *ResolutionIndex) LookupIMDbCode(imdbCode string) (MovieRef, bool) {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	movieID, ok := ri.imdbToID[imdbCode]
+	if !ok {
+		return MovieRef{}, false
+	}
+	return ri.byID[movieID], true
+}
+
+// Len returns the number of movies held by the index.
+func (ri *ResolutionIndex) Len() int {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return len(ri.byID)
+}
+
+// A FileResolutionStore is a ResolutionStore which persists MovieRef instances to
+// a file as JSON lines, each call to Save appends a single line to the file. The
+// file is compacted by Load whenever it holds more than one line for a movie ID.
+type FileResolutionStore struct {
+	path string
+}
+
+// NewFileResolutionStore creates a *FileResolutionStore for the file at the
+// provided path, the file is created upon the first call to Save if need be.
+func NewFileResolutionStore(path string) *FileResolutionStore {
+	return &FileResolutionStore{path}
+}
+
+// Load implements the ResolutionStore interface, a missing file is treated as an
+// empty store. The returned MovieRef instances hold a single MovieRef per movie ID.
+func (frs *FileResolutionStore) Load() ([]MovieRef, error) {
+	file, err := os.Open(frs.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make([]MovieRef, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		refs    = make([]MovieRef, 0)
+		indexOf = make(map[int]int)
+		scanner = bufio.NewScanner(file)
+		line    = 0
+		lines   = 0
+	)
+
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var ref MovieRef
+		if err := json.Unmarshal(scanner.Bytes(), &ref); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", frs.path, line, err)
+		}
+
+		lines++
+		if i, ok := indexOf[ref.ID]; ok {
+			refs[i] = ref
+			continue
+		}
+		indexOf[ref.ID] = len(refs)
+		refs = append(refs, ref)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if lines > len(refs) {
+		if err := frs.compact(refs); err != nil {
+			debug.Println(err)
+		}
+	}
+
+	return refs, nil
+}
+
+// compact replaces the file of the store with one holding a single line for each
+// of the provided MovieRef instances, the file is written to a temporary file
+// first so that it is never left partially written.
+func (frs *FileResolutionStore) compact(refs []MovieRef) error {
+	const fileMode = 0o644
+	payload := make([]byte, 0)
+	for _, ref := range refs {
+		line, err := json.Marshal(ref)
+		if err != nil {
+			return err
+		}
+		payload = append(append(payload, line...), '\n')
+	}
+
+	if err := os.WriteFile(frs.path+".tmp", payload, fileMode); err != nil {
+		return err
+	}
+
+	return os.Rename(frs.path+".tmp", frs.path)
+}
+
+// Save implements the ResolutionStore interface.
+func (frs *FileResolutionStore) Save(ref MovieRef) error {
+	const fileMode = 0o644
+	payload, err := json.Marshal(ref)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(frs.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(payload, '\n'))
+	return errors.Join(err, file.Close())
+}
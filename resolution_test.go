@@ -0,0 +1,205 @@
+package yts_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func TestResolutionIndex(t *testing.T) {
+	const methodName = "ResolutionIndex"
+
+	index, err := yts.NewResolutionIndex(nil)
+	assertError(t, methodName, err, nil)
+
+	assertError(t, methodName, index.Add(yts.MovieRef{Slug: "no-id"}), nil)
+	assertEqual(t, methodName, index.Len(), 0)
+
+	assertError(t, methodName, index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023"}), nil)
+	assertError(t, methodName, index.Add(yts.MovieRef{ID: 57427, ImdbCode: "tt15398776"}), nil)
+
+	want := yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023", ImdbCode: "tt15398776"}
+	got, ok := index.LookupID(57427)
+	assertEqual(t, methodName, ok, true)
+	assertEqual(t, methodName, got, want)
+
+	got, ok = index.LookupSlug("oppenheimer-2023")
+	assertEqual(t, methodName, ok, true)
+	assertEqual(t, methodName, got, want)
+
+	got, ok = index.LookupIMDbCode("tt15398776")
+	assertEqual(t, methodName, ok, true)
+	assertEqual(t, methodName, got, want)
+
+	assertError(t, methodName, index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023-renamed"}), nil)
+	_, ok = index.LookupSlug("oppenheimer-2023")
+	assertEqual(t, methodName, ok, false)
+	got, _ = index.LookupSlug("oppenheimer-2023-renamed")
+	assertEqual(t, methodName, got.ImdbCode, "tt15398776")
+	assertEqual(t, methodName, index.Len(), 1)
+}
+
+func TestFileResolutionStore(t *testing.T) {
+	const methodName = "FileResolutionStore"
+
+	storePath := filepath.Join(t.TempDir(), "index.jsonl")
+	index, err := yts.NewResolutionIndex(yts.NewFileResolutionStore(storePath))
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, index.Len(), 0)
+
+	_ = index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023"})
+	_ = index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023"})
+	_ = index.Add(yts.MovieRef{ID: 57427, ImdbCode: "tt15398776"})
+	_ = index.Add(yts.MovieRef{ID: 6738, Slug: "no-country-for-old-men-2007"})
+
+	payload, _ := os.ReadFile(storePath)
+	lines := strings.Split(strings.TrimSpace(string(payload)), "\n")
+	assertEqual(t, methodName, len(lines), 3)
+
+	reloaded, err := yts.NewResolutionIndex(yts.NewFileResolutionStore(storePath))
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, reloaded.Len(), 2)
+
+	got, _ := reloaded.LookupIMDbCode("tt15398776")
+	assertEqual(t, methodName, got, yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023", ImdbCode: "tt15398776"})
+
+	// Loading compacts the file to a single line per movie ID.
+	payload, _ = os.ReadFile(storePath)
+	lines = strings.Split(strings.TrimSpace(string(payload)), "\n")
+	assertEqual(t, methodName, len(lines), 2)
+
+	compacted, _ := yts.NewResolutionIndex(yts.NewFileResolutionStore(storePath))
+	got, _ = compacted.LookupIMDbCode("tt15398776")
+	assertEqual(t, methodName, got, yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023", ImdbCode: "tt15398776"})
+
+	_ = os.WriteFile(storePath, []byte("{invalid\n"), 0o600)
+	_, err = yts.NewResolutionIndex(yts.NewFileResolutionStore(storePath))
+	if err == nil {
+		t.Errorf("%s() expected error for corrupt store", methodName)
+	}
+}
+
+// A blockingResolutionStore records saved MovieRef instances, the first save
+// closes entered and then blocks until release is closed.
+type blockingResolutionStore struct {
+	mu      sync.Mutex
+	calls   int
+	saved   []yts.MovieRef
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingResolutionStore) Load() ([]yts.MovieRef, error) {
+	return nil, nil
+}
+
+func (s *blockingResolutionStore) Save(ref yts.MovieRef) error {
+	s.mu.Lock()
+	s.calls++
+	first := s.calls == 1
+	s.mu.Unlock()
+
+	if first {
+		close(s.entered)
+		<-s.release
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = append(s.saved, ref)
+	return nil
+}
+
+func TestResolutionIndex_ConcurrentAdd(t *testing.T) {
+	const methodName = "ResolutionIndex.Add"
+
+	store := &blockingResolutionStore{entered: make(chan struct{}), release: make(chan struct{})}
+	index, _ := yts.NewResolutionIndex(store)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023"})
+	}()
+
+	<-store.entered
+	go func() {
+		defer wg.Done()
+		_ = index.Add(yts.MovieRef{ID: 57427, Slug: "oppenheimer-2023-renamed"})
+	}()
+
+	// Adds wait for the save in progress before merging, while lookups do not.
+	time.Sleep(20 * time.Millisecond)
+	got, _ := index.LookupID(57427)
+	assertEqual(t, methodName, got.Slug, "oppenheimer-2023")
+
+	close(store.release)
+	wg.Wait()
+
+	// The last ref saved, which takes precedence when loading the store, is the
+	// latest ref merged into the index.
+	want, _ := index.LookupID(57427)
+	assertEqual(t, methodName, len(store.saved), 2)
+	assertEqual(t, methodName, store.saved[len(store.saved)-1], want)
+}
+
+func TestClient_ResolveMovieSlugToIDWithContext_ResolutionIndex(t *testing.T) {
+	const methodName = "Client.ResolveMovieSlugToID"
+
+	var pageRequests int32
+	server := createTestServer(
+		t,
+		defaultHandlerConfig(t, "list_movies.json", "movie_by_imdb_id", "list_movies.json"),
+		testHTTPHandlerConfig{
+			filename:   path.Join("enrich_movies", "movie_page.html"),
+			pattern:    "/movies/",
+			statusCode: http.StatusOK,
+		},
+	)
+	defer server.Close()
+
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/movies/") {
+			atomic.AddInt32(&pageRequests, 1)
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	serverURL, _ := url.Parse(server.URL)
+	index, _ := yts.NewResolutionIndex(nil)
+	clientCfg := yts.DefaultClientConfig()
+	clientCfg.SiteURL = *serverURL
+	clientCfg.APIBaseURL = *serverURL
+	clientCfg.ResolutionIndex = index
+	c, _ := yts.NewClientWithConfig(&clientCfg)
+
+	_, err := c.SearchMoviesWithContext(context.Background(), yts.DefaultSearchMoviesFilters("oppenheimer"))
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, index.Len(), 2)
+
+	movieID, err := c.ResolveMovieSlugToIDWithContext(context.Background(), "oppenheimer-2023")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movieID, 57427)
+	assertEqual(t, methodName, atomic.LoadInt32(&pageRequests), int32(0))
+
+	movieID, err = c.ResolveMovieSlugToIDWithContext(context.Background(), "unindexed-2023")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, movieID, 57427)
+	assertEqual(t, methodName, atomic.LoadInt32(&pageRequests), int32(1))
+
+	_, err = c.ResolveMovieSlugToIDWithContext(context.Background(), "unindexed-2023")
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, atomic.LoadInt32(&pageRequests), int32(1))
+}
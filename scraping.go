@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -16,10 +17,12 @@ import (
 
 const (
 	trendingCSS = "div.browse-movie-wrap"
+	browseCSS   = "div.browse-content section div.browse-movie-wrap"
 	popularCSS  = "div#popular-downloads div.browse-movie-wrap"
 	latestCSS   = "div.content-dark div.home-movies div.browse-movie-wrap"
 	upcomingCSS = "div.content-dark ~ div.home-content div.browse-movie-wrap"
 	directorCSS = "div#movie-content div#movie-sub-info div#crew div.directors"
+	crewCSS     = "div#movie-content div#movie-sub-info div#crew"
 )
 
 const (
@@ -36,6 +39,15 @@ const (
 	directorNameCSS  = "div.list-cast-info a.name-cast span span"
 )
 
+const (
+	crewDirectorsCSS = "div.directors div.list-cast"
+	crewActorsCSS    = "div.actors div.list-cast"
+	crewThumbCSS     = "a.avatar-thumb img"
+	crewProfileCSS   = "a.avatar-thumb"
+	crewInfoCSS      = "div.list-cast-info"
+	crewNameCSS      = "a.name-cast span[itemprop='name']"
+)
+
 const (
 	reviewsCSS      = "div#movie-reviews div.review"
 	reviewRatingCSS = "div.review-properties span.review-rating"
@@ -43,8 +55,31 @@ const (
 	reviewsMoreCSS  = "div#movie-reviews a.more-reviews"
 )
 
+const (
+	pageDetailsCSS       = "div#movie-content"
+	pageSubtitlesCSS     = "div#movie-info a[href*='subtitles']"
+	pageIMDbCSS          = "div#movie-info div.rating-row a[title='IMDb Rating']"
+	pageIMDbRatingCSS    = "span[itemprop='ratingValue']"
+	pageTomatometerCSS   = "div#movie-info div.rating-row span[title='Tomatometer']"
+	pageAudienceScoreCSS = "div#movie-info div.rating-row span[title='Audience']"
+	pageAlsoKnownAsCSS   = "div#movie-info div.alternative-titles span.alternative-title"
+	pageSimilarMoviesCSS = "div#movie-related a"
+)
+
+const (
+	reviewListingCSS         = "div.imdb-user-review"
+	reviewListingTitleCSS    = "a.title"
+	reviewListingAuthorCSS   = "span.display-name-link a"
+	reviewListingDateCSS     = "span.review-date"
+	reviewListingRatingCSS   = "span.rating-other-user-rating"
+	reviewListingContentCSS  = "div.content div.text"
+	reviewListingHelpfulCSS  = "div.actions"
+	reviewListingLoadMoreCSS = "div.load-more-data"
+)
+
 const (
 	commentCSS          = "div.comment"
+	commentProfileCSS   = "div.comment a.avatar-thumb"
 	movieIDCSS          = "div#movie-info[data-movie-id]"
 	commentCountCSS     = "div#movie-comments span#comment-count"
 	commentAvatarCSS    = "div.comment a.avatar-thumb img"
@@ -135,7 +170,7 @@ func (smb *SiteMovieBase) scrape(s *goquery.Selection, u *url.URL) error {
 	})
 
 	if image != "" {
-		smb.Image = fmt.Sprintf("%s%s", u.String(), image)
+		smb.Image = resolveScrapedImageURL(u, image)
 	}
 
 	smb.Title = bottom.Find(movieTitleCSS).Text()
@@ -152,7 +187,7 @@ func (smb *SiteMovieBase) scrape(s *goquery.Selection, u *url.URL) error {
 // - The trending movies shown on the YTS website.
 type SiteMovie struct {
 	SiteMovieBase
-	Rating string `json:"rating"`
+	Rating Rating `json:"rating"`
 }
 
 var validateRatingRule = validation.NewStringRule(
@@ -164,13 +199,21 @@ var validateRatingRule = validation.NewStringRule(
 	`expecting rating in "[0-9].[0-9] / 10" format`,
 )
 
+func scrapeRating(s string) (Rating, error) {
+	rating := cleanString(s)
+	if err := validation.Validate(rating, validateRatingRule); err != nil {
+		return Rating{}, fmt.Errorf("rating: %w", err)
+	}
+
+	return ParseRating(rating)
+}
+
 func (sm *SiteMovie) validateScraping() error {
 	bErr := sm.SiteMovieBase.validateScraping()
 	mErr := validation.ValidateStruct(
 		sm,
 		validation.Field(
 			&sm.Rating,
-			validateRatingRule,
 		),
 	)
 	return errors.Join(bErr, mErr)
@@ -183,9 +226,10 @@ func (sm *SiteMovie) scrape(s *goquery.Selection, u *url.URL) error {
 		rating = anchor.Find("h4.rating").Text()
 	)
 
+	parsedRating, rErr := scrapeRating(rating)
 	sm.Slug = path.Base(sm.Link)
-	sm.Rating = rating
-	return sm.validateScraping()
+	sm.Rating = parsedRating
+	return errors.Join(rErr, sm.validateScraping())
 }
 
 // A SiteUpcomingMovie instance represents all the information provided for each
@@ -273,13 +317,206 @@ func (smd *SiteMovieDirector) scrape(s *goquery.Selection) error {
 	return smd.validateScraping()
 }
 
+// A SiteMoviePerson instance contains the name, IMDb code and thumbnail image URL
+// for a member of the crew or cast of a movie as shown on a YTS movie page. The IMDb
+// code is scraped from the IMDb profile link without its "nm" prefix, matching the
+// format of the ImdbCode of a Cast returned by the YTS API e.g. "0614165".
+type SiteMoviePerson struct {
+	Name          string `json:"name"`
+	ImdbCode      string `json:"imdb_code"`
+	URLSmallImage string `json:"url_small_image"`
+}
+
+var (
+	imdbNameCodeRegex       = regexp.MustCompile(`nm(\d{7,})`)
+	castImdbCodeFormatRegex = regexp.MustCompile(`^\d{7,}$`)
+)
+
+func (smp *SiteMoviePerson) validateScraping() error {
+	return validation.ValidateStruct(
+		smp,
+		validation.Field(
+			&smp.Name,
+			validation.Required,
+		),
+		validation.Field(
+			&smp.ImdbCode,
+			validation.Match(castImdbCodeFormatRegex),
+		),
+		validation.Field(
+			&smp.URLSmallImage,
+			is.URL,
+		),
+	)
+}
+
+func (smp *SiteMoviePerson) scrape(s *goquery.Selection) error {
+	var (
+		nameSel       = s.Find(crewNameCSS)
+		thumbImgSel   = s.Find(crewThumbCSS)
+		profileURL, _ = s.Find(crewProfileCSS).Attr("href")
+	)
+
+	smp.Name = cleanString(nameSel.Text())
+	if matches := imdbNameCodeRegex.FindStringSubmatch(profileURL); matches != nil {
+		smp.ImdbCode = matches[1]
+	}
+
+	smp.URLSmallImage, _ = thumbImgSel.Attr("src")
+	return smp.validateScraping()
+}
+
+// A SiteMovieCastMember instance contains the information for an actor listed in
+// the top cast of a movie as shown on a YTS movie page, along with the name of the
+// character they played.
+type SiteMovieCastMember struct {
+	SiteMoviePerson
+	CharacterName string `json:"character_name"`
+}
+
+func (smcm *SiteMovieCastMember) scrape(s *goquery.Selection) error {
+	var (
+		pErr      = smcm.SiteMoviePerson.scrape(s)
+		infoSel   = s.Find(crewInfoCSS)
+		infoNodes = infoSel.Contents().Not("a")
+	)
+
+	character := cleanString(infoNodes.Text())
+	character = strings.TrimPrefix(character, "as ")
+	smcm.CharacterName = strings.TrimSpace(character)
+	return pErr
+}
+
+// A SiteMovieSubtitle instance contains the label and URL of a subtitles link as
+// shown on a YTS movie page.
+type SiteMovieSubtitle struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+func (sms *SiteMovieSubtitle) validateScraping() error {
+	return validation.ValidateStruct(
+		sms,
+		validation.Field(
+			&sms.URL,
+			validation.Required,
+			is.URL,
+		),
+	)
+}
+
+// A SiteMovieScores instance contains the IMDb rating and RottenTomatoes scores
+// shown on a YTS movie page, the RottenTomatoes scores are percentages and a field
+// is left as 0 if the corresponding score is not shown.
+type SiteMovieScores struct {
+	IMDbRating    Rating `json:"imdb_rating"`
+	IMDbURL       string `json:"imdb_url"`
+	Tomatometer   int    `json:"tomatometer"`
+	AudienceScore int    `json:"audience_score"`
+}
+
+func (sms *SiteMovieScores) validateScraping() error {
+	const maxPercentage = 100
+	return validation.ValidateStruct(
+		sms,
+		validation.Field(
+			&sms.IMDbRating,
+		),
+		validation.Field(
+			&sms.IMDbURL,
+			is.URL,
+		),
+		validation.Field(
+			&sms.Tomatometer,
+			validation.Min(0),
+			validation.Max(maxPercentage),
+		),
+		validation.Field(
+			&sms.AudienceScore,
+			validation.Min(0),
+			validation.Max(maxPercentage),
+		),
+	)
+}
+
+func scrapePercentage(s *goquery.Selection) (int, error) {
+	text := strings.TrimSuffix(cleanString(s.Text()), "%")
+	if text == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(text)
+}
+
+func (sms *SiteMovieScores) scrape(d *goquery.Document) error {
+	var (
+		imdbSel       = d.Find(pageIMDbCSS)
+		imdbRatingSel = imdbSel.Parent().Find(pageIMDbRatingCSS)
+		imdbRating    = cleanString(imdbRatingSel.Text())
+		tomatoSel     = d.Find(pageTomatometerCSS).Next()
+		audienceSel   = d.Find(pageAudienceScoreCSS).Next()
+	)
+
+	var (
+		tomatometer, tErr   = scrapePercentage(tomatoSel)
+		audienceScore, aErr = scrapePercentage(audienceSel)
+	)
+
+	if imdbRating != "" {
+		value, err := strconv.ParseFloat(imdbRating, 64)
+		if err != nil {
+			return fmt.Errorf("imdb_rating: %w", err)
+		}
+		sms.IMDbRating = Rating{Value: value, Scale: DefaultRatingScale}
+	}
+
+	sms.IMDbURL, _ = imdbSel.Attr("href")
+	sms.Tomatometer = tomatometer
+	sms.AudienceScore = audienceScore
+	return errors.Join(tErr, aErr, sms.validateScraping())
+}
+
+var similarMovieTitleRegex = regexp.MustCompile(`^(.*)\s+\((\d{4})\)$`)
+
+func scrapeSimilarMovie(s *goquery.Selection, u *url.URL) (SiteMovieBase, error) {
+	var (
+		smb      = SiteMovieBase{Genres: make([]Genre, 0)}
+		title, _ = s.Attr("title")
+		image, _ = s.Find("img").Attr("src")
+	)
+
+	title = cleanString(title)
+	if matches := similarMovieTitleRegex.FindStringSubmatch(title); matches != nil {
+		smb.Year, _ = strconv.Atoi(matches[2])
+		title = matches[1]
+	}
+
+	if image != "" {
+		image = resolveScrapedImageURL(u, image)
+	}
+
+	smb.Link, _ = s.Attr("href")
+	smb.Slug = path.Base(smb.Link)
+	smb.Title = title
+	smb.Image = image
+	return smb, smb.validateScraping()
+}
+
 // A SiteMovieReview instance contains all the visible information for a movie
 // review as shown on a YTS movie page.
+//
+// The ID, Date, HelpfulCount and HelpfulTotal fields are only populated for reviews
+// returned by the AllMovieReviews method, since the reviews embedded on a YTS
+// movie page do not carry this information.
 type SiteMovieReview struct {
-	Author  string `json:"author"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Rating  string `json:"rating"`
+	ID           string `json:"id,omitempty"`
+	Author       string `json:"author"`
+	Title        string `json:"title"`
+	Content      string `json:"content"`
+	Rating       Rating `json:"rating"`
+	Date         string `json:"date,omitempty"`
+	HelpfulCount int    `json:"helpful_count,omitempty"`
+	HelpfulTotal int    `json:"helpful_total,omitempty"`
 }
 
 func (smr *SiteMovieReview) validateScraping() error {
@@ -299,8 +536,7 @@ func (smr *SiteMovieReview) validateScraping() error {
 		),
 		validation.Field(
 			&smr.Rating,
-			validateRatingRule,
-			validation.Required,
+			requiredRatingRule,
 		),
 	)
 }
@@ -314,20 +550,85 @@ func (smr *SiteMovieReview) scrape(s *goquery.Selection) error {
 	)
 
 	smr.Author = cleanString(authorSel.Text())
-	smr.Rating = cleanString(ratingSel.Text())
+	rating, rErr := scrapeRating(ratingSel.Text())
+	smr.Rating = rating
 	smr.Title = cleanString(titleSel.Text())
 	smr.Content = cleanString(contentSel.Text())
-	return smr.validateScraping()
+	return errors.Join(rErr, smr.validateScraping())
+}
+
+func (smr *SiteMovieReview) validateListingScraping() error {
+	return validation.ValidateStruct(
+		smr,
+		validation.Field(
+			&smr.Author,
+			validation.Required,
+		),
+		validation.Field(
+			&smr.Content,
+			validation.Required,
+		),
+		validation.Field(
+			&smr.Rating,
+		),
+		validation.Field(
+			&smr.HelpfulCount,
+			validation.Min(0),
+			validation.Max(smr.HelpfulTotal),
+		),
+	)
+}
+
+var reviewHelpfulRegex = regexp.MustCompile(`([\d,]+)\s+out\s+of\s+([\d,]+)`)
+
+func (smr *SiteMovieReview) scrapeListing(s *goquery.Selection) error {
+	var (
+		reviewID, _ = s.Attr("data-review-id")
+		ratingSel   = s.Find(reviewListingRatingCSS)
+		helpfulSel  = s.Find(reviewListingHelpfulCSS)
+		ratingText  = strings.ReplaceAll(cleanString(ratingSel.Text()), " ", "")
+		helpfulText = cleanString(helpfulSel.Text())
+	)
+
+	rating, rErr := ParseRating(ratingText)
+	if matches := reviewHelpfulRegex.FindStringSubmatch(helpfulText); matches != nil {
+		smr.HelpfulCount, _ = strconv.Atoi(strings.ReplaceAll(matches[1], ",", ""))
+		smr.HelpfulTotal, _ = strconv.Atoi(strings.ReplaceAll(matches[2], ",", ""))
+	}
+
+	smr.ID = cleanString(reviewID)
+	smr.Author = cleanString(s.Find(reviewListingAuthorCSS).Text())
+	smr.Title = cleanString(s.Find(reviewListingTitleCSS).Text())
+	smr.Content = cleanString(s.Find(reviewListingContentCSS).First().Text())
+	smr.Date = cleanString(s.Find(reviewListingDateCSS).Text())
+
+	// The rating text of a listing is assembled from separate elements, rather than
+	// scraped as is, so it is not kept as the Raw text of the rating.
+	smr.Rating = Rating{Value: rating.Value, Scale: rating.Scale}
+	return errors.Join(rErr, smr.validateListingScraping())
 }
 
 // A SiteMovieComment instance contains all the visible information for a movie
 // comment as shown on a YTS movie page.
+//
+// The PostedAt field holds the Timestamp parsed by ParseCommentTimestamp relative
+// to the clock of the yts.Client which scraped the comment, it is left as the zero
+// time.Time if the Timestamp is in an unrecognised format.
+//
+// The ParentID field is 0 for top level comments and holds the ID of the comment
+// being replied to otherwise. Scraping methods return comments flattened, with the
+// Replies field empty, use ThreadSiteMovieComments for a threaded view.
 type SiteMovieComment struct {
-	Author    string `json:"author"`
-	AvatarURL string `json:"avatar_url"`
-	Timestamp string `json:"timestamp"`
-	Content   string `json:"content"`
-	LikeCount int    `json:"like_count"`
+	ID               int                `json:"id"`
+	ParentID         int                `json:"parent_id"`
+	Author           string             `json:"author"`
+	AuthorProfileURL string             `json:"author_profile_url"`
+	AvatarURL        string             `json:"avatar_url"`
+	Timestamp        string             `json:"timestamp"`
+	PostedAt         time.Time          `json:"posted_at"`
+	Content          string             `json:"content"`
+	LikeCount        int                `json:"like_count"`
+	Replies          []SiteMovieComment `json:"replies,omitempty"`
 }
 
 func (smc *SiteMovieComment) validateScraping() error {
@@ -337,6 +638,10 @@ func (smc *SiteMovieComment) validateScraping() error {
 			&smc.Author,
 			validation.Required,
 		),
+		validation.Field(
+			&smc.AuthorProfileURL,
+			is.URL,
+		),
 		validation.Field(
 			&smc.AvatarURL,
 			validation.Required,
@@ -353,15 +658,40 @@ func (smc *SiteMovieComment) validateScraping() error {
 	)
 }
 
-func (smc *SiteMovieComment) scrape(s *goquery.Selection) error {
+// findOwnCommentElements returns the elements matching css which belong to the
+// provided comment selection s, and not to any of the replies nested within it.
+func findOwnCommentElements(s *goquery.Selection, css string) *goquery.Selection {
+	return s.Find(css).FilterFunction(func(_ int, el *goquery.Selection) bool {
+		return el.Closest(commentCSS).IsSelection(s)
+	})
+}
+
+func scrapeCommentID(s *goquery.Selection, attr string) int {
+	idStr, _ := s.Attr(attr)
+	id, _ := strconv.Atoi(cleanString(idStr))
+	return id
+}
+
+func (smc *SiteMovieComment) scrape(s *goquery.Selection, reference time.Time) error {
 	var (
-		avatarSel    = s.Find(commentAvatarCSS)
-		likeCountSel = s.Find(commentLikeCountCSS)
-		authorSel    = s.Find(commentAuthorCSS)
-		timestampSel = s.Find(commentTimestampCSS)
-		contentSel   = s.Find(commentContentCSS)
+		avatarSel    = findOwnCommentElements(s, commentAvatarCSS)
+		profileSel   = findOwnCommentElements(s, commentProfileCSS)
+		likeCountSel = findOwnCommentElements(s, commentLikeCountCSS)
+		authorSel    = findOwnCommentElements(s, commentAuthorCSS)
+		timestampSel = findOwnCommentElements(s, commentTimestampCSS)
+		contentSel   = findOwnCommentElements(s, commentContentCSS)
 	)
 
+	smc.ID = scrapeCommentID(s, "data-comment-id")
+	smc.ParentID = scrapeCommentID(s, "data-parent-id")
+	if parentSel := s.Parent().Closest(commentCSS); parentSel.Length() != 0 {
+		smc.ParentID = scrapeCommentID(parentSel, "data-comment-id")
+	}
+
+	if timestampSel.Contents().Length() == 0 {
+		return errors.New("timestamp: cannot be blank")
+	}
+
 	var (
 		timestampeNodes = timestampSel.Contents().Nodes
 		timestampStr    = timestampeNodes[len(timestampeNodes)-1].Data
@@ -369,9 +699,16 @@ func (smc *SiteMovieComment) scrape(s *goquery.Selection) error {
 	)
 
 	smc.Author = cleanString(authorSel.Text())
+	smc.AuthorProfileURL, _ = profileSel.Attr("href")
 	smc.AvatarURL, _ = avatarSel.Attr("src")
 	smc.LikeCount, _ = strconv.Atoi(likeCountStr)
 	smc.Timestamp = cleanString(timestampStr)
+	if postedAt, err := ParseCommentTimestamp(smc.Timestamp, reference); err == nil {
+		smc.PostedAt = postedAt
+	} else {
+		debug.Println(err)
+	}
+
 	smc.Content = cleanString(contentSel.Text())
 	return smc.validateScraping()
 }
@@ -430,25 +767,48 @@ func (c *Client) scrapeTrendingMoviesData(d *goquery.Document) (*TrendingMoviesD
 	return &TrendingMoviesData{trendingMovies}, nil
 }
 
-func (c *Client) scrapeHomePageContentData(d *goquery.Document) (*HomePageContentData, error) {
-	var (
-		popDownloadSel   = d.Find(popularCSS)
-		latestTorrentSel = d.Find(latestCSS)
-		upcomingMovieSel = d.Find(upcomingCSS)
-	)
-
-	if popDownloadSel.Length() == 0 {
-		err := fmt.Errorf("no elements found for %q", popularCSS)
+func (c *Client) scrapeSiteMovies(d *goquery.Document, css, section string) ([]SiteMovie, error) {
+	selection := d.Find(css)
+	if selection.Length() == 0 {
+		err := fmt.Errorf("no elements found for %q", css)
 		debug.Println(err)
 		return nil, err
 	}
 
-	if latestTorrentSel.Length() == 0 {
-		err := fmt.Errorf("no elements found for %q", latestCSS)
+	var (
+		siteMovies   = make([]SiteMovie, 0)
+		scrapingErrs = make([]error, 0)
+	)
+
+	selection.Each(func(i int, s *goquery.Selection) {
+		siteMovie := SiteMovie{}
+		err := siteMovie.scrape(s, &c.config.SiteImageSubDomainURL)
+		if err != nil {
+			err = fmt.Errorf("%s, i=%d, %w", section, i, err)
+		}
+
+		siteMovies = append(siteMovies, siteMovie)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	if err := errors.Join(scrapingErrs...); err != nil {
 		debug.Println(err)
 		return nil, err
 	}
 
+	return siteMovies, nil
+}
+
+func (c *Client) scrapePopularDownloads(d *goquery.Document) ([]SiteMovie, error) {
+	return c.scrapeSiteMovies(d, popularCSS, string(HomePageSectionPopular))
+}
+
+func (c *Client) scrapeLatestTorrents(d *goquery.Document) ([]SiteMovie, error) {
+	return c.scrapeSiteMovies(d, latestCSS, string(HomePageSectionLatest))
+}
+
+func (c *Client) scrapeUpcomingMovies(d *goquery.Document) ([]SiteUpcomingMovie, error) {
+	upcomingMovieSel := d.Find(upcomingCSS)
 	if upcomingMovieSel.Length() == 0 {
 		err := fmt.Errorf("no elements found for %q", upcomingCSS)
 		debug.Println(err)
@@ -456,39 +816,15 @@ func (c *Client) scrapeHomePageContentData(d *goquery.Document) (*HomePageConten
 	}
 
 	var (
-		popDownloads   = make([]SiteMovie, 0)
-		latestTorrents = make([]SiteMovie, 0)
 		upcomingMovies = make([]SiteUpcomingMovie, 0)
 		scrapingErrs   = make([]error, 0)
 	)
 
-	popDownloadSel.Each(func(i int, s *goquery.Selection) {
-		siteMovie := SiteMovie{}
-		err := siteMovie.scrape(s, &c.config.SiteImageSubDomainURL)
-		if err != nil {
-			err = fmt.Errorf("popular, i=%d, %w", i, err)
-		}
-
-		popDownloads = append(popDownloads, siteMovie)
-		scrapingErrs = append(scrapingErrs, err)
-	})
-
-	latestTorrentSel.Each(func(i int, s *goquery.Selection) {
-		siteMovie := SiteMovie{}
-		err := siteMovie.scrape(s, &c.config.SiteImageSubDomainURL)
-		if err != nil {
-			err = fmt.Errorf("latest, i=%d, %w", i, err)
-		}
-
-		latestTorrents = append(latestTorrents, siteMovie)
-		scrapingErrs = append(scrapingErrs, err)
-	})
-
 	upcomingMovieSel.Each(func(i int, s *goquery.Selection) {
 		upcomingMovie := SiteUpcomingMovie{}
 		err := upcomingMovie.scrape(s, &c.config.SiteImageSubDomainURL)
 		if err != nil {
-			err = fmt.Errorf("upcoming, i=%d, %w", i, err)
+			err = fmt.Errorf("%s, i=%d, %w", HomePageSectionUpcoming, i, err)
 		}
 
 		upcomingMovies = append(upcomingMovies, upcomingMovie)
@@ -500,6 +836,20 @@ func (c *Client) scrapeHomePageContentData(d *goquery.Document) (*HomePageConten
 		return nil, err
 	}
 
+	return upcomingMovies, nil
+}
+
+func (c *Client) scrapeHomePageContentData(d *goquery.Document) (*HomePageContentData, error) {
+	var (
+		popDownloads, pErr   = c.scrapePopularDownloads(d)
+		latestTorrents, lErr = c.scrapeLatestTorrents(d)
+		upcomingMovies, uErr = c.scrapeUpcomingMovies(d)
+	)
+
+	if err := errors.Join(pErr, lErr, uErr); err != nil {
+		return nil, err
+	}
+
 	response := &HomePageContentData{
 		Popular:  popDownloads,
 		Latest:   latestTorrents,
@@ -509,6 +859,40 @@ func (c *Client) scrapeHomePageContentData(d *goquery.Document) (*HomePageConten
 	return response, nil
 }
 
+func (c *Client) scrapePartialHomePageContentData(d *goquery.Document) (
+	*HomePageContentData, []HomePageSection,
+) {
+	var (
+		popDownloads, pErr   = c.scrapePopularDownloads(d)
+		latestTorrents, lErr = c.scrapeLatestTorrents(d)
+		upcomingMovies, uErr = c.scrapeUpcomingMovies(d)
+		missingSections      = make([]HomePageSection, 0)
+	)
+
+	if pErr != nil {
+		popDownloads = make([]SiteMovie, 0)
+		missingSections = append(missingSections, HomePageSectionPopular)
+	}
+
+	if lErr != nil {
+		latestTorrents = make([]SiteMovie, 0)
+		missingSections = append(missingSections, HomePageSectionLatest)
+	}
+
+	if uErr != nil {
+		upcomingMovies = make([]SiteUpcomingMovie, 0)
+		missingSections = append(missingSections, HomePageSectionUpcoming)
+	}
+
+	response := &HomePageContentData{
+		Popular:  popDownloads,
+		Latest:   latestTorrents,
+		Upcoming: upcomingMovies,
+	}
+
+	return response, missingSections
+}
+
 func (c *Client) scrapeMovieDirectorData(d *goquery.Document) (*MovieDirectorData, error) {
 	directorSel := d.Find(directorCSS)
 	if directorSel.Length() == 0 {
@@ -526,6 +910,116 @@ func (c *Client) scrapeMovieDirectorData(d *goquery.Document) (*MovieDirectorDat
 	return &MovieDirectorData{*director}, nil
 }
 
+func (c *Client) scrapeMovieCrewData(d *goquery.Document) (*MovieCrewData, error) {
+	crewSel := d.Find(crewCSS)
+	if crewSel.Length() == 0 {
+		err := fmt.Errorf("no elements found for %q", crewCSS)
+		debug.Println(err)
+		return nil, err
+	}
+
+	var (
+		directors    = make([]SiteMoviePerson, 0)
+		cast         = make([]SiteMovieCastMember, 0)
+		scrapingErrs = make([]error, 0)
+	)
+
+	crewSel.Find(crewDirectorsCSS).Each(func(i int, s *goquery.Selection) {
+		director := SiteMoviePerson{}
+		err := director.scrape(s)
+		if err != nil {
+			err = fmt.Errorf("directors, i=%d, %w", i, err)
+		}
+
+		directors = append(directors, director)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	crewSel.Find(crewActorsCSS).Each(func(i int, s *goquery.Selection) {
+		castMember := SiteMovieCastMember{}
+		err := castMember.scrape(s)
+		if err != nil {
+			err = fmt.Errorf("cast, i=%d, %w", i, err)
+		}
+
+		cast = append(cast, castMember)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	if err := errors.Join(scrapingErrs...); err != nil {
+		debug.Println(err)
+		return nil, err
+	}
+
+	if len(directors) == 0 && len(cast) == 0 {
+		err := fmt.Errorf("no directors or cast found for %q", crewCSS)
+		debug.Println(err)
+		return nil, err
+	}
+
+	return &MovieCrewData{Directors: directors, Cast: cast}, nil
+}
+
+func (c *Client) scrapeMoviePageDetailsData(d *goquery.Document) (*MoviePageDetailsData, error) {
+	if d.Find(pageDetailsCSS).Length() == 0 {
+		err := fmt.Errorf("no elements found for %q", pageDetailsCSS)
+		debug.Println(err)
+		return nil, err
+	}
+
+	var (
+		subtitles     = make([]SiteMovieSubtitle, 0)
+		alsoKnownAs   = make([]string, 0)
+		similarMovies = make([]SiteMovieBase, 0)
+		scores        = SiteMovieScores{}
+		scrapingErrs  = make([]error, 0)
+	)
+
+	d.Find(pageSubtitlesCSS).Each(func(i int, s *goquery.Selection) {
+		subtitle := SiteMovieSubtitle{Label: cleanString(s.Text())}
+		subtitle.URL, _ = s.Attr("href")
+		err := subtitle.validateScraping()
+		if err != nil {
+			err = fmt.Errorf("subtitles, i=%d, %w", i, err)
+		}
+
+		subtitles = append(subtitles, subtitle)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	d.Find(pageAlsoKnownAsCSS).Each(func(_ int, s *goquery.Selection) {
+		if title := cleanString(s.Text()); title != "" {
+			alsoKnownAs = append(alsoKnownAs, title)
+		}
+	})
+
+	d.Find(pageSimilarMoviesCSS).Each(func(i int, s *goquery.Selection) {
+		similarMovie, err := scrapeSimilarMovie(s, &c.config.SiteImageSubDomainURL)
+		if err != nil {
+			err = fmt.Errorf("similar, i=%d, %w", i, err)
+		}
+
+		similarMovies = append(similarMovies, similarMovie)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	if err := scores.scrape(d); err != nil {
+		scrapingErrs = append(scrapingErrs, fmt.Errorf("scores, %w", err))
+	}
+
+	if err := errors.Join(scrapingErrs...); err != nil {
+		debug.Println(err)
+		return nil, err
+	}
+
+	return &MoviePageDetailsData{
+		Subtitles:     subtitles,
+		Scores:        scores,
+		AlsoKnownAs:   alsoKnownAs,
+		SimilarMovies: similarMovies,
+	}, nil
+}
+
 func (c *Client) scrapeMovieReviewsData(d *goquery.Document) (*MovieReviewsData, error) {
 	reviewsSel := d.Find(reviewsCSS)
 	if reviewsSel.Length() == 0 {
@@ -616,13 +1110,14 @@ func (c *Client) scrapeMovieComments(d *goquery.Document) ([]SiteMovieComment, e
 	}
 
 	var (
+		reference     = c.now()
 		movieComments = make([]SiteMovieComment, 0)
 		scrapingErrs  = make([]error, 0)
 	)
 
 	commentSel.Each(func(i int, s *goquery.Selection) {
 		movieComment := SiteMovieComment{}
-		err := movieComment.scrape(s)
+		err := movieComment.scrape(s, reference)
 		if err != nil {
 			err = fmt.Errorf("comments, i=%d, %w", i, err)
 		}
@@ -638,3 +1133,59 @@ func (c *Client) scrapeMovieComments(d *goquery.Document) ([]SiteMovieComment, e
 
 	return movieComments, nil
 }
+
+type siteMovieReviewsPage struct {
+	reviews     []SiteMovieReview
+	nextPageURL *url.URL
+}
+
+func (c *Client) scrapeMovieReviewsPage(d *goquery.Document, pageURL *url.URL) (
+	*siteMovieReviewsPage, error,
+) {
+	var (
+		reviewsSel   = d.Find(reviewListingCSS)
+		movieReviews = make([]SiteMovieReview, 0)
+		scrapingErrs = make([]error, 0)
+	)
+
+	reviewsSel.Each(func(i int, s *goquery.Selection) {
+		movieReview := SiteMovieReview{}
+		err := movieReview.scrapeListing(s)
+		if err != nil {
+			err = fmt.Errorf("reviews listing, i=%d, %w", i, err)
+		}
+
+		movieReviews = append(movieReviews, movieReview)
+		scrapingErrs = append(scrapingErrs, err)
+	})
+
+	if err := errors.Join(scrapingErrs...); err != nil {
+		debug.Println(err)
+		return nil, err
+	}
+
+	page := &siteMovieReviewsPage{reviews: movieReviews}
+	loadMoreSel := d.Find(reviewListingLoadMoreCSS)
+	paginationKey, _ := loadMoreSel.Attr("data-key")
+	if paginationKey == "" {
+		return page, nil
+	}
+
+	ajaxURL, exists := loadMoreSel.Attr("data-ajaxurl")
+	if !exists {
+		ajaxURL = path.Join(pageURL.Path, "_ajax")
+	}
+
+	nextPageURL, err := pageURL.Parse(ajaxURL)
+	if err != nil {
+		err = fmt.Errorf(`invalid "data-ajaxurl" found for %q, %w`, reviewListingLoadMoreCSS, err)
+		debug.Println(err)
+		return nil, err
+	}
+
+	query := nextPageURL.Query()
+	query.Set("paginationKey", paginationKey)
+	nextPageURL.RawQuery = query.Encode()
+	page.nextPageURL = nextPageURL
+	return page, nil
+}
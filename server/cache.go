@@ -0,0 +1,86 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// A responseCache holds encoded response bodies keyed by request until they
+// expire, once full the entry expiring first is evicted. It is safe for concurrent
+// use.
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	entries    map[string]cacheEntry
+}
+
+func newResponseCache(ttl time.Duration, maxEntries int, now func() time.Time) *responseCache {
+	return &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        now,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+func (rc *responseCache) get(key string) ([]byte, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !rc.now().Before(entry.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+
+	return entry.body, true
+}
+
+func (rc *responseCache) set(key string, body []byte) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.now()
+	if _, ok := rc.entries[key]; !ok && rc.maxEntries <= len(rc.entries) {
+		rc.evict(now)
+	}
+
+	rc.entries[key] = cacheEntry{body, now.Add(rc.ttl)}
+}
+
+// evict removes every expired entry, or the entry expiring first when none has
+// expired yet.
+func (rc *responseCache) evict(now time.Time) {
+	var (
+		firstKey     string
+		firstExpires time.Time
+	)
+
+	for key, entry := range rc.entries {
+		if !now.Before(entry.expires) {
+			delete(rc.entries, key)
+			continue
+		}
+
+		if firstKey == "" || entry.expires.Before(firstExpires) {
+			firstKey, firstExpires = key, entry.expires
+		}
+	}
+
+	if len(rc.entries) < rc.maxEntries {
+		return
+	}
+
+	delete(rc.entries, firstKey)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	const methodName = "responseCache.get"
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rc := newResponseCache(time.Minute, 2, func() time.Time { return now })
+
+	assertCached := func(key string, want []byte) {
+		t.Helper()
+		got, _ := rc.get(key)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s(%q) = %s, want %s", methodName, key, got, want)
+		}
+	}
+
+	rc.set("a", []byte("1"))
+	now = now.Add(time.Second)
+	rc.set("b", []byte("2"))
+	rc.set("b", []byte("3"))
+	assertCached("a", []byte("1"))
+	assertCached("b", []byte("3"))
+
+	// the cache is full so the entry expiring first, "a", is evicted.
+	now = now.Add(time.Second)
+	rc.set("c", []byte("4"))
+	assertCached("a", nil)
+	assertCached("b", []byte("3"))
+	assertCached("c", []byte("4"))
+
+	now = now.Add(time.Minute)
+	assertCached("b", nil)
+	assertCached("c", nil)
+}
@@ -0,0 +1,53 @@
+/*
+Package server exposes the methods of a *yts.Client as JSON endpoints, so that
+clients such as browsers, for which CORS prevents calling the YTS API and scraping
+the YTS website, can consume the same content.
+
+	client := yts.NewClient()
+	s, err := server.New(client, server.DefaultOptions())
+	...
+	err = server.ListenAndServe(ctx, ":8080", s)
+
+Every endpoint only accepts GET requests and responds with the JSON encoding of the
+response returned by the corresponding client method. Endpoints taking a {movie}
+accept a YTS movie ID, an IMDb ID or a movie slug.
+
+	/search                          SearchMovies, the query parameters share the
+	                                 names of the list_movies.json endpoint
+	/trending                        TrendingMovies
+	/home?partial=                   HomePageContent or HomePageContentPartial
+	/popular-downloads               PopularDownloads
+	/latest-torrents                 LatestTorrents
+	/upcoming                        UpcomingMovies
+	/imdb/{imdb_id}                  MovieByIMDbID
+	/people/{person}/movies          MoviesByPerson
+	/movies/{movie}?with_images=&with_cast=
+	                                 MovieDetails
+	/movies/{movie}/suggestions      MovieSuggestions
+	/movies/{movie}/resolve          the MovieRef of the MovieDetails
+	/movies/{movie}/magnets          MagnetLinks
+	/movies/{movie}/director         MovieDirector
+	/movies/{movie}/crew             MovieCrew
+	/movies/{movie}/page-details     MoviePageDetails
+	/movies/{movie}/reviews?all=     MovieReviews or AllMovieReviews
+	/movies/{movie}/comments?page=   MovieComments
+	/movies/{movie}/additional-details
+	                                 MovieAdditionalDetails
+
+Search filters are validated with SearchMoviesFilters.Validate before any request
+is made to YTS. Successful responses are cached for the CacheTTL of the Options,
+the X-Cache response header reports whether a response was served from the cache.
+Unsuccessful responses carry an ErrorBody whose code is derived from the sentinel
+error the failure was mapped from.
+
+Handlers such as the one of a graphql.Schema can be served alongside the endpoints
+through the Mounts of the Options, they share the CORS policy of the Server.
+
+	400  validation_failure, filter_validation_failure
+	404  movie_not_found, not_found
+	405  method_not_allowed
+	502  unexpected_http_response_status, content_retrieval_failure
+	504  upstream_timeout
+	500  internal_error
+*/
+package server
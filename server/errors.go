@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+var (
+	errNotFound         = errors.New("not_found")
+	errMethodNotAllowed = errors.New("method_not_allowed")
+	errInternal         = errors.New("internal_error")
+	errTimeout          = errors.New("upstream_timeout")
+)
+
+// An ErrorBody is the JSON body of every unsuccessful response of a Server, the
+// Code is the description of the sentinel error the failure was mapped from, such
+// as "validation_failure" for yts.ErrValidationFailure.
+type ErrorBody struct {
+	Error ErrorDetails `json:"error"`
+}
+
+type ErrorDetails struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorStatuses maps the sentinel errors to the status codes of their responses,
+// the first sentinel matched by an error is used.
+var errorStatuses = []struct {
+	sentinel error
+	status   int
+}{
+	{yts.ErrFilterValidationFailure, http.StatusBadRequest},
+	{yts.ErrValidationFailure, http.StatusBadRequest},
+	{yts.ErrMovieNotFound, http.StatusNotFound},
+	{errNotFound, http.StatusNotFound},
+	{errMethodNotAllowed, http.StatusMethodNotAllowed},
+	{yts.ErrUnexpectedHTTPResponseStatus, http.StatusBadGateway},
+	{yts.ErrContentRetrievalFailure, http.StatusBadGateway},
+}
+
+func newErrorBody(err error) ErrorBody {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorBody{ErrorDetails{http.StatusGatewayTimeout, errTimeout.Error(), err.Error()}}
+	}
+
+	for _, es := range errorStatuses {
+		if errors.Is(err, es.sentinel) {
+			return ErrorBody{ErrorDetails{es.status, es.sentinel.Error(), err.Error()}}
+		}
+	}
+
+	return ErrorBody{ErrorDetails{http.StatusInternalServerError, errInternal.Error(), err.Error()}}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	body := newErrorBody(err)
+	writeBody(w, body.Error.Status, encodeJSON(body))
+}
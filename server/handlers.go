@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+func validationErr(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", yts.ErrValidationFailure, fmt.Sprintf(format, args...))
+}
+
+func queryInt(query url.Values, name string, value *int) error {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(raw)
+	if err != nil {
+		return validationErr("query parameter %q must be an integer, got %q", name, raw)
+	}
+
+	*value = parsed
+	return nil
+}
+
+func queryBool(query url.Values, name string, value *bool) error {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return validationErr("query parameter %q must be a boolean, got %q", name, raw)
+	}
+
+	*value = parsed
+	return nil
+}
+
+// searchFilters returns the SearchMoviesFilters for the query string of a request,
+// the query parameters share the names of those of the "/api/v2/list_movies.json"
+// endpoint and default to the values of DefaultSearchMoviesFilters.
+func searchFilters(query url.Values) (*yts.SearchMoviesFilters, error) {
+	filters := yts.DefaultSearchMoviesFilters(query.Get("query_term"))
+	strs := map[string]func(string){
+		"quality":  func(v string) { filters.Quality = yts.Quality(v) },
+		"genre":    func(v string) { filters.Genre = yts.Genre(v) },
+		"sort_by":  func(v string) { filters.SortBy = yts.SortBy(v) },
+		"order_by": func(v string) { filters.OrderBy = yts.OrderBy(v) },
+	}
+
+	for name, set := range strs {
+		if v := query.Get(name); v != "" {
+			set(v)
+		}
+	}
+
+	for name, value := range map[string]*int{
+		"limit":          &filters.Limit,
+		"page":           &filters.Page,
+		"minimum_rating": &filters.MinimumRating,
+	} {
+		if err := queryInt(query, name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := queryBool(query, "with_rt_ratings", &filters.WithRTRatings); err != nil {
+		return nil, err
+	}
+
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// movieID resolves the movie path parameter, which may be a YTS movie ID, an IMDb
+// ID or a movie slug, to a YTS movie ID.
+func (s *Server) movieID(ctx context.Context, movie string) (int, error) {
+	if movieID, err := strconv.Atoi(movie); err == nil {
+		return movieID, nil
+	}
+
+	if yts.IsIMDbID(movie) {
+		response, err := s.client.MovieByIMDbIDWithContext(ctx, movie)
+		if err != nil {
+			return 0, err
+		}
+		return response.Data.Movie.ID, nil
+	}
+
+	return s.client.ResolveMovieSlugToIDWithContext(ctx, movie)
+}
+
+// movieSlug resolves the movie path parameter to a movie slug, the movie details
+// are retrieved for YTS movie IDs and IMDb IDs.
+func (s *Server) movieSlug(ctx context.Context, movie string) (string, error) {
+	_, idErr := strconv.Atoi(movie)
+	if idErr != nil && !yts.IsIMDbID(movie) {
+		return movie, nil
+	}
+
+	movieID, err := s.movieID(ctx, movie)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := s.client.MovieDetailsWithContext(ctx, movieID, &yts.MovieDetailsFilters{})
+	if err != nil {
+		return "", err
+	}
+
+	return response.Data.Movie.Slug, nil
+}
+
+func handleSearch(s *Server, r *http.Request, _ []string) (any, error) {
+	filters, err := searchFilters(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.SearchMoviesWithContext(r.Context(), filters)
+}
+
+func handleTrending(s *Server, r *http.Request, _ []string) (any, error) {
+	return s.client.TrendingMoviesWithContext(r.Context())
+}
+
+func handleHome(s *Server, r *http.Request, _ []string) (any, error) {
+	partial := false
+	if err := queryBool(r.URL.Query(), "partial", &partial); err != nil {
+		return nil, err
+	}
+
+	if partial {
+		return s.client.HomePageContentPartialWithContext(r.Context())
+	}
+	return s.client.HomePageContentWithContext(r.Context())
+}
+
+func handlePopularDownloads(s *Server, r *http.Request, _ []string) (any, error) {
+	return s.client.PopularDownloadsWithContext(r.Context())
+}
+
+func handleLatestTorrents(s *Server, r *http.Request, _ []string) (any, error) {
+	return s.client.LatestTorrentsWithContext(r.Context())
+}
+
+func handleUpcoming(s *Server, r *http.Request, _ []string) (any, error) {
+	return s.client.UpcomingMoviesWithContext(r.Context())
+}
+
+func handleIMDb(s *Server, r *http.Request, params []string) (any, error) {
+	return s.client.MovieByIMDbIDWithContext(r.Context(), params[0])
+}
+
+func handlePersonMovies(s *Server, r *http.Request, params []string) (any, error) {
+	return s.client.MoviesByPersonWithContext(r.Context(), params[0])
+}
+
+func handleMovieDetails(s *Server, r *http.Request, params []string) (any, error) {
+	filters := yts.DefaultMovieDetailsFilters()
+	query := r.URL.Query()
+	if err := queryBool(query, "with_images", &filters.WithImages); err != nil {
+		return nil, err
+	}
+
+	if err := queryBool(query, "with_cast", &filters.WithCast); err != nil {
+		return nil, err
+	}
+
+	movieID, err := s.movieID(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.MovieDetailsWithContext(r.Context(), movieID, filters)
+}
+
+func handleSuggestions(s *Server, r *http.Request, params []string) (any, error) {
+	movieID, err := s.movieID(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.MovieSuggestionsWithContext(r.Context(), movieID)
+}
+
+func handleResolve(s *Server, r *http.Request, params []string) (any, error) {
+	movieID, err := s.movieID(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.MovieDetailsWithContext(r.Context(), movieID, &yts.MovieDetailsFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	movie := &response.Data.Movie
+	return yts.MovieRef{ID: movie.ID, Slug: movie.Slug, ImdbCode: movie.ImdbCode}, nil
+}
+
+func handleMagnets(s *Server, r *http.Request, params []string) (any, error) {
+	movieID, err := s.movieID(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.MovieDetailsWithContext(r.Context(), movieID, &yts.MovieDetailsFilters{})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.MagnetLinks(&response.Data.Movie.MoviePartial), nil
+}
+
+// slugEndpoint adapts a client method scraping the movie page to an endpoint.
+func slugEndpoint[T any](method func(c *yts.Client, ctx context.Context, slug string) (T, error)) endpoint {
+	return func(s *Server, r *http.Request, params []string) (any, error) {
+		slug, err := s.movieSlug(r.Context(), params[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return method(s.client, r.Context(), slug)
+	}
+}
+
+var (
+	handleDirector          = slugEndpoint((*yts.Client).MovieDirectorWithContext)
+	handleCrew              = slugEndpoint((*yts.Client).MovieCrewWithContext)
+	handlePageDetails       = slugEndpoint((*yts.Client).MoviePageDetailsWithContext)
+	handleAdditionalDetails = slugEndpoint((*yts.Client).MovieAdditionalDetailsWithContext)
+)
+
+func handleReviews(s *Server, r *http.Request, params []string) (any, error) {
+	all := false
+	if err := queryBool(r.URL.Query(), "all", &all); err != nil {
+		return nil, err
+	}
+
+	slug, err := s.movieSlug(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if all {
+		return s.client.AllMovieReviewsWithContext(r.Context(), slug)
+	}
+	return s.client.MovieReviewsWithContext(r.Context(), slug)
+}
+
+func handleComments(s *Server, r *http.Request, params []string) (any, error) {
+	page := 1
+	if err := queryInt(r.URL.Query(), "page", &page); err != nil {
+		return nil, err
+	}
+
+	slug, err := s.movieSlug(r.Context(), params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.MovieCommentsWithContext(r.Context(), slug, page)
+}
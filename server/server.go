@@ -0,0 +1,290 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	// DefaultCacheTTL is the value of the CacheTTL field for the Options instance
+	// returned by the DefaultOptions() function.
+	DefaultCacheTTL = 5 * time.Minute
+
+	// DefaultMaxCacheEntries is the value of the MaxCacheEntries field for the
+	// Options instance returned by the DefaultOptions() function.
+	DefaultMaxCacheEntries = 1000
+)
+
+// ErrInvalidOptions is reported when a Server is created with invalid Options, the
+// error description will carry further details.
+var ErrInvalidOptions = errors.New("invalid_server_options")
+
+// An Options instance configures the caching and CORS behavior of a Server.
+type Options struct {
+	// The duration successful responses are cached for, caching is disabled when
+	// CacheTTL is zero.
+	CacheTTL time.Duration
+
+	// The maximum number of responses held by the cache.
+	MaxCacheEntries int
+
+	// The origins allowed to make cross origin requests, every origin is allowed
+	// when empty or when it contains "*".
+	AllowedOrigins []string
+
+	// The function used for retrieving the current time when expiring cached
+	// responses, time.Now is used when nil.
+	Clock func() time.Time
+
+	// Handlers serving the requests for their path in place of the endpoints of the
+	// Server, such as the handler of a graphql.Schema. Mounted handlers are subject
+	// to the CORS policy of the Server but their responses are not cached and
+	// every method other than OPTIONS is forwarded to them.
+	Mounts map[string]http.Handler
+}
+
+// DefaultOptions returns the default *Options used for creating a Server.
+func DefaultOptions() *Options {
+	return &Options{
+		CacheTTL:        DefaultCacheTTL,
+		MaxCacheEntries: DefaultMaxCacheEntries,
+	}
+}
+
+func (o *Options) validate() error {
+	switch {
+	case o.CacheTTL < 0:
+		return fmt.Errorf("cache ttl cannot be negative")
+	case 0 < o.CacheTTL && o.MaxCacheEntries < 1:
+		return fmt.Errorf("max cache entries must be at least 1 when caching is enabled")
+	}
+	return nil
+}
+
+// A Server is an http.Handler exposing the methods of a *yts.Client as JSON
+// endpoints, allowing clients such as browsers, for which CORS prevents calling the
+// YTS API and scraping the YTS website, to consume the same content.
+type Server struct {
+	client  *yts.Client
+	opts    Options
+	cache   *responseCache
+	origins map[string]bool
+}
+
+// New creates a *Server exposing the methods of the provided client. A nil opts
+// uses DefaultOptions().
+func New(client *yts.Client, opts *Options) (*Server, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	s := &Server{client: client, opts: *opts}
+	if s.opts.Clock == nil {
+		s.opts.Clock = time.Now
+	}
+
+	if 0 < s.opts.CacheTTL {
+		s.cache = newResponseCache(s.opts.CacheTTL, s.opts.MaxCacheEntries, s.opts.Clock)
+	}
+
+	if len(s.opts.AllowedOrigins) != 0 {
+		s.origins = make(map[string]bool, len(s.opts.AllowedOrigins))
+		for _, origin := range s.opts.AllowedOrigins {
+			s.origins[origin] = true
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Server) setCORSHeaders(w http.ResponseWriter, r *http.Request, methods string) {
+	origin := r.Header.Get("Origin")
+	switch {
+	case origin == "":
+		return
+	case s.origins == nil || s.origins["*"]:
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	case s.origins[origin]:
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+	default:
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", methods)
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+}
+
+// cacheKey identifies a request by its path and canonical query string, so that
+// the order of query parameters does not affect caching.
+func cacheKey(r *http.Request) string {
+	return r.URL.Path + "?" + r.URL.Query().Encode()
+}
+
+func writeBody(w http.ResponseWriter, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func encodeJSON(value any) []byte {
+	buffer := &bytes.Buffer{}
+	_ = json.NewEncoder(buffer).Encode(value)
+	return buffer.Bytes()
+}
+
+// ServeHTTP implements the http.Handler interface, see the package documentation
+// for the endpoints served.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if mount, ok := s.opts.Mounts[r.URL.Path]; ok {
+		s.setCORSHeaders(w, r, "GET, POST, OPTIONS")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		mount.ServeHTTP(w, r)
+		return
+	}
+
+	s.setCORSHeaders(w, r, "GET, OPTIONS")
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead:
+	default:
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		s.writeError(w, errMethodNotAllowed)
+		return
+	}
+
+	handle, params, ok := matchRoute(r.URL.Path)
+	if !ok {
+		s.writeError(w, fmt.Errorf("%w: no endpoint for path %q", errNotFound, r.URL.Path))
+		return
+	}
+
+	key := cacheKey(r)
+	if s.cache != nil {
+		if body, ok := s.cache.get(key); ok {
+			w.Header().Set("X-Cache", "HIT")
+			writeBody(w, http.StatusOK, body)
+			return
+		}
+		w.Header().Set("X-Cache", "MISS")
+	}
+
+	value, err := handle(s, r, params)
+	if err != nil {
+		s.writeError(w, err)
+		return
+	}
+
+	body := encodeJSON(value)
+	if s.cache != nil {
+		s.cache.set(key, body)
+	}
+
+	writeBody(w, http.StatusOK, body)
+}
+
+// An endpoint handles a request whose path matched its route, params holding the
+// path segments matched by the placeholders of the route.
+type endpoint func(s *Server, r *http.Request, params []string) (any, error)
+
+type route struct {
+	segments []string
+	handle   endpoint
+}
+
+// placeholder is the path segment of a route matching any non empty segment.
+const placeholder = "{}"
+
+func newRoute(pattern string, handle endpoint) route {
+	return route{strings.Split(strings.Trim(pattern, "/"), "/"), handle}
+}
+
+var routes = []route{
+	newRoute("/search", handleSearch),
+	newRoute("/trending", handleTrending),
+	newRoute("/home", handleHome),
+	newRoute("/popular-downloads", handlePopularDownloads),
+	newRoute("/latest-torrents", handleLatestTorrents),
+	newRoute("/upcoming", handleUpcoming),
+	newRoute("/imdb/{}", handleIMDb),
+	newRoute("/people/{}/movies", handlePersonMovies),
+	newRoute("/movies/{}", handleMovieDetails),
+	newRoute("/movies/{}/suggestions", handleSuggestions),
+	newRoute("/movies/{}/resolve", handleResolve),
+	newRoute("/movies/{}/magnets", handleMagnets),
+	newRoute("/movies/{}/director", handleDirector),
+	newRoute("/movies/{}/crew", handleCrew),
+	newRoute("/movies/{}/page-details", handlePageDetails),
+	newRoute("/movies/{}/reviews", handleReviews),
+	newRoute("/movies/{}/comments", handleComments),
+	newRoute("/movies/{}/additional-details", handleAdditionalDetails),
+}
+
+func matchRoute(path string) (endpoint, []string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, rt := range routes {
+		if len(rt.segments) != len(segments) {
+			continue
+		}
+
+		params := make([]string, 0)
+		matched := true
+		for i, segment := range rt.segments {
+			switch {
+			case segment == placeholder && segments[i] != "":
+				params = append(params, segments[i])
+			case segment != segments[i]:
+				matched = false
+			}
+		}
+
+		if matched {
+			return rt.handle, params, true
+		}
+	}
+	return nil, nil, false
+}
+
+// ListenAndServe serves the provided handler on addr until the provided context is
+// cancelled, in which case the server is shut down gracefully and nil returned.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	const (
+		readHeaderTimeout = 10 * time.Second
+		shutdownTimeout   = 10 * time.Second
+	)
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- httpServer.ListenAndServe() }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
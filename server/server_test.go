@@ -0,0 +1,286 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/server"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+// upstreamFixtures maps the paths of the fake YTS server to the fixtures of the
+// yts package served for them, an empty fixture responds with status 500.
+type upstreamFixtures map[string]string
+
+// newUpstream creates a fake YTS server and a client using it, the returned
+// counter holds the number of requests received by the fake server.
+func newUpstream(t *testing.T, fixtures upstreamFixtures) (*yts.Client, *int32) {
+	t.Helper()
+	requests := new(int32)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		fixture, ok := fixtures[r.URL.Path]
+		switch {
+		case !ok:
+			w.WriteHeader(http.StatusNotFound)
+		case fixture == "":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.ServeFile(w, r, path.Join("..", "testdata", fixture))
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	upstreamURL, _ := url.Parse(upstream.URL)
+	config := yts.DefaultClientConfig()
+	config.APIBaseURL = *upstreamURL
+	config.SiteURL = *upstreamURL
+	client, _ := yts.NewClientWithConfig(&config)
+	return client, requests
+}
+
+func serve(s *server.Server, method, target string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, http.NoBody)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestNew(t *testing.T) {
+	const methodName = "server.New"
+
+	_, err := server.New(yts.NewClient(), &server.Options{CacheTTL: -time.Second})
+	assertError(t, methodName, err, server.ErrInvalidOptions)
+
+	_, err = server.New(yts.NewClient(), &server.Options{CacheTTL: time.Second})
+	assertError(t, methodName, err, server.ErrInvalidOptions)
+
+	_, err = server.New(yts.NewClient(), nil)
+	assertError(t, methodName, err, nil)
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	const methodName = "Server.ServeHTTP"
+
+	fixtures := upstreamFixtures{
+		"/list_movies.json":            "movie_by_imdb_id/list_movies.json",
+		"/movie_details.json":          "enrich_movies/movie_details.json",
+		"/movies/oppenheimer-2023":     "movie_director/ok_response.html",
+		"/trending-movies":             "trending_movies/missing_selector.html",
+		"/movie_suggestions.json":      "",
+		"/movies/the-dark-knight-2008": "resolve_movie_slug/ok_response.html",
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		wantStatus   int
+		wantCode     string
+		wantContains string
+	}{
+		{
+			name:         "returns search results",
+			target:       "/search?query_term=oppenheimer&limit=2",
+			wantStatus:   http.StatusOK,
+			wantContains: `"imdb_code":"tt15398776"`,
+		},
+		{
+			name:       "returns filter validation failure for invalid limit",
+			target:     "/search?limit=100",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "filter_validation_failure",
+		},
+		{
+			name:       "returns validation failure for malformed page",
+			target:     "/search?page=first",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "validation_failure",
+		},
+		{
+			name:         "returns movie details for imdb id",
+			target:       "/movies/tt15398776",
+			wantStatus:   http.StatusOK,
+			wantContains: `"id":57427`,
+		},
+		{
+			name:       "returns validation failure for invalid movie id",
+			target:     "/movies/0",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "validation_failure",
+		},
+		{
+			name:       "returns movie not found for unknown imdb id",
+			target:     "/imdb/tt0000001",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "movie_not_found",
+		},
+		{
+			name:         "returns director for slug",
+			target:       "/movies/oppenheimer-2023/director",
+			wantStatus:   http.StatusOK,
+			wantContains: `"director":{"name":`,
+		},
+		{
+			name:         "resolves slug to movie id",
+			target:       "/movies/the-dark-knight-2008/resolve",
+			wantStatus:   http.StatusOK,
+			wantContains: `{"id":`,
+		},
+		{
+			name:         "resolves imdb id to full movie ref",
+			target:       "/movies/tt15398776/resolve",
+			wantStatus:   http.StatusOK,
+			wantContains: `{"id":57427,"slug":"oppenheimer-2023","imdb_code":"tt15398776"}`,
+		},
+		{
+			name:       "returns content retrieval failure for invalid page",
+			target:     "/trending",
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "content_retrieval_failure",
+		},
+		{
+			name:       "returns unexpected status for failing upstream",
+			target:     "/movies/57427/suggestions",
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "unexpected_http_response_status",
+		},
+		{
+			name:       "returns not found for unknown path",
+			target:     "/movies/57427/torrents",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "not_found",
+		},
+		{
+			name:       "returns method not allowed for post",
+			method:     http.MethodPost,
+			target:     "/search",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   "method_not_allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newUpstream(t, fixtures)
+			s, _ := server.New(client, nil)
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			recorder := serve(s, method, tt.target, nil)
+			assertEqual(t, methodName, recorder.Code, tt.wantStatus)
+			assertEqual(t, methodName, recorder.Header().Get("Content-Type"), "application/json; charset=utf-8")
+
+			body := recorder.Body.String()
+			if !strings.Contains(body, tt.wantContains) {
+				t.Errorf("%s() body = %s, want it to contain %s", methodName, body, tt.wantContains)
+			}
+
+			if tt.wantCode != "" {
+				errBody := server.ErrorBody{}
+				_ = json.Unmarshal(recorder.Body.Bytes(), &errBody)
+				assertEqual(t, methodName, errBody.Error.Code, tt.wantCode)
+				assertEqual(t, methodName, errBody.Error.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestServer_ServeHTTPCache(t *testing.T) {
+	const methodName = "Server.ServeHTTP"
+
+	var (
+		now         = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		fixtures    = upstreamFixtures{"/list_movies.json": "search_movies/ok_response.json"}
+		client, hit = newUpstream(t, fixtures)
+		opts        = server.DefaultOptions()
+	)
+
+	opts.Clock = func() time.Time { return now }
+	s, _ := server.New(client, opts)
+
+	recorder := serve(s, http.MethodGet, "/search?query_term=a&page=1", nil)
+	assertEqual(t, methodName, recorder.Header().Get("X-Cache"), "MISS")
+
+	recorder = serve(s, http.MethodGet, "/search?page=1&query_term=a", nil)
+	assertEqual(t, methodName, recorder.Header().Get("X-Cache"), "HIT")
+	assertEqual(t, methodName, atomic.LoadInt32(hit), int32(1))
+
+	serve(s, http.MethodGet, "/search?limit=100", nil)
+	recorder = serve(s, http.MethodGet, "/search?limit=100", nil)
+	assertEqual(t, methodName, recorder.Header().Get("X-Cache"), "MISS")
+
+	now = now.Add(server.DefaultCacheTTL)
+	recorder = serve(s, http.MethodGet, "/search?query_term=a&page=1", nil)
+	assertEqual(t, methodName, recorder.Header().Get("X-Cache"), "MISS")
+	assertEqual(t, methodName, atomic.LoadInt32(hit), int32(2))
+}
+
+func TestServer_ServeHTTPCORS(t *testing.T) {
+	const methodName = "Server.ServeHTTP"
+
+	opts := server.DefaultOptions()
+	opts.AllowedOrigins = []string{"https://app.example.com"}
+	s, _ := server.New(yts.NewClient(), opts)
+
+	recorder := serve(s, http.MethodOptions, "/search", map[string]string{"Origin": "https://app.example.com"})
+	assertEqual(t, methodName, recorder.Code, http.StatusNoContent)
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Origin"), "https://app.example.com")
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Methods"), "GET, OPTIONS")
+
+	recorder = serve(s, http.MethodOptions, "/search", map[string]string{"Origin": "https://evil.example.com"})
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Origin"), "")
+
+	s, _ = server.New(yts.NewClient(), nil)
+	recorder = serve(s, http.MethodOptions, "/search", map[string]string{"Origin": "https://any.example.com"})
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Origin"), "*")
+}
+
+func TestServer_ServeHTTPMounts(t *testing.T) {
+	const methodName = "Server.ServeHTTP"
+
+	opts := server.DefaultOptions()
+	opts.Mounts = map[string]http.Handler{
+		"/graphql": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Method))
+		}),
+	}
+	s, _ := server.New(yts.NewClient(), opts)
+
+	recorder := serve(s, http.MethodPost, "/graphql", map[string]string{"Origin": "https://any.example.com"})
+	assertEqual(t, methodName, recorder.Body.String(), http.MethodPost)
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Origin"), "*")
+	assertEqual(t, methodName, recorder.Header().Get("X-Cache"), "")
+
+	recorder = serve(s, http.MethodOptions, "/graphql", map[string]string{"Origin": "https://any.example.com"})
+	assertEqual(t, methodName, recorder.Code, http.StatusNoContent)
+	assertEqual(t, methodName, recorder.Header().Get("Access-Control-Allow-Methods"), "GET, POST, OPTIONS")
+}
@@ -0,0 +1,94 @@
+package watch
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock provides the current time and timers to a Watcher, it allows tests to
+// control the passing of time by using a FakeClock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RealClock returns the Clock backed by the time package, it is the Clock used
+// by a Watcher when the Clock field of its Options is nil.
+func RealClock() Clock {
+	return realClock{}
+}
+
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// A FakeClock is a Clock whose time only moves when Advance is called, it is safe
+// for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+// NewFakeClock creates a *FakeClock whose current time is now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now implements the Clock interface.
+func (fc *FakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+// After implements the Clock interface, the returned channel receives the time
+// once Advance has moved the clock at least d past the current time.
+func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- fc.now
+		return ch
+	}
+
+	fc.timers = append(fc.timers, fakeTimer{fc.now.Add(d), ch})
+	return ch
+}
+
+// Advance moves the clock forward by d and fires every timer which is due.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+
+	pending := fc.timers[:0]
+	for _, timer := range fc.timers {
+		if timer.deadline.After(fc.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- fc.now
+	}
+	fc.timers = pending
+}
+
+// Waiters returns the number of timers which have not fired yet, tests use it to
+// wait until a Watcher is blocked on the clock before calling Advance.
+func (fc *FakeClock) Waiters() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return len(fc.timers)
+}
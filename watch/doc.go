@@ -0,0 +1,26 @@
+/*
+Package watch provides a Watcher which periodically polls the YTS API with a set
+of saved SearchMoviesFilters and delivers the movies matching them which it has not
+seen before, such as new releases of a favourite genre.
+
+	client := yts.NewClient()
+	filters := []watch.SavedFilter{
+		{Name: "4k-sci-fi", Filters: *yts.DefaultSearchMoviesFilters("")},
+	}
+	filters[0].Filters.Genre = yts.GenreSciFi
+	filters[0].Filters.Quality = yts.Quality2160p
+
+	opts := watch.DefaultOptions()
+	opts.Store = watch.NewFileStateStore("watch-state.json")
+	w, err := watch.New(client, filters, opts)
+	...
+	go w.Run(ctx)
+	for match := range w.Matches() {
+		...
+	}
+
+Errors encountered while polling delay the next poll with an exponential backoff,
+and the seen movies are persisted so that a restarted Watcher only delivers movies
+added since it last ran. Tests can control the passing of time with a FakeClock.
+*/
+package watch
@@ -0,0 +1,148 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxSeenPerFilter caps the number of movie IDs remembered for a single saved
+// filter, the oldest IDs are forgotten first.
+const maxSeenPerFilter = 1000
+
+// A FilterState records what a Watcher has seen for a single saved filter.
+type FilterState struct {
+	// The IDs of the movies already seen for the filter, oldest first.
+	Seen []int `json:"seen"`
+
+	// The time the filter was last polled successfully.
+	LastPolled time.Time `json:"last_polled"`
+}
+
+func (fs *FilterState) hasSeen(movieID int) bool {
+	for _, seenID := range fs.Seen {
+		if seenID == movieID {
+			return true
+		}
+	}
+	return false
+}
+
+func (fs *FilterState) markSeen(movieID int) {
+	fs.Seen = append(fs.Seen, movieID)
+	if overflow := len(fs.Seen) - maxSeenPerFilter; 0 < overflow {
+		fs.Seen = append([]int(nil), fs.Seen[overflow:]...)
+	}
+}
+
+// A State holds the FilterState of every saved filter of a Watcher keyed by the
+// name of the filter.
+type State struct {
+	Filters map[string]FilterState `json:"filters"`
+}
+
+func newState() State {
+	return State{Filters: make(map[string]FilterState)}
+}
+
+func (s State) clone() State {
+	cloned := newState()
+	for name, fs := range s.Filters {
+		fs.Seen = append([]int(nil), fs.Seen...)
+		cloned.Filters[name] = fs
+	}
+	return cloned
+}
+
+// A StateStore persists the State of a Watcher between restarts.
+type StateStore interface {
+	Load() (State, error)
+	Save(state State) error
+}
+
+// A MemoryStateStore is a StateStore which only holds the State in memory, it is
+// safe for concurrent use.
+type MemoryStateStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+// NewMemoryStateStore creates a *MemoryStateStore holding an empty State.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{state: newState()}
+}
+
+// Load implements the StateStore interface.
+func (ms *MemoryStateStore) Load() (State, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.state.clone(), nil
+}
+
+// Save implements the StateStore interface.
+func (ms *MemoryStateStore) Save(state State) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.state = state.clone()
+	return nil
+}
+
+// A FileStateStore is a StateStore which persists the State as JSON to a file,
+// the file is replaced atomically by every call to Save.
+type FileStateStore struct {
+	path string
+}
+
+// NewFileStateStore creates a *FileStateStore for the file at the provided path.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path}
+}
+
+// Load implements the StateStore interface, a missing file is treated as an empty
+// State.
+func (fs *FileStateStore) Load() (State, error) {
+	state := newState()
+	payload, err := os.ReadFile(fs.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return newState(), err
+	}
+
+	if state.Filters == nil {
+		state.Filters = make(map[string]FilterState)
+	}
+
+	return state, nil
+}
+
+// Save implements the StateStore interface.
+func (fs *FileStateStore) Save(state State) error {
+	const (
+		dirMode  = 0o755
+		fileMode = 0o644
+	)
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fs.path), dirMode); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fs.path+".tmp", payload, fileMode); err != nil {
+		return err
+	}
+
+	return os.Rename(fs.path+".tmp", fs.path)
+}
@@ -0,0 +1,339 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+const (
+	// DefaultInterval is the value of the Interval field for the Options instance
+	// returned by the DefaultOptions() function.
+	DefaultInterval = 15 * time.Minute
+
+	// DefaultMinBackoff is the value of the MinBackoff field for the Options
+	// instance returned by the DefaultOptions() function.
+	DefaultMinBackoff = 30 * time.Second
+
+	// DefaultMaxBackoff is the value of the MaxBackoff field for the Options
+	// instance returned by the DefaultOptions() function.
+	DefaultMaxBackoff = time.Hour
+)
+
+// ErrInvalidOptions is reported when a Watcher is created with invalid Options or
+// saved filters, the error description will carry further details.
+var ErrInvalidOptions = errors.New("invalid_watch_options")
+
+// A MovieSearcher is implemented by *yts.Client and is used by a Watcher for
+// polling the "/api/v2/list_movies.json" endpoint.
+type MovieSearcher interface {
+	SearchMoviesWithContext(ctx context.Context, filters *yts.SearchMoviesFilters) (
+		*yts.SearchMoviesResponse, error,
+	)
+}
+
+// A SavedFilter is a named set of SearchMoviesFilters polled by a Watcher, only
+// the first page of results is polled so the filters should sort newly added
+// movies first, as DefaultSearchMoviesFilters does.
+type SavedFilter struct {
+	Name    string
+	Filters yts.SearchMoviesFilters
+}
+
+// A Match is a movie matching a SavedFilter which the Watcher had not seen before.
+type Match struct {
+	Filter string    `json:"filter"`
+	Movie  yts.Movie `json:"movie"`
+	SeenAt time.Time `json:"seen_at"`
+}
+
+// An Options instance configures how a Watcher polls and delivers matches.
+type Options struct {
+	// The time waited between successful polls.
+	Interval time.Duration
+
+	// The time waited after a failed poll doubles for each consecutive failure,
+	// starting at MinBackoff and never exceeding MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// The Clock used for timing polls, RealClock() is used when nil.
+	Clock Clock
+
+	// The StateStore the seen movies are persisted to, an in-memory store is used
+	// when nil.
+	Store StateStore
+
+	// The first poll of a saved filter only records the movies it returns as seen,
+	// unless DeliverInitial is set in which case they are delivered as matches.
+	DeliverInitial bool
+
+	// When set, matches are passed to OnMatch instead of the channel returned by
+	// the Matches method.
+	OnMatch func(Match)
+
+	// When set, errors encountered by Run are passed to OnError before backing off.
+	OnError func(error)
+}
+
+// DefaultOptions returns the default *Options used for creating a Watcher.
+func DefaultOptions() *Options {
+	return &Options{
+		Interval:   DefaultInterval,
+		MinBackoff: DefaultMinBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+	}
+}
+
+func (o *Options) validate() error {
+	switch {
+	case o.Interval <= 0:
+		return fmt.Errorf("interval must be positive")
+	case o.MinBackoff <= 0:
+		return fmt.Errorf("min backoff must be positive")
+	case o.MaxBackoff < o.MinBackoff:
+		return fmt.Errorf("max backoff must be at least min backoff")
+	}
+	return nil
+}
+
+// A Watcher periodically polls a set of saved filters and delivers the movies it
+// has not seen before, the seen movies are persisted to a StateStore so that a
+// restarted Watcher does not deliver them again.
+type Watcher struct {
+	searcher MovieSearcher
+	filters  []SavedFilter
+	opts     Options
+	matches  chan Match
+
+	mu    sync.Mutex
+	state State
+}
+
+// New creates a *Watcher polling the provided saved filters using the provided
+// searcher, usually a *yts.Client. Every saved filter must have a unique, non
+// empty name. A nil opts uses DefaultOptions().
+func New(searcher MovieSearcher, filters []SavedFilter, opts *Options) (*Watcher, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+	}
+
+	names := make(map[string]bool, len(filters))
+	for i, filter := range filters {
+		if filter.Name == "" || names[filter.Name] {
+			err := fmt.Errorf("filters[%d] must have a unique non empty name", i)
+			return nil, fmt.Errorf("%w: %s", ErrInvalidOptions, err)
+		}
+		names[filter.Name] = true
+	}
+
+	w := &Watcher{
+		searcher: searcher,
+		filters:  append([]SavedFilter(nil), filters...),
+		opts:     *opts,
+		matches:  make(chan Match),
+	}
+
+	if w.opts.Clock == nil {
+		w.opts.Clock = RealClock()
+	}
+
+	if w.opts.Store == nil {
+		w.opts.Store = NewMemoryStateStore()
+	}
+
+	state, err := w.opts.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Filters == nil {
+		state.Filters = make(map[string]FilterState)
+	}
+
+	w.state = state
+	return w, nil
+}
+
+// Matches returns the channel matches are delivered on while Run is executing,
+// the channel is closed once Run returns. Nothing is delivered on the channel
+// when the OnMatch field of the Options is set.
+func (w *Watcher) Matches() <-chan Match {
+	return w.matches
+}
+
+// State returns a copy of the current State of the watcher.
+func (w *Watcher) State() State {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.state.clone()
+}
+
+// pollFilter polls a single saved filter and returns its new matches, which are
+// only marked as seen once delivered using markDelivered.
+func (w *Watcher) pollFilter(ctx context.Context, filter *SavedFilter, now time.Time) ([]Match, error) {
+	filters := filter.Filters
+	response, err := w.searcher.SearchMoviesWithContext(ctx, &filters)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filter.Name, err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var (
+		matches      = make([]Match, 0)
+		fs, polled   = w.state.Filters[filter.Name]
+		deliverMatch = polled || w.opts.DeliverInitial
+	)
+
+	// the API returns the newest movies first, they are walked oldest first so
+	// that matches are delivered in the order the movies were added.
+	movies := response.Data.Movies
+	for i := len(movies) - 1; 0 <= i; i-- {
+		if fs.hasSeen(movies[i].ID) {
+			continue
+		}
+
+		if deliverMatch {
+			matches = append(matches, Match{filter.Name, movies[i], now})
+		} else {
+			fs.markSeen(movies[i].ID)
+		}
+	}
+
+	fs.LastPolled = now
+	w.state.Filters[filter.Name] = fs
+	return matches, nil
+}
+
+func (w *Watcher) markDelivered(match *Match) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	fs := w.state.Filters[match.Filter]
+	if !fs.hasSeen(match.Movie.ID) {
+		fs.markSeen(match.Movie.ID)
+	}
+	w.state.Filters[match.Filter] = fs
+}
+
+func (w *Watcher) poll(ctx context.Context) ([]Match, error) {
+	var (
+		now     = w.opts.Clock.Now()
+		matches = make([]Match, 0)
+		errs    = make([]error, 0)
+	)
+
+	for i := range w.filters {
+		filterMatches, err := w.pollFilter(ctx, &w.filters[i], now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		matches = append(matches, filterMatches...)
+	}
+
+	return matches, errors.Join(errs...)
+}
+
+// Poll polls every saved filter once and returns the new matches, which are
+// recorded as seen, the State is saved to the StateStore once every filter has
+// been polled. Filters which fail to be polled are skipped and their errors are
+// joined into the returned error.
+func (w *Watcher) Poll(ctx context.Context) ([]Match, error) {
+	matches, err := w.poll(ctx)
+	for i := range matches {
+		w.markDelivered(&matches[i])
+	}
+
+	if sErr := w.opts.Store.Save(w.State()); sErr != nil {
+		err = errors.Join(err, sErr)
+	}
+
+	return matches, err
+}
+
+// deliver delivers the provided matches in order, recording each match as seen
+// once delivered, and stops at the first match which could not be delivered
+// before the provided context was cancelled.
+func (w *Watcher) deliver(ctx context.Context, matches []Match) error {
+	for i := range matches {
+		if w.opts.OnMatch != nil {
+			w.opts.OnMatch(matches[i])
+			w.markDelivered(&matches[i])
+			continue
+		}
+
+		select {
+		case w.matches <- matches[i]:
+			w.markDelivered(&matches[i])
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) backoff(failures int) time.Duration {
+	delay := w.opts.MinBackoff
+	for i := 1; i < failures && delay < w.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, w.opts.MaxBackoff)
+}
+
+// Run polls the saved filters immediately and then every Interval until the
+// provided context is cancelled, delivering new matches as they are found. After
+// a failed poll the next poll is delayed by an exponential backoff instead of the
+// Interval. Only delivered matches are recorded as seen, so matches left
+// undelivered when the context is cancelled are delivered again by the next Run.
+// Run returns the error of the context once it is cancelled and closes the
+// channel returned by the Matches method, it must only be called once.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.matches)
+
+	failures := 0
+	for {
+		// the State is saved once the matches have been delivered, matches which
+		// were not delivered before the context was cancelled are left unseen so
+		// that they are delivered again by the next Run.
+		matches, err := w.poll(ctx)
+		if ctx.Err() == nil {
+			err = errors.Join(err, w.deliver(ctx, matches))
+		}
+
+		if sErr := w.opts.Store.Save(w.State()); sErr != nil {
+			err = errors.Join(err, sErr)
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		delay := w.opts.Interval
+		if err != nil {
+			failures++
+			delay = w.backoff(failures)
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		} else {
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.opts.Clock.After(delay):
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package watch_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/watch"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+var errFakeSearch = errors.New("fake_search_failure")
+
+// fakeSearcher returns the movies set for a query term, newest first, or fails
+// with errFakeSearch for the number of calls set in failures.
+type fakeSearcher struct {
+	mu       sync.Mutex
+	movies   map[string][]yts.Movie
+	failures int
+	calls    int
+}
+
+func (fs *fakeSearcher) SearchMoviesWithContext(_ context.Context, filters *yts.SearchMoviesFilters) (
+	*yts.SearchMoviesResponse, error,
+) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.calls++
+	if 0 < fs.failures {
+		fs.failures--
+		return nil, errFakeSearch
+	}
+
+	response := &yts.SearchMoviesResponse{}
+	response.Data.Movies = append([]yts.Movie(nil), fs.movies[filters.QueryTerm]...)
+	return response, nil
+}
+
+func (fs *fakeSearcher) set(queryTerm string, ids ...int) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	movies := make([]yts.Movie, 0, len(ids))
+	for _, id := range ids {
+		movies = append(movies, yts.Movie{MoviePartial: yts.MoviePartial{ID: id}})
+	}
+	fs.movies[queryTerm] = movies
+}
+
+func (fs *fakeSearcher) callCount() int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.calls
+}
+
+func savedFilter(name string) watch.SavedFilter {
+	return watch.SavedFilter{Name: name, Filters: *yts.DefaultSearchMoviesFilters(name)}
+}
+
+func matchIDs(matches []watch.Match) []int {
+	ids := make([]int, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.Movie.ID)
+	}
+	return ids
+}
+
+func TestNew(t *testing.T) {
+	const methodName = "watch.New"
+
+	searcher := &fakeSearcher{movies: make(map[string][]yts.Movie)}
+	tests := []struct {
+		name    string
+		filters []watch.SavedFilter
+		opts    *watch.Options
+		wantErr error
+	}{
+		{
+			name:    "uses default options when nil",
+			filters: []watch.SavedFilter{savedFilter("a")},
+		},
+		{
+			name:    "returns error for duplicate filter names",
+			filters: []watch.SavedFilter{savedFilter("a"), savedFilter("a")},
+			wantErr: watch.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error for empty filter name",
+			filters: []watch.SavedFilter{savedFilter("")},
+			wantErr: watch.ErrInvalidOptions,
+		},
+		{
+			name:    "returns error when max backoff is below min backoff",
+			opts:    &watch.Options{Interval: time.Minute, MinBackoff: time.Minute, MaxBackoff: time.Second},
+			wantErr: watch.ErrInvalidOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := watch.New(searcher, tt.filters, tt.opts)
+			assertError(t, methodName, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	const methodName = "Watcher.Poll"
+
+	var (
+		searcher  = &fakeSearcher{movies: make(map[string][]yts.Movie)}
+		storePath = filepath.Join(t.TempDir(), "state.json")
+		clock     = watch.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		filters   = []watch.SavedFilter{savedFilter("a"), savedFilter("b")}
+		opts      = watch.DefaultOptions()
+	)
+
+	opts.Clock = clock
+	opts.Store = watch.NewFileStateStore(storePath)
+	w, err := watch.New(searcher, filters, opts)
+	assertError(t, methodName, err, nil)
+
+	searcher.set("a", 2, 1)
+	searcher.set("b", 3)
+	matches, err := w.Poll(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, matchIDs(matches), []int{})
+
+	searcher.set("a", 5, 4, 2, 1)
+	clock.Advance(time.Minute)
+	matches, err = w.Poll(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, matchIDs(matches), []int{4, 5})
+	assertEqual(t, methodName, matches[0].Filter, "a")
+	assertEqual(t, methodName, matches[0].SeenAt, clock.Now())
+
+	restarted, err := watch.New(searcher, filters, opts)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, restarted.State(), w.State())
+
+	searcher.set("b", 6, 3)
+	matches, err = restarted.Poll(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, matchIDs(matches), []int{6})
+}
+
+func TestWatcher_PollDeliverInitial(t *testing.T) {
+	const methodName = "Watcher.Poll"
+
+	searcher := &fakeSearcher{movies: make(map[string][]yts.Movie)}
+	opts := watch.DefaultOptions()
+	opts.DeliverInitial = true
+
+	w, _ := watch.New(searcher, []watch.SavedFilter{savedFilter("a")}, opts)
+	searcher.set("a", 2, 1)
+	matches, err := w.Poll(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, matchIDs(matches), []int{1, 2})
+}
+
+// waitForTimer blocks until the watcher is waiting on the fake clock.
+func waitForTimer(t *testing.T, clock *watch.FakeClock) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for watcher to wait on clock")
+		}
+		runtime.Gosched()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	const methodName = "Watcher.Run"
+
+	var (
+		searcher = &fakeSearcher{movies: make(map[string][]yts.Movie), failures: 2}
+		clock    = watch.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+		opts     = &watch.Options{
+			Interval:   10 * time.Minute,
+			MinBackoff: time.Minute,
+			MaxBackoff: 90 * time.Second,
+			Clock:      clock,
+		}
+		errCount int
+		errMu    sync.Mutex
+	)
+
+	opts.OnError = func(error) {
+		errMu.Lock()
+		defer errMu.Unlock()
+		errCount++
+	}
+
+	w, _ := watch.New(searcher, []watch.SavedFilter{savedFilter("a")}, opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	// the first two polls fail, so the watcher backs off for one minute and then
+	// for the capped ninety seconds before its first successful poll.
+	waitForTimer(t, clock)
+	clock.Advance(time.Minute)
+	waitForTimer(t, clock)
+	clock.Advance(time.Minute)
+	assertEqual(t, methodName, searcher.callCount(), 2)
+	clock.Advance(30 * time.Second)
+	waitForTimer(t, clock)
+	assertEqual(t, methodName, searcher.callCount(), 3)
+
+	searcher.set("a", 7)
+	clock.Advance(10 * time.Minute)
+	match := <-w.Matches()
+	assertEqual(t, methodName, match.Movie.ID, 7)
+
+	cancel()
+	assertError(t, methodName, <-done, context.Canceled)
+	_, open := <-w.Matches()
+	assertEqual(t, methodName, open, false)
+
+	errMu.Lock()
+	defer errMu.Unlock()
+	assertEqual(t, methodName, errCount, 2)
+}
+
+func TestWatcher_RunCancelledDuringDelivery(t *testing.T) {
+	const methodName = "Watcher.Run"
+
+	var (
+		searcher = &fakeSearcher{movies: make(map[string][]yts.Movie)}
+		filters  = []watch.SavedFilter{savedFilter("a")}
+		opts     = watch.DefaultOptions()
+	)
+
+	opts.Clock = watch.NewFakeClock(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	opts.Store = watch.NewMemoryStateStore()
+	opts.DeliverInitial = true
+
+	w, _ := watch.New(searcher, filters, opts)
+	searcher.set("a", 2, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	match := <-w.Matches()
+	assertEqual(t, methodName, match.Movie.ID, 1)
+	cancel()
+	assertError(t, methodName, <-done, context.Canceled)
+
+	// the second match was never delivered, so a restarted watcher delivers it.
+	restarted, _ := watch.New(searcher, filters, opts)
+	matches, err := restarted.Poll(context.Background())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, matchIDs(matches), []int{2})
+}
@@ -60,6 +60,25 @@ type ClientConfig struct {
 	// *yts.Client.
 	RequestTimeout time.Duration
 
+	// The function used by *yts.Client methods for retrieving the current time, it
+	// serves as the reference for parsing relative comment timestamps such as "3
+	// years ago", and for expiring the movie details cached by the client. When this
+	// field is nil time.Now is used.
+	Clock func() time.Time
+
+	// The index used by *yts.Client methods for resolving between movie slugs, YTS
+	// movie IDs and IMDb codes, it is populated with every movie returned by the YTS
+	// API. Providing an index created with a ResolutionStore allows resolutions to
+	// persist across program runs, when this field is nil an in-memory index private
+	// to the client is used.
+	ResolutionIndex *ResolutionIndex
+
+	// The transport used by the internal *http.Client instance of the *yts.Client
+	// for making http requests, such as a cassette.Recorder for recording and
+	// replaying responses in tests. When this field is nil http.DefaultTransport is
+	// used.
+	Transport http.RoundTripper
+
 	// This flag "switches on" an internal logger and is intended for use by developers
 	// for debugging purposes, if you encounter a bug in this package turning this flag
 	// on will reveal greater detail regarding the error in question.
@@ -72,6 +91,8 @@ type ClientConfig struct {
 type Client struct {
 	config    ClientConfig
 	netClient *http.Client
+	cache     *movieCache
+	index     *ResolutionIndex
 }
 
 var (
@@ -95,6 +116,11 @@ var (
 	// an input argument to one of the methods of the yts.Client method, the error
 	// description will carry further information.
 	ErrValidationFailure = errors.New("validation_failure")
+
+	// ErrMovieNotFound is reported whenever a yts.Client method was unable to find
+	// a movie on YTS corresponding to the provided input, the error description will
+	// carry further information.
+	ErrMovieNotFound = errors.New("movie_not_found")
 )
 
 func wrapErr(sentinel error, others ...error) error {
@@ -162,8 +188,22 @@ func NewClientWithConfig(config *ClientConfig) (*Client, error) {
 		debug.setDebug(true)
 	}
 
-	netClient := &http.Client{Timeout: config.RequestTimeout}
-	return &Client{*config, netClient}, nil
+	index := config.ResolutionIndex
+	if index == nil {
+		index, _ = NewResolutionIndex(nil)
+	}
+
+	netClient := &http.Client{Timeout: config.RequestTimeout, Transport: config.Transport}
+	cache := newMovieCache(movieCacheTTL, movieCacheMaxEntries)
+	return &Client{*config, netClient, cache, index}, nil
+}
+
+func (c *Client) now() time.Time {
+	if c.config.Clock != nil {
+		return c.config.Clock()
+	}
+
+	return time.Now()
 }
 
 // NewClient returns a new `*yts.Client` instance with the internal ClientConfig
@@ -221,6 +261,7 @@ func (c *Client) SearchMoviesWithContext(ctx context.Context, filters *SearchMov
 		return nil, err
 	}
 
+	c.index.addMovies(parsedPayload.Data.Movies)
 	return parsedPayload, nil
 }
 
@@ -266,6 +307,7 @@ func (c *Client) MovieDetailsWithContext(ctx context.Context, movieID int, filte
 		return nil, err
 	}
 
+	c.index.addMoviePartials(&parsedPayload.Data.Movie.MoviePartial)
 	return parsedPayload, nil
 }
 
@@ -313,6 +355,7 @@ func (c *Client) MovieSuggestionsWithContext(ctx context.Context, movieID int) (
 		return nil, err
 	}
 
+	c.index.addMovies(parsedPayload.Data.Movies)
 	return parsedPayload, nil
 }
 
@@ -333,6 +376,10 @@ func (c *Client) ResolveMovieSlugToIDWithContext(ctx context.Context, movieSlug
 		return 0, wrapErr(ErrValidationFailure, err)
 	}
 
+	if ref, ok := c.index.LookupSlug(movieSlug); ok {
+		return ref.ID, nil
+	}
+
 	pageURLString := fmt.Sprintf("%s/movies/%s", &c.config.SiteURL, movieSlug)
 	pageURL, _ := url.Parse(pageURLString)
 	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
@@ -345,11 +392,16 @@ func (c *Client) ResolveMovieSlugToIDWithContext(ctx context.Context, movieSlug
 		return 0, ErrContentRetrievalFailure
 	}
 
+	if err := c.index.Add(MovieRef{ID: movieID, Slug: movieSlug}); err != nil {
+		debug.Println(err)
+	}
+
 	return movieID, nil
 }
 
 // ResolveMovieSlugToID method converts the provided movie slug to its corresponding
-// ID in the YTS movie database.
+// ID in the YTS movie database, the ResolutionIndex of the client is consulted
+// first and the movie page is only scraped when the slug is absent from it.
 func (c *Client) ResolveMovieSlugToID(movieSlug string) (int, error) {
 	return c.ResolveMovieSlugToIDWithContext(context.Background(), movieSlug)
 }
@@ -392,6 +444,16 @@ func (c *Client) TrendingMovies() (*TrendingMoviesResponse, error) {
 	return c.TrendingMoviesWithContext(context.Background())
 }
 
+// A HomePageSection identifies one of the movie sections shown on the "/" home
+// page of the YTS website.
+type HomePageSection string
+
+const (
+	HomePageSectionPopular  HomePageSection = "popular"
+	HomePageSectionLatest   HomePageSection = "latest"
+	HomePageSectionUpcoming HomePageSection = "upcoming"
+)
+
 type HomePageContentData struct {
 	Popular  []SiteMovie         `json:"popular"`
 	Latest   []SiteMovie         `json:"latest"`
@@ -400,9 +462,11 @@ type HomePageContentData struct {
 
 // A HomePageContentResponse holds the content retrieved by scraping the /trending
 // page of the YTS website, the content in question being the current popular,
-// trending and upcoming movie torrents.
+// trending and upcoming movie torrents. The MissingSections field is only ever
+// populated by the HomePageContentPartial method.
 type HomePageContentResponse struct {
-	Data HomePageContentData `json:"data"`
+	Data            HomePageContentData `json:"data"`
+	MissingSections []HomePageSection   `json:"missing_sections,omitempty"`
 }
 
 // HomePageContentWithContext is the same as the HomePageContent method but
@@ -421,7 +485,7 @@ func (c *Client) HomePageContentWithContext(ctx context.Context) (
 		return nil, ErrContentRetrievalFailure
 	}
 
-	return &HomePageContentResponse{*data}, nil
+	return &HomePageContentResponse{Data: *data}, nil
 }
 
 // HomePageContent method scrapes the popular, latest torrents and upcoming
@@ -431,6 +495,143 @@ func (c *Client) HomePageContent() (*HomePageContentResponse, error) {
 	return c.HomePageContentWithContext(context.Background())
 }
 
+// HomePageContentPartialWithContext is the same as the HomePageContentPartial
+// method but requires a context.Context argument to be passed, this context is
+// then passed to the http.NewRequestWithContext call used for making the network
+// request.
+func (c *Client) HomePageContentPartialWithContext(ctx context.Context) (
+	*HomePageContentResponse, error,
+) {
+	document, err := c.newDocumentRequestWithContext(ctx, &c.config.SiteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	const sectionCount = 3
+	data, missingSections := c.scrapePartialHomePageContentData(document)
+	if len(missingSections) == sectionCount {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &HomePageContentResponse{*data, missingSections}, nil
+}
+
+// HomePageContentPartial method is a lenient version of the HomePageContent
+// method, sections of the "/" home page which are missing or fail to be scraped
+// are returned empty and reported in the MissingSections field of the response,
+// an error is only returned if none of the sections could be scraped.
+func (c *Client) HomePageContentPartial() (*HomePageContentResponse, error) {
+	return c.HomePageContentPartialWithContext(context.Background())
+}
+
+type PopularDownloadsData struct {
+	Movies []SiteMovie `json:"movies"`
+}
+
+// A PopularDownloadsResponse holds the content retrieved by scraping the popular
+// downloads section of the "/" home page of the YTS website.
+type PopularDownloadsResponse struct {
+	Data PopularDownloadsData `json:"data"`
+}
+
+// PopularDownloadsWithContext is the same as the PopularDownloads method but
+// requires a context.Context argument to be passed, this context is then passed to
+// the http.NewRequestWithContext call used for making the network request.
+func (c *Client) PopularDownloadsWithContext(ctx context.Context) (
+	*PopularDownloadsResponse, error,
+) {
+	document, err := c.newDocumentRequestWithContext(ctx, &c.config.SiteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := c.scrapePopularDownloads(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &PopularDownloadsResponse{PopularDownloadsData{movies}}, nil
+}
+
+// PopularDownloads method scrapes only the popular downloads section of the YTS
+// website's "/" home page and returns this as an instance of
+// *PopularDownloadsResponse.
+func (c *Client) PopularDownloads() (*PopularDownloadsResponse, error) {
+	return c.PopularDownloadsWithContext(context.Background())
+}
+
+type LatestTorrentsData struct {
+	Movies []SiteMovie `json:"movies"`
+}
+
+// A LatestTorrentsResponse holds the content retrieved by scraping the latest
+// torrents section of the "/" home page of the YTS website.
+type LatestTorrentsResponse struct {
+	Data LatestTorrentsData `json:"data"`
+}
+
+// LatestTorrentsWithContext is the same as the LatestTorrents method but requires
+// a context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (c *Client) LatestTorrentsWithContext(ctx context.Context) (
+	*LatestTorrentsResponse, error,
+) {
+	document, err := c.newDocumentRequestWithContext(ctx, &c.config.SiteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := c.scrapeLatestTorrents(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &LatestTorrentsResponse{LatestTorrentsData{movies}}, nil
+}
+
+// LatestTorrents method scrapes only the latest torrents section of the YTS
+// website's "/" home page and returns this as an instance of
+// *LatestTorrentsResponse.
+func (c *Client) LatestTorrents() (*LatestTorrentsResponse, error) {
+	return c.LatestTorrentsWithContext(context.Background())
+}
+
+type UpcomingMoviesData struct {
+	Movies []SiteUpcomingMovie `json:"movies"`
+}
+
+// A UpcomingMoviesResponse holds the content retrieved by scraping the upcoming
+// movies section of the "/" home page of the YTS website.
+type UpcomingMoviesResponse struct {
+	Data UpcomingMoviesData `json:"data"`
+}
+
+// UpcomingMoviesWithContext is the same as the UpcomingMovies method but requires
+// a context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (c *Client) UpcomingMoviesWithContext(ctx context.Context) (
+	*UpcomingMoviesResponse, error,
+) {
+	document, err := c.newDocumentRequestWithContext(ctx, &c.config.SiteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := c.scrapeUpcomingMovies(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &UpcomingMoviesResponse{UpcomingMoviesData{movies}}, nil
+}
+
+// UpcomingMovies method scrapes only the upcoming movies section of the YTS
+// website's "/" home page and returns this as an instance of
+// *UpcomingMoviesResponse.
+func (c *Client) UpcomingMovies() (*UpcomingMoviesResponse, error) {
+	return c.UpcomingMoviesWithContext(context.Background())
+}
+
 type MovieDirectorData struct {
 	Director SiteMovieDirector `json:"director"`
 }
@@ -474,6 +675,97 @@ func (c *Client) MovieDirectorWithContext(ctx context.Context, movieSlug string)
 	return &MovieDirectorResponse{*data}, nil
 }
 
+type MovieCrewData struct {
+	Directors []SiteMoviePerson     `json:"directors"`
+	Cast      []SiteMovieCastMember `json:"cast"`
+}
+
+// A MovieCrewResponse holds every director and top cast member listed on the YTS
+// page for a movie.
+type MovieCrewResponse struct {
+	Data MovieCrewData `json:"data"`
+}
+
+// MovieCrewWithContext is the same as the MovieCrew method but requires a
+// context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext call used for making the network request.
+func (c *Client) MovieCrewWithContext(ctx context.Context, movieSlug string) (
+	*MovieCrewResponse, error,
+) {
+	if movieSlug == "" {
+		err := fmt.Errorf("provided movie slug cannot be an empty")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	pageURLString := fmt.Sprintf("%s/movies/%s", &c.config.SiteURL, movieSlug)
+	pageURL, _ := url.Parse(pageURLString)
+	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.scrapeMovieCrewData(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &MovieCrewResponse{*data}, nil
+}
+
+// MovieCrew method fetches the movie page corresponding to the provided movie slug
+// and scrapes all the directors and top cast members listed therein, unlike the
+// MovieDirector method which only returns the first director. Use ReconcileCast
+// for combining the scraped cast with the Cast returned by MovieDetails.
+func (c *Client) MovieCrew(movieSlug string) (*MovieCrewResponse, error) {
+	return c.MovieCrewWithContext(context.Background(), movieSlug)
+}
+
+type MoviePageDetailsData struct {
+	Subtitles     []SiteMovieSubtitle `json:"subtitles"`
+	Scores        SiteMovieScores     `json:"scores"`
+	AlsoKnownAs   []string            `json:"also_known_as"`
+	SimilarMovies []SiteMovieBase     `json:"similar_movies"`
+}
+
+// A MoviePageDetailsResponse holds the details shown on the YTS page for a movie
+// which are not available through the YTS API.
+type MoviePageDetailsResponse struct {
+	Data MoviePageDetailsData `json:"data"`
+}
+
+// MoviePageDetailsWithContext is the same as the MoviePageDetails method but
+// requires a context.Context argument to be passed, this context is then passed to
+// the http.NewRequestWithContext call used for making the network request.
+func (c *Client) MoviePageDetailsWithContext(ctx context.Context, movieSlug string) (
+	*MoviePageDetailsResponse, error,
+) {
+	if movieSlug == "" {
+		err := fmt.Errorf("provided movie slug cannot be an empty")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	pageURLString := fmt.Sprintf("%s/movies/%s", &c.config.SiteURL, movieSlug)
+	pageURL, _ := url.Parse(pageURLString)
+	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.scrapeMoviePageDetailsData(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	return &MoviePageDetailsResponse{*data}, nil
+}
+
+// MoviePageDetails method fetches the movie page corresponding to the provided
+// movie slug and scrapes the subtitle links, IMDb and RottenTomatoes scores, "also
+// known as" titles and similar movies shown therein.
+func (c *Client) MoviePageDetails(movieSlug string) (*MoviePageDetailsResponse, error) {
+	return c.MoviePageDetailsWithContext(context.Background(), movieSlug)
+}
+
 type MovieReviewsData struct {
 	Reviews         []SiteMovieReview `json:"reviews"`
 	ReviewsMoreLink string            `json:"reviews_more_link"`
@@ -516,6 +808,97 @@ func (c *Client) MovieReviews(movieSlug string) (*MovieReviewsResponse, error) {
 	return c.MovieReviewsWithContext(context.Background(), movieSlug)
 }
 
+// maxMovieReviewsPages is the maximum number of review listing pages followed by
+// the AllMovieReviews method, guarding against pagination which never ends.
+const maxMovieReviewsPages = 100
+
+type AllMovieReviewsData struct {
+	Reviews []SiteMovieReview `json:"reviews"`
+}
+
+// An AllMovieReviewsResponse contains every review found by following the link
+// to more reviews available on the YTS page for a movie.
+type AllMovieReviewsResponse struct {
+	Data AllMovieReviewsData `json:"data"`
+}
+
+// AllMovieReviewsWithContext is the same as the AllMovieReviews method but requires
+// a context.Context argument to be passed, this context is then passed to the
+// http.NewRequestWithContext calls used for making the network requests.
+func (c *Client) AllMovieReviewsWithContext(ctx context.Context, movieSlug string) (
+	*AllMovieReviewsResponse, error,
+) {
+	if movieSlug == "" {
+		err := fmt.Errorf("provided movie slug cannot be an empty")
+		return nil, wrapErr(ErrValidationFailure, err)
+	}
+
+	pageURLString := fmt.Sprintf("%s/movies/%s", &c.config.SiteURL, movieSlug)
+	pageURL, _ := url.Parse(pageURLString)
+	document, err := c.newDocumentRequestWithContext(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.scrapeMovieReviewsData(document)
+	if err != nil {
+		return nil, ErrContentRetrievalFailure
+	}
+
+	var (
+		reviews     = make([]SiteMovieReview, 0)
+		seenReviews = make(map[string]bool)
+		seenPages   = make(map[string]bool)
+	)
+
+	var listingURL *url.URL
+	if data.ReviewsMoreLink != "" {
+		listingURL, _ = url.Parse(data.ReviewsMoreLink)
+	}
+
+	for i := 0; listingURL != nil && i < maxMovieReviewsPages; i++ {
+		if seenPages[listingURL.String()] {
+			break
+		}
+		seenPages[listingURL.String()] = true
+
+		listingDoc, err := c.newDocumentRequestWithContext(ctx, listingURL)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := c.scrapeMovieReviewsPage(listingDoc, listingURL)
+		if err != nil {
+			return nil, ErrContentRetrievalFailure
+		}
+
+		for _, review := range page.reviews {
+			if review.ID != "" && seenReviews[review.ID] {
+				continue
+			}
+			seenReviews[review.ID] = true
+			reviews = append(reviews, review)
+		}
+
+		listingURL = page.nextPageURL
+	}
+
+	if len(reviews) == 0 {
+		reviews = data.Reviews
+	}
+
+	return &AllMovieReviewsResponse{AllMovieReviewsData{reviews}}, nil
+}
+
+// AllMovieReviews method fetches the movie page corresponding to the provided movie
+// slug and follows the link to more reviews found therein, paginating through the
+// full reviews listing and returning every review along with its ID, date and
+// helpfulness counts where present. The reviews embedded on the movie page are
+// returned in the event the reviews listing contains no reviews.
+func (c *Client) AllMovieReviews(movieSlug string) (*AllMovieReviewsResponse, error) {
+	return c.AllMovieReviewsWithContext(context.Background(), movieSlug)
+}
+
 const movieCommentsPerPage = 30
 
 type MovieCommentsData struct {
@@ -1,12 +1,14 @@
 package yts_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path"
 	"reflect"
 	"strings"
@@ -529,7 +531,7 @@ func TestClient_TrendingMoviesWithContext(t *testing.T) {
 	mockedOKResponse := &yts.TrendingMoviesResponse{
 		Data: yts.TrendingMoviesData{
 			Movies: []yts.SiteMovie{{
-				Rating: "7.6 / 10",
+				Rating: yts.Rating{Value: 7.6, Scale: 10},
 				SiteMovieBase: yts.SiteMovieBase{
 					Slug:   "superbad-2007",
 					Title:  "Superbad",
@@ -661,7 +663,7 @@ func TestClient_HomePageContentWithContext(t *testing.T) {
 	mockedOKResponse := &yts.HomePageContentResponse{
 		Data: yts.HomePageContentData{
 			Popular: []yts.SiteMovie{{
-				Rating: "6.8 / 10",
+				Rating: yts.Rating{Value: 6.8, Scale: 10},
 				SiteMovieBase: yts.SiteMovieBase{
 					Slug:   "migration-2023",
 					Title:  "Migration",
@@ -672,7 +674,7 @@ func TestClient_HomePageContentWithContext(t *testing.T) {
 				},
 			}},
 			Latest: []yts.SiteMovie{{
-				Rating: "5.3 / 10",
+				Rating: yts.Rating{Value: 5.3, Scale: 10},
 				SiteMovieBase: yts.SiteMovieBase{
 					Slug:   "het-einde-van-de-reis-1981",
 					Title:  "[NL] Het einde van de reis",
@@ -800,6 +802,290 @@ func TestClient_HomePageContentWithContext(t *testing.T) {
 	}
 }
 
+func mockedHomePageContentData(t *testing.T) yts.HomePageContentData {
+	t.Helper()
+	return yts.HomePageContentData{
+		Popular: []yts.SiteMovie{{
+			Rating: yts.Rating{Value: 6.8, Scale: 10},
+			SiteMovieBase: yts.SiteMovieBase{
+				Slug:   "migration-2023",
+				Title:  "Migration",
+				Year:   2023,
+				Link:   "https://yts.mx/movies/migration-2023",
+				Image:  "https://img.yts.mx/assets/images/movies/migration_2023/medium-cover.jpg",
+				Genres: []yts.Genre{"Action", "Adventure"},
+			},
+		}},
+		Latest: []yts.SiteMovie{{
+			Rating: yts.Rating{Value: 5.3, Scale: 10},
+			SiteMovieBase: yts.SiteMovieBase{
+				Slug:   "het-einde-van-de-reis-1981",
+				Title:  "[NL] Het einde van de reis",
+				Year:   1981,
+				Link:   "https://yts.mx/movies/het-einde-van-de-reis-1981",
+				Image:  "https://img.yts.mx/assets/images/movies/het_einde_van_de_reis_1981/medium-cover.jpg",
+				Genres: []yts.Genre{"Action"},
+			},
+		}},
+		Upcoming: []yts.SiteUpcomingMovie{{
+			Progress: 28,
+			Quality:  yts.Quality2160p,
+			SiteMovieBase: yts.SiteMovieBase{
+				Title:  "Boyz n the Hood",
+				Year:   1991,
+				Link:   "https://www.imdb.com/title/tt0101507/",
+				Image:  "https://img.yts.mx/assets/images/movies/Boyz_n_the_Hood_1991/medium-cover.jpg",
+				Genres: []yts.Genre{},
+			},
+		}},
+	}
+}
+
+func TestClient_HomePageContentPartialWithContext(t *testing.T) {
+	const (
+		methodName  = "Client.HomePageContentPartial"
+		testdataDir = "homepage_content"
+		pattern     = "/"
+	)
+
+	timedoutCtx, cancel := context.WithDeadline(
+		context.Background(), time.Now(),
+	)
+	defer cancel()
+
+	var (
+		okData            = mockedHomePageContentData(t)
+		missingPopular    = okData
+		missingLatest     = okData
+		missingUpcoming   = okData
+		invalidPopular    = okData
+		invalidUpcoming   = okData
+		noMissingSections = []yts.HomePageSection{}
+	)
+
+	missingPopular.Popular = []yts.SiteMovie{}
+	missingLatest.Latest = []yts.SiteMovie{}
+	missingLatest.Upcoming = []yts.SiteUpcomingMovie{}
+	missingUpcoming.Upcoming = []yts.SiteUpcomingMovie{}
+	invalidPopular.Popular = []yts.SiteMovie{}
+	invalidUpcoming.Upcoming = []yts.SiteUpcomingMovie{}
+
+	tests := []struct {
+		name       string
+		handlerCfg testHTTPHandlerConfig
+		clientCfg  yts.ClientConfig
+		ctx        context.Context
+		want       *yts.HomePageContentResponse
+		wantErr    error
+	}{
+		{
+			name:       "returns available sections when popular movies selector missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_popular.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data:            missingPopular,
+				MissingSections: []yts.HomePageSection{yts.HomePageSectionPopular},
+			},
+		},
+		{
+			name:       "returns available sections when latest torrents container missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_latest.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data: missingLatest,
+				MissingSections: []yts.HomePageSection{
+					yts.HomePageSectionLatest,
+					yts.HomePageSectionUpcoming,
+				},
+			},
+		},
+		{
+			name:       "returns available sections when upcoming movies selector missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_upcoming.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data:            missingUpcoming,
+				MissingSections: []yts.HomePageSection{yts.HomePageSectionUpcoming},
+			},
+		},
+		{
+			name:       "reports section as missing when validation for scraped popular movies fail",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "invalid_popular.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data:            invalidPopular,
+				MissingSections: []yts.HomePageSection{yts.HomePageSectionPopular},
+			},
+		},
+		{
+			name:       `reports section as missing when scraped "Progress" is invalid`,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "invalid_progress.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data:            invalidUpcoming,
+				MissingSections: []yts.HomePageSection{yts.HomePageSectionUpcoming},
+			},
+		},
+		{
+			name:       "returns error when all sections are missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, "trending_movies", "missing_selector.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns error when request context times out",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        timedoutCtx,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:       "returns error when response status is outside 2.x.x range",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "non_existent.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			wantErr:    yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:       "returns mocked ok response when scraping succeeds",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			clientCfg:  yts.DefaultClientConfig(),
+			ctx:        context.Background(),
+			want: &yts.HomePageContentResponse{
+				Data:            okData,
+				MissingSections: noMissingSections,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := tt.clientCfg
+			if tt.handlerCfg.pattern != "" {
+				server := createTestServer(t, tt.handlerCfg)
+				serverURL, _ := url.Parse(server.URL)
+				clientCfg.SiteURL = *serverURL
+				defer server.Close()
+			}
+
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.HomePageContentPartialWithContext(tt.ctx)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestClient_HomePageSectionsWithContext(t *testing.T) {
+	const (
+		testdataDir = "homepage_content"
+		pattern     = "/"
+	)
+
+	okData := mockedHomePageContentData(t)
+
+	type sectionMethod func(c *yts.Client) (any, error)
+	var (
+		popularDownloads = func(c *yts.Client) (any, error) {
+			return c.PopularDownloadsWithContext(context.Background())
+		}
+		latestTorrents = func(c *yts.Client) (any, error) {
+			return c.LatestTorrentsWithContext(context.Background())
+		}
+		upcomingMovies = func(c *yts.Client) (any, error) {
+			return c.UpcomingMoviesWithContext(context.Background())
+		}
+	)
+
+	tests := []struct {
+		name       string
+		methodName string
+		method     sectionMethod
+		handlerCfg testHTTPHandlerConfig
+		want       any
+		wantErr    error
+	}{
+		{
+			name:       "returns error when popular movies selector missing",
+			methodName: "Client.PopularDownloads",
+			method:     popularDownloads,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_popular.html"),
+			want:       (*yts.PopularDownloadsResponse)(nil),
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns popular movies when other sections are missing",
+			methodName: "Client.PopularDownloads",
+			method:     popularDownloads,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_latest.html"),
+			want: &yts.PopularDownloadsResponse{
+				Data: yts.PopularDownloadsData{Movies: okData.Popular},
+			},
+		},
+		{
+			name:       "returns error when latest torrents selector missing",
+			methodName: "Client.LatestTorrents",
+			method:     latestTorrents,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_latest.html"),
+			want:       (*yts.LatestTorrentsResponse)(nil),
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns latest torrents when other sections are missing",
+			methodName: "Client.LatestTorrents",
+			method:     latestTorrents,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_upcoming.html"),
+			want: &yts.LatestTorrentsResponse{
+				Data: yts.LatestTorrentsData{Movies: okData.Latest},
+			},
+		},
+		{
+			name:       "returns error when upcoming movies selector missing",
+			methodName: "Client.UpcomingMovies",
+			method:     upcomingMovies,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_upcoming.html"),
+			want:       (*yts.UpcomingMoviesResponse)(nil),
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       `returns error when scraped "Quality" is invalid`,
+			methodName: "Client.UpcomingMovies",
+			method:     upcomingMovies,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "invalid_quality.html"),
+			want:       (*yts.UpcomingMoviesResponse)(nil),
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns upcoming movies when other sections are missing",
+			methodName: "Client.UpcomingMovies",
+			method:     upcomingMovies,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_popular.html"),
+			want: &yts.UpcomingMoviesResponse{
+				Data: yts.UpcomingMoviesData{Movies: okData.Upcoming},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := createTestServer(t, tt.handlerCfg)
+			serverURL, _ := url.Parse(server.URL)
+			defer server.Close()
+
+			clientCfg := yts.DefaultClientConfig()
+			clientCfg.SiteURL = *serverURL
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := tt.method(c)
+			assertError(t, tt.methodName, err, tt.wantErr)
+			assertEqual(t, tt.methodName, got, tt.want)
+		})
+	}
+}
+
 func TestClient_ResolveMovieSlugToIDWithContext(t *testing.T) {
 	const (
 		methodName  = "Client.ResolveMovieSlugtoID"
@@ -969,6 +1255,261 @@ func TestClient_MovieDirectorWithContext(t *testing.T) {
 	}
 }
 
+func TestClient_MoviePageDetailsWithContext(t *testing.T) {
+	const (
+		methodName  = "Client.MoviePageDetails"
+		testdataDir = "movie_page_details"
+		movieSlug   = "oppenheimer-2023"
+		pattern     = "movies/oppenheimer-2023"
+	)
+
+	timedoutCtx, cancel := context.WithDeadline(
+		context.Background(), time.Now(),
+	)
+	defer cancel()
+
+	mockedOKResponse := &yts.MoviePageDetailsResponse{
+		Data: yts.MoviePageDetailsData{
+			Subtitles: []yts.SiteMovieSubtitle{
+				{Label: "Subtitles", URL: "https://yifysubtitles.ch/movie-imdb/tt15398776"},
+			},
+			Scores: yts.SiteMovieScores{
+				IMDbRating:    yts.Rating{Value: 8.3, Scale: 10},
+				IMDbURL:       "https://www.imdb.com/title/tt15398776/",
+				Tomatometer:   93,
+				AudienceScore: 91,
+			},
+			AlsoKnownAs: []string{
+				"Оппенгеймер",
+				"Oppenheimer: The Father of the Atomic Bomb",
+			},
+			SimilarMovies: []yts.SiteMovieBase{
+				{
+					Title:  "Killers of the Flower Moon",
+					Year:   2023,
+					Link:   "https://yts.mx/movies/killers-of-the-flower-moon-2023",
+					Image:  "https://img.yts.mx/assets/images/movies/killers_of_the_flower_moon_2023/medium-cover.jpg",
+					Genres: []yts.Genre{},
+					Slug:   "killers-of-the-flower-moon-2023",
+				},
+				{
+					Title:  "The Zone of Interest",
+					Year:   2023,
+					Link:   "https://yts.mx/movies/the-zone-of-interest-2023",
+					Image:  "https://img.yts.mx/assets/images/movies/the_zone_of_interest_2023/medium-cover.jpg",
+					Genres: []yts.Genre{},
+					Slug:   "the-zone-of-interest-2023",
+				},
+			},
+		},
+	}
+
+	mockedMissingMetadataResponse := &yts.MoviePageDetailsResponse{
+		Data: yts.MoviePageDetailsData{
+			Subtitles:     []yts.SiteMovieSubtitle{},
+			AlsoKnownAs:   []string{},
+			SimilarMovies: []yts.SiteMovieBase{},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		handlerCfg testHTTPHandlerConfig
+		ctx        context.Context
+		movieSlug  string
+		want       *yts.MoviePageDetailsResponse
+		wantErr    error
+	}{
+		{
+			name:      "returns error for empty movie slug",
+			ctx:       context.Background(),
+			movieSlug: "",
+			wantErr:   yts.ErrValidationFailure,
+		},
+		{
+			name:       "returns error when movie content selector missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_movie_content.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       `returns error when "Year" is missing from a similar movie`,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "invalid_similar_movie.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       `returns error when "Tomatometer" is not a percentage`,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "invalid_tomatometer.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns error when request context times out",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			ctx:        timedoutCtx,
+			movieSlug:  movieSlug,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:       "returns error when response status is outside 2.x.x range",
+			handlerCfg: handlerConfigWithStatusCode(t, pattern, http.StatusNotFound),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:       "returns empty metadata when page has none",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_metadata.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			want:       mockedMissingMetadataResponse,
+		},
+		{
+			name:       "returns mocked ok response when scraping succeeds",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			want:       mockedOKResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := yts.DefaultClientConfig()
+			if tt.handlerCfg.pattern != "" {
+				server := createTestServer(t, tt.handlerCfg)
+				serverURL, _ := url.Parse(server.URL)
+				clientCfg.SiteURL = *serverURL
+				defer server.Close()
+			}
+
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.MoviePageDetailsWithContext(tt.ctx, tt.movieSlug)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
+func TestClient_MovieCrewWithContext(t *testing.T) {
+	const (
+		methodName  = "Client.MovieCrew"
+		testdataDir = "movie_crew"
+		movieSlug   = "no-country-for-old-men-2007"
+		pattern     = "movies/no-country-for-old-men-2007"
+		thumbURL    = "https://img.yts.mx/assets/images/actors/thumb/"
+	)
+
+	timedoutCtx, cancel := context.WithDeadline(
+		context.Background(), time.Now(),
+	)
+	defer cancel()
+
+	mockedOKResponse := &yts.MovieCrewResponse{
+		Data: yts.MovieCrewData{
+			Directors: []yts.SiteMoviePerson{
+				{Name: "Joel Coen", ImdbCode: "0001053", URLSmallImage: thumbURL + "nm0001053.jpg"},
+				{Name: "Ethan Coen", ImdbCode: "0001054", URLSmallImage: thumbURL + "nm0001054.jpg"},
+			},
+			Cast: []yts.SiteMovieCastMember{
+				{
+					SiteMoviePerson: yts.SiteMoviePerson{
+						Name:          "Josh Brolin",
+						ImdbCode:      "0000982",
+						URLSmallImage: thumbURL + "nm0000982.jpg",
+					},
+					CharacterName: "Llewelyn Moss",
+				},
+				{
+					SiteMoviePerson: yts.SiteMoviePerson{
+						Name:          "Javier Bardem",
+						ImdbCode:      "0000849",
+						URLSmallImage: thumbURL + "nm0000849.jpg",
+					},
+					CharacterName: "Anton Chigurh",
+				},
+				{
+					SiteMoviePerson: yts.SiteMoviePerson{
+						Name:     "Tommy Lee Jones",
+						ImdbCode: "0000169",
+					},
+					CharacterName: "Ed Tom Bell",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		handlerCfg testHTTPHandlerConfig
+		ctx        context.Context
+		movieSlug  string
+		want       *yts.MovieCrewResponse
+		wantErr    error
+	}{
+		{
+			name:      "returns error for empty movie slug",
+			ctx:       context.Background(),
+			movieSlug: "",
+			wantErr:   yts.ErrValidationFailure,
+		},
+		{
+			name:       "returns error when crew selector missing",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_crew.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       `returns error when "Name" is missing from a cast member`,
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "missing_name.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:       "returns error when request context times out",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			ctx:        timedoutCtx,
+			movieSlug:  movieSlug,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:       "returns error when response status is outside 2.x.x range",
+			handlerCfg: handlerConfigWithStatusCode(t, pattern, http.StatusNotFound),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			wantErr:    yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:       "returns mocked ok response when scraping succeeds",
+			handlerCfg: defaultHandlerConfig(t, pattern, testdataDir, "ok_response.html"),
+			ctx:        context.Background(),
+			movieSlug:  movieSlug,
+			want:       mockedOKResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientCfg := yts.DefaultClientConfig()
+			if tt.handlerCfg.pattern != "" {
+				server := createTestServer(t, tt.handlerCfg)
+				serverURL, _ := url.Parse(server.URL)
+				clientCfg.SiteURL = *serverURL
+				defer server.Close()
+			}
+
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.MovieCrewWithContext(tt.ctx, tt.movieSlug)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
 func TestClient_MoviesReviewsWithContext(t *testing.T) {
 	const (
 		methodName  = "Client.MovieReviews"
@@ -987,19 +1528,19 @@ func TestClient_MoviesReviewsWithContext(t *testing.T) {
 			Reviews: []yts.SiteMovieReview{
 				{
 					Author:  "claszdsburrogato",
-					Rating:  "7 / 10",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
 					Title:   "title-one",
 					Content: "content-one",
 				},
 				{
 					Author:  "Bonobo13579",
-					Rating:  "7 / 10",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
 					Title:   "title-two",
 					Content: "content-two",
 				},
 				{
 					Author:  "MrDHWong",
-					Rating:  "10 / 10",
+					Rating:  yts.Rating{Value: 10, Scale: 10},
 					Title:   "title-three",
 					Content: "content-three",
 				},
@@ -1132,6 +1673,178 @@ func TestClient_MoviesReviewsWithContext(t *testing.T) {
 	}
 }
 
+func createAllMovieReviewsTestServer(t *testing.T, pageFilename, listingFilename string) *httptest.Server {
+	t.Helper()
+	const testdataDir = "testdata/all_movie_reviews"
+
+	var (
+		serveMux = &http.ServeMux{}
+		server   = httptest.NewServer(serveMux)
+	)
+
+	serveMux.HandleFunc("/movies/oppenheimer-2023", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := os.ReadFile(path.Join(testdataDir, pageFilename))
+		page = bytes.ReplaceAll(page, []byte("__SERVER_URL__"), []byte(server.URL))
+		_, _ = w.Write(page)
+	})
+
+	serveMux.HandleFunc("/title/tt15398776/reviews", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path.Join(testdataDir, listingFilename))
+	})
+
+	serveMux.HandleFunc("/title/tt15398776/reviews/_ajax", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("paginationKey") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.ServeFile(w, r, path.Join(testdataDir, "reviews_page_two.html"))
+	})
+
+	return server
+}
+
+func TestClient_AllMovieReviewsWithContext(t *testing.T) {
+	const (
+		methodName = "Client.AllMovieReviews"
+		movieSlug  = "oppenheimer-2023"
+	)
+
+	timedoutCtx, cancel := context.WithDeadline(
+		context.Background(), time.Now(),
+	)
+	defer cancel()
+
+	mockedOKResponse := &yts.AllMovieReviewsResponse{
+		Data: yts.AllMovieReviewsData{
+			Reviews: []yts.SiteMovieReview{
+				{
+					ID:           "rw9164410",
+					Author:       "claszdsburrogato",
+					Title:        "title-one",
+					Content:      "content-one",
+					Rating:       yts.Rating{Value: 7, Scale: 10},
+					Date:         "22 July 2023",
+					HelpfulCount: 1234,
+					HelpfulTotal: 1580,
+				},
+				{
+					ID:           "rw9164411",
+					Author:       "Bonobo13579",
+					Title:        "title-two",
+					Content:      "content-two",
+					Date:         "23 July 2023",
+					HelpfulCount: 12,
+					HelpfulTotal: 20,
+				},
+				{
+					ID:      "rw9164412",
+					Author:  "MrDHWong",
+					Title:   "title-three",
+					Content: "content-three",
+					Rating:  yts.Rating{Value: 10, Scale: 10},
+					Date:    "24 July 2023",
+				},
+			},
+		},
+	}
+
+	mockedPageResponse := &yts.AllMovieReviewsResponse{
+		Data: yts.AllMovieReviewsData{
+			Reviews: []yts.SiteMovieReview{
+				{
+					Author:  "claszdsburrogato",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
+					Title:   "title-one",
+					Content: "content-one",
+				},
+				{
+					Author:  "Bonobo13579",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
+					Title:   "title-two",
+					Content: "content-two",
+				},
+				{
+					Author:  "MrDHWong",
+					Rating:  yts.Rating{Value: 10, Scale: 10},
+					Title:   "title-three",
+					Content: "content-three",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		pageFilename    string
+		listingFilename string
+		ctx             context.Context
+		movieSlug       string
+		want            *yts.AllMovieReviewsResponse
+		wantErr         error
+	}{
+		{
+			name:      "returns error for empty movie slug",
+			ctx:       context.Background(),
+			movieSlug: "",
+			wantErr:   yts.ErrValidationFailure,
+		},
+		{
+			name:            "returns error when request context times out",
+			listingFilename: "reviews_page_one.html",
+			ctx:             timedoutCtx,
+			movieSlug:       movieSlug,
+			wantErr:         context.DeadlineExceeded,
+		},
+		{
+			name:            "returns error when movie page is not found",
+			listingFilename: "reviews_page_one.html",
+			ctx:             context.Background(),
+			movieSlug:       "non-existent-movie",
+			wantErr:         yts.ErrUnexpectedHTTPResponseStatus,
+		},
+		{
+			name:            "returns error when validation for scraped listing reviews fail",
+			listingFilename: "invalid_reviews_page.html",
+			ctx:             context.Background(),
+			movieSlug:       movieSlug,
+			wantErr:         yts.ErrContentRetrievalFailure,
+		},
+		{
+			name:         "returns movie page reviews when more reviews link is empty",
+			pageFilename: "missing_reviews_more_url.html",
+			ctx:          context.Background(),
+			movieSlug:    movieSlug,
+			want:         mockedPageResponse,
+		},
+		{
+			name:            "returns deduplicated reviews across all listing pages",
+			listingFilename: "reviews_page_one.html",
+			ctx:             context.Background(),
+			movieSlug:       movieSlug,
+			want:            mockedOKResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pageFilename := tt.pageFilename
+			if pageFilename == "" {
+				pageFilename = "movie_page.html"
+			}
+
+			server := createAllMovieReviewsTestServer(t, pageFilename, tt.listingFilename)
+			serverURL, _ := url.Parse(server.URL)
+			defer server.Close()
+
+			clientCfg := yts.DefaultClientConfig()
+			clientCfg.SiteURL = *serverURL
+			c, _ := yts.NewClientWithConfig(&clientCfg)
+			got, err := c.AllMovieReviewsWithContext(tt.ctx, tt.movieSlug)
+			assertError(t, methodName, err, tt.wantErr)
+			assertEqual(t, methodName, got, tt.want)
+		})
+	}
+}
+
 func TestClient_MovieCommentsWithContext(t *testing.T) {
 	const (
 		methodName           = "Client.MovieComments"
@@ -1161,25 +1874,34 @@ func TestClient_MovieCommentsWithContext(t *testing.T) {
 				CommentsMore: more,
 				Comments: []yts.SiteMovieComment{
 					{
-						Author:    "aaron2023",
-						AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-						Timestamp: "April 30, 2024 at 09:46 am",
-						Content:   "content-one",
-						LikeCount: 0,
+						ID:               35774453,
+						Author:           "aaron2023",
+						AuthorProfileURL: "https://yts.mx/user/aaron2023",
+						AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+						Timestamp:        "April 30, 2024 at 09:46 am",
+						PostedAt:         time.Date(2024, 4, 30, 9, 46, 0, 0, time.Local),
+						Content:          "content-one",
+						LikeCount:        0,
 					},
 					{
-						Author:    "AmanS666",
-						AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-						Timestamp: "January 29, 2024 at 09:13 am",
-						Content:   "content-two",
-						LikeCount: 1,
+						ID:               35757878,
+						Author:           "AmanS666",
+						AuthorProfileURL: "https://yts.mx/user/amans666",
+						AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+						Timestamp:        "January 29, 2024 at 09:13 am",
+						PostedAt:         time.Date(2024, 1, 29, 9, 13, 0, 0, time.Local),
+						Content:          "content-two",
+						LikeCount:        1,
 					},
 					{
-						Author:    "zorg2",
-						AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-						Timestamp: "January 19, 2024 at 10:44 am",
-						Content:   "content-three",
-						LikeCount: 0,
+						ID:               35755966,
+						Author:           "zorg2",
+						AuthorProfileURL: "https://yts.mx/user/zorg2",
+						AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+						Timestamp:        "January 19, 2024 at 10:44 am",
+						PostedAt:         time.Date(2024, 1, 19, 10, 44, 0, 0, time.Local),
+						Content:          "content-three",
+						LikeCount:        0,
 					},
 				},
 			},
@@ -1370,43 +2092,52 @@ func TestClient_MovieAdditionalDetailsWithContext(t *testing.T) {
 			},
 			Comments: []yts.SiteMovieComment{
 				{
-					Author:    "aaron2023",
-					AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-					Timestamp: "April 30, 2024 at 09:46 am",
-					Content:   "content-one",
-					LikeCount: 0,
+					ID:               35774453,
+					Author:           "aaron2023",
+					AuthorProfileURL: "https://yts.mx/user/aaron2023",
+					AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+					Timestamp:        "April 30, 2024 at 09:46 am",
+					PostedAt:         time.Date(2024, 4, 30, 9, 46, 0, 0, time.Local),
+					Content:          "content-one",
+					LikeCount:        0,
 				},
 				{
-					Author:    "AmanS666",
-					AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-					Timestamp: "January 29, 2024 at 09:13 am",
-					Content:   "content-two",
-					LikeCount: 1,
+					ID:               35757878,
+					Author:           "AmanS666",
+					AuthorProfileURL: "https://yts.mx/user/amans666",
+					AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+					Timestamp:        "January 29, 2024 at 09:13 am",
+					PostedAt:         time.Date(2024, 1, 29, 9, 13, 0, 0, time.Local),
+					Content:          "content-two",
+					LikeCount:        1,
 				},
 				{
-					Author:    "zorg2",
-					AvatarURL: "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
-					Timestamp: "January 19, 2024 at 10:44 am",
-					Content:   "content-three",
-					LikeCount: 0,
+					ID:               35755966,
+					Author:           "zorg2",
+					AuthorProfileURL: "https://yts.mx/user/zorg2",
+					AvatarURL:        "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg",
+					Timestamp:        "January 19, 2024 at 10:44 am",
+					PostedAt:         time.Date(2024, 1, 19, 10, 44, 0, 0, time.Local),
+					Content:          "content-three",
+					LikeCount:        0,
 				},
 			},
 			Reviews: []yts.SiteMovieReview{
 				{
 					Author:  "claszdsburrogato",
-					Rating:  "7 / 10",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
 					Title:   "title-one",
 					Content: "content-one",
 				},
 				{
 					Author:  "Bonobo13579",
-					Rating:  "7 / 10",
+					Rating:  yts.Rating{Value: 7, Scale: 10},
 					Title:   "title-two",
 					Content: "content-two",
 				},
 				{
 					Author:  "MrDHWong",
-					Rating:  "10 / 10",
+					Rating:  yts.Rating{Value: 10, Scale: 10},
 					Title:   "title-three",
 					Content: "content-three",
 				},
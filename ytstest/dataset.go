@@ -0,0 +1,189 @@
+package ytstest
+
+import (
+	"fmt"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// A Movie is a movie of a Dataset, the MovieDetails are served by the API endpoints
+// while the remaining fields are rendered into the movie page of the movie.
+type Movie struct {
+	yts.MovieDetails
+
+	// The summary served for the movie by the list_movies.json endpoint.
+	Summary string
+
+	// The director shown on the movie page, the movie page lacks the directors
+	// section when the Name of the director is empty.
+	Director yts.SiteMovieDirector
+
+	// The reviews shown on the movie page along with the link to every review, the
+	// movie page lacks the reviews section when Reviews is empty.
+	Reviews         []yts.SiteMovieReview
+	ReviewsMoreLink string
+
+	// The comments of the movie served by the comments endpoint, the Timestamp of a
+	// comment is derived from its PostedAt time when empty.
+	Comments []yts.SiteMovieComment
+
+	// The IDs of the movies served by the movie_suggestions.json endpoint.
+	Suggestions []int
+}
+
+// A Dataset holds the movies served by a Server.
+type Dataset struct {
+	Movies []Movie
+
+	// The slugs of the movies shown on the trending movies page.
+	Trending []string
+}
+
+func (d *Dataset) movieByID(id int) (*Movie, bool) {
+	for i := range d.Movies {
+		if d.Movies[i].ID == id {
+			return &d.Movies[i], true
+		}
+	}
+	return nil, false
+}
+
+func (d *Dataset) movieBySlug(slug string) (*Movie, bool) {
+	for i := range d.Movies {
+		if d.Movies[i].Slug == slug {
+			return &d.Movies[i], true
+		}
+	}
+	return nil, false
+}
+
+func newTorrent(hash string, quality yts.Quality, torrentType, size string, seeds, peers int) yts.Torrent {
+	return yts.Torrent{
+		URL:              "https://yts.mx/torrent/download/" + hash,
+		Hash:             hash,
+		Quality:          quality,
+		Type:             torrentType,
+		IsRepack:         "0",
+		VideoCodec:       "x264",
+		BitDepth:         "8",
+		AudioChannels:    "5.1",
+		Seeds:            seeds,
+		Peers:            peers,
+		Size:             size,
+		DateUploaded:     "2023-11-20 21:19:58",
+		DateUploadedUnix: 1700511598,
+	}
+}
+
+func newDatasetMovie(id int, slug, title string, year int, rating float64, imdbCode string,
+	genres []yts.Genre, torrents []yts.Torrent) Movie {
+	const imageURL = "https://img.yts.mx/assets/images/movies/"
+
+	movie := Movie{}
+	movie.MoviePartial = yts.MoviePartial{
+		ID:                      id,
+		URL:                     "https://yts.mx/movies/" + slug,
+		ImdbCode:                imdbCode,
+		Title:                   title,
+		TitleEnglish:            title,
+		TitleLong:               fmt.Sprintf("%s (%d)", title, year),
+		Slug:                    slug,
+		Year:                    year,
+		Rating:                  rating,
+		Runtime:                 120,
+		Genres:                  genres,
+		DescriptionFull:         "The description of " + title + ".",
+		Language:                "en",
+		MpaRating:               "R",
+		BackgroundImage:         imageURL + slug + "/background.jpg",
+		BackgroundImageOriginal: imageURL + slug + "/background.jpg",
+		SmallCoverImage:         imageURL + slug + "/small-cover.jpg",
+		MediumCoverImage:        imageURL + slug + "/medium-cover.jpg",
+		LargeCoverImage:         imageURL + slug + "/large-cover.jpg",
+		Torrents:                torrents,
+		DateUploaded:            "2023-11-20 21:19:58",
+		DateUploadedUnix:        1700511598 + id,
+	}
+	movie.DescriptionIntro = movie.DescriptionFull
+	movie.Summary = movie.DescriptionFull
+	return movie
+}
+
+// DefaultDataset returns a *Dataset of three movies, Oppenheimer, The Dark Knight
+// and Superbad, each with torrents, a director, reviews and comments, the first two
+// being trending and suggested for one another.
+func DefaultDataset() *Dataset {
+	oppenheimer := newDatasetMovie(57427, "oppenheimer-2023", "Oppenheimer", 2023, 8.4, "tt15398776",
+		[]yts.Genre{yts.GenreBiography, yts.GenreDrama, yts.GenreHistory},
+		[]yts.Torrent{
+			newTorrent("8A2B1A7B8C8B0E3F2F4C2D34D5F6A7B8C9D0E1F2", yts.Quality720p, "bluray", "1.66 GB", 1200, 310),
+			newTorrent("1F2E3D4C5B6A79808F7E6D5C4B3A291807F6E5D4", yts.Quality1080p, "bluray", "3.39 GB", 2100, 640),
+		})
+	oppenheimer.LikeCount = 213
+	oppenheimer.Cast = []yts.Cast{
+		{Name: "Cillian Murphy", CharacterName: "J. Robert Oppenheimer", ImdbCode: "0614165"},
+		{Name: "Emily Blunt", CharacterName: "Kitty Oppenheimer", ImdbCode: "1289434"},
+	}
+	oppenheimer.Director = yts.SiteMovieDirector{
+		Name:          "Christopher Nolan",
+		URLSmallImage: "https://img.yts.mx/assets/images/actors/thumb/nm0634240.jpg",
+	}
+	oppenheimer.Reviews = []yts.SiteMovieReview{
+		{Author: "claszdsburrogato", Title: "A towering achievement", Content: "Review content.", Rating: yts.Rating{Value: 9, Scale: 10}},
+		{Author: "MrDHWong", Title: "A masterpiece", Content: "Review content.", Rating: yts.Rating{Value: 10, Scale: 10}},
+	}
+	oppenheimer.ReviewsMoreLink = "https://www.imdb.com/title/tt15398776/reviews"
+	oppenheimer.Comments = []yts.SiteMovieComment{
+		{ID: 35774453, Author: "aaron2023", Content: "Great movie.", LikeCount: 3,
+			PostedAt: time.Date(2024, 4, 30, 9, 46, 0, 0, time.UTC)},
+		{ID: 35757878, Author: "AmanS666", Content: "Thanks for the upload.", LikeCount: 1,
+			PostedAt: time.Date(2024, 1, 29, 9, 13, 0, 0, time.UTC)},
+	}
+	oppenheimer.Suggestions = []int{4171}
+
+	darkKnight := newDatasetMovie(4171, "the-dark-knight-2008", "The Dark Knight", 2008, 9.0, "tt0468569",
+		[]yts.Genre{yts.GenreAction, yts.GenreCrime, yts.GenreDrama},
+		[]yts.Torrent{
+			newTorrent("9C3E4F5A6B7C8D9E0F1A2B3C4D5E6F7A8B9C0D1E", yts.Quality1080p, "bluray", "1.71 GB", 980, 120),
+			newTorrent("0D1E2F3A4B5C6D7E8F9A0B1C2D3E4F5A6B7C8D9E", yts.Quality2160p, "bluray", "6.02 GB", 450, 80),
+		})
+	darkKnight.LikeCount = 1520
+	darkKnight.Cast = []yts.Cast{
+		{Name: "Christian Bale", CharacterName: "Bruce Wayne", ImdbCode: "0000288"},
+		{Name: "Heath Ledger", CharacterName: "Joker", ImdbCode: "0005132"},
+	}
+	darkKnight.Director = oppenheimer.Director
+	darkKnight.Reviews = []yts.SiteMovieReview{
+		{Author: "tmdb28039023", Title: "Why so serious?", Content: "Review content.", Rating: yts.Rating{Value: 10, Scale: 10}},
+	}
+	darkKnight.ReviewsMoreLink = "https://www.imdb.com/title/tt0468569/reviews"
+	darkKnight.Comments = []yts.SiteMovieComment{
+		{ID: 35711111, Author: "gotham", Content: "Best superhero movie.", LikeCount: 12,
+			PostedAt: time.Date(2023, 12, 24, 18, 30, 0, 0, time.UTC)},
+	}
+	darkKnight.Suggestions = []int{57427}
+
+	superbad := newDatasetMovie(1675, "superbad-2007", "Superbad", 2007, 7.6, "tt0829482",
+		[]yts.Genre{yts.GenreComedy},
+		[]yts.Torrent{
+			newTorrent("2E3F4A5B6C7D8E9F0A1B2C3D4E5F6A7B8C9D0E1F", yts.Quality720p, "bluray", "850.42 MB", 310, 45),
+		})
+	superbad.LikeCount = 340
+	superbad.Cast = []yts.Cast{
+		{Name: "Jonah Hill", CharacterName: "Seth", ImdbCode: "1706767"},
+	}
+	superbad.Director = yts.SiteMovieDirector{
+		Name:          "Greg Mottola",
+		URLSmallImage: "https://img.yts.mx/assets/images/actors/thumb/nm0609236.jpg",
+	}
+	superbad.Reviews = []yts.SiteMovieReview{
+		{Author: "moviefan", Title: "Still hilarious", Content: "Review content.", Rating: yts.Rating{Value: 8, Scale: 10}},
+	}
+	superbad.ReviewsMoreLink = "https://www.imdb.com/title/tt0829482/reviews"
+
+	return &Dataset{
+		Movies:   []Movie{oppenheimer, darkKnight, superbad},
+		Trending: []string{"oppenheimer-2023", "the-dark-knight-2008"},
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Package ytstest provides a fake YTS API and website for testing code built on a
+yts.Client without network access, much like net/http/httptest does for HTTP
+servers.
+
+	s := ytstest.NewServer(ytstest.DefaultDataset())
+	defer s.Close()
+
+	client := s.Client()
+	response, err := client.SearchMovies(yts.DefaultSearchMoviesFilters("oppenheimer"))
+
+A Server serves the movies of its Dataset from the following endpoints, every other
+path responds with status 404.
+
+	/api/v2/list_movies.json         the query_term, limit, page, quality, genre,
+	                                 minimum_rating, sort_by and order_by filters
+	/api/v2/movie_details.json       honoring with_cast and with_images
+	/api/v2/movie_suggestions.json   the Suggestions of the movie
+	/movies/{slug}                   the movie page, with the director, cast,
+	                                 reviews and comment count of the movie
+	/ajax/comments/{id}?offset=      the comments of the movie, 30 at a time
+	/trending-movies                 the Trending movies of the Dataset
+
+The movie page of a movie therefore supports the MovieDirector, MovieCrew,
+MovieReviews, MovieComments, MovieAdditionalDetails and ResolveMovieSlugToID methods
+of the client, as well as TrendingMovies for the trending movies page.
+
+Faults are injected per path with SetFault, delaying responses, responding with an
+unsuccessful status code or with a malformed body, which the client reports as
+ErrUnexpectedHTTPResponseStatus and ErrContentRetrievalFailure respectively.
+
+	s.SetFault(ytstest.MoviePagePath, ytstest.Fault{Malformed: true})
+	s.SetFault("/", ytstest.Fault{Latency: time.Second})
+
+The Requests method reports the number of requests received for a path, for
+asserting that a response was served from a cache for instance.
+*/
+package ytstest
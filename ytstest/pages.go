@@ -0,0 +1,193 @@
+package ytstest
+
+import (
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// commentTimestampLayout is the layout of the absolute comment timestamps shown by
+// the YTS website, such as "April 30, 2024 at 09:46 am".
+const commentTimestampLayout = "January 2, 2006 at 03:04 pm"
+
+const defaultAvatarURL = "https://img.yts.mx/assets/images/users/thumb/default_avatar.jpg"
+
+// malformedHTML is served in place of a page by a Fault with Malformed set, it is
+// truncated and lacks every element scraped by the yts.Client.
+const malformedHTML = `<div class="main-content"><div class="container" id="movie-`
+
+var templateFuncs = template.FuncMap{
+	"imagePath": func(imageURL string) string {
+		parsed, err := url.Parse(imageURL)
+		if err != nil {
+			return imageURL
+		}
+		return parsed.Path
+	},
+	"profileURL": func(c yts.SiteMovieComment) string {
+		if c.AuthorProfileURL != "" {
+			return c.AuthorProfileURL
+		}
+		return "https://yts.mx/user/" + strings.ToLower(c.Author)
+	},
+	"avatarURL": func(c yts.SiteMovieComment) string {
+		if c.AvatarURL != "" {
+			return c.AvatarURL
+		}
+		return defaultAvatarURL
+	},
+	"timestamp": func(c yts.SiteMovieComment) string {
+		if c.Timestamp != "" {
+			return c.Timestamp
+		}
+		return c.PostedAt.Format(commentTimestampLayout)
+	},
+	"rating": func(rating float64) string {
+		return fmt.Sprintf("%.1f / 10", rating)
+	},
+}
+
+var moviePageTemplate = template.Must(template.New("movie").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.TitleLong}} YIFY - Download Movie TORRENT - YTS</title></head>
+<body>
+<div class="main-content">
+ <div class="container" id="movie-content" itemscope itemtype="http://schema.org/Movie">
+  <div class="row">
+   <div id="movie-info" data-movie-id="{{.ID}}">
+    <h1 itemprop="name">{{.Title}}</h1>
+    <h2>{{.Year}}</h2>
+   </div>
+  </div>
+  <div id="movie-sub-info" class="row">
+   <div id="crew">
+    {{- with .Director}}{{if .Name}}
+    <div class="directors">
+     <h3>Director</h3>
+     <div class="list-cast">
+      <div class="tableCell">
+       <a class="avatar-thumb" href="https://www.imdb.com/name/" target="_blank">
+        <img src="{{.URLSmallImage}}" alt="{{.Name}} Photo">
+       </a>
+      </div>
+      <div class="list-cast-info tableCell">
+       <a class="name-cast" href="https://yts.mx/browse-movies/{{.Name}}">
+        <span itemprop="director" itemscope itemtype="http://schema.org/Person">
+         <span itemprop="name">{{.Name}}</span>
+        </span>
+       </a>
+      </div>
+     </div>
+    </div>
+    {{- end}}{{end}}
+    {{- if .Cast}}
+    <div class="actors">
+     <h3>Top cast</h3>
+     {{- range .Cast}}
+     <div class="list-cast">
+      <div class="tableCell">
+       <a class="avatar-thumb" href="https://www.imdb.com/name/nm{{.ImdbCode}}/" target="_blank">
+        {{- with .URLSmallImage}}<img src="{{.}}">{{end -}}
+       </a>
+      </div>
+      <div class="list-cast-info tableCell">
+       <a class="name-cast" href="https://yts.mx/browse-movies/{{.Name}}">
+        <span itemprop="actor" itemscope itemtype="http://schema.org/Person">
+         <span itemprop="name">{{.Name}}</span>
+        </span>
+       </a> as {{.CharacterName}}
+      </div>
+     </div>
+     {{- end}}
+    </div>
+    {{- end}}
+   </div>
+  </div>
+  <div id="movie-bottom" class="row">
+   <div id="movie-comments">
+    <h3><span class="icon-comment"></span> <span id="comment-count">{{len .Comments}}</span> Comments</h3>
+   </div>
+   {{- if .Reviews}}
+   <div id="movie-reviews">
+    <h3><span class="icon-star"></span> Movie Reviews</h3>
+    {{- range .Reviews}}
+    <div class="review">
+     <div class="review-properties">
+      Reviewed by <span class="review-author">{{.Author}}</span>
+      <span class="icon-star"></span> <span class="review-rating">{{.Rating}}</span>
+     </div>
+     <h4>{{.Title}}</h4>
+     <article><p>{{.Content}}</p></article>
+    </div>
+    {{- end}}
+    <a class="more-reviews" href="{{.ReviewsMoreLink}}" target="_blank">Read more IMDb reviews</a>
+   </div>
+   {{- end}}
+  </div>
+ </div>
+</div>
+</body>
+</html>
+`))
+
+var commentsTemplate = template.Must(template.New("comments").Funcs(templateFuncs).Parse(`
+{{- range .}}
+<div class="comment" data-comment-id="{{.ID}}">
+ <a title="View profile" href="{{profileURL .}}" class="avatar-thumb">
+  <img alt="{{.Author}} profile" src="{{avatarURL .}}">
+ </a>
+ <div class="comment-text">
+  <div class="pull-right comment-likes">
+   <span class="comment-like-count">{{.LikeCount}}</span>
+   <span title="Likes" class="icon icon-heart2"></span>
+  </div>
+  <span>
+   <a href="{{profileURL .}}">{{.Author}}</a>
+   {{timestamp .}}
+  </span>
+  <p>{{.Content}}</p>
+ </div>
+</div>
+{{- end}}
+`))
+
+var trendingTemplate = template.Must(template.New("trending").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head><title>Trending Movies - YTS</title></head>
+<body>
+<div class="main-content">
+ <div class="browse-content">
+  <div class="container">
+   <section>
+    <div class="row">
+     {{- range .}}
+     <div class="browse-movie-wrap col-xs-10 col-sm-4 col-md-5 col-lg-4">
+      <a class="browse-movie-link" href="{{.URL}}">
+       <figure>
+        <img class="img-responsive" src="{{imagePath .MediumCoverImage}}" alt="{{.TitleLong}} download">
+        <figcaption class="hidden-xs hidden-sm">
+         <span class="icon-star"></span>
+         <h4 class="rating">{{rating .Rating}}</h4>
+         {{- range .Genres}}
+         <h4>{{.}}</h4>
+         {{- end}}
+        </figcaption>
+       </figure>
+      </a>
+      <div class="browse-movie-bottom">
+       <a class="browse-movie-title" href="{{.URL}}">{{.Title}}</a>
+       <div class="browse-movie-year">{{.Year}}</div>
+      </div>
+     </div>
+     {{- end}}
+    </div>
+   </section>
+  </div>
+ </div>
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,481 @@
+package ytstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+)
+
+// The paths served by a Server, which can be provided to the SetFault and Requests
+// methods. Paths ending with a slash are followed by a movie slug or ID.
+const (
+	ListMoviesPath       = "/api/v2/list_movies.json"
+	MovieDetailsPath     = "/api/v2/movie_details.json"
+	MovieSuggestionsPath = "/api/v2/movie_suggestions.json"
+	MoviePagePath        = "/movies/"
+	CommentsPath         = "/ajax/comments/"
+	TrendingPath         = "/trending-movies"
+)
+
+// commentsPerPage is the number of comments served per request by the comments
+// endpoint, the offset query parameter selecting the first one.
+const commentsPerPage = 30
+
+// malformedJSON is served in place of a JSON response by a Fault with Malformed set.
+const malformedJSON = `{"status":"ok","status_message":"Query was successful","data":{"movie`
+
+// A Fault alters the responses of a Server for the path it is set for, its fields
+// are applied in order, a request first waits for the Latency and then receives
+// either the StatusCode or a malformed response.
+type Fault struct {
+	// The duration a request waits before being responded to, the request fails
+	// early if its context is done.
+	Latency time.Duration
+
+	// The status code responded with along with an empty body, ignored when zero.
+	StatusCode int
+
+	// Whether to respond with a truncated body, which fails to decode for the API
+	// endpoints and lacks every scraped element for the pages of the website.
+	Malformed bool
+}
+
+// A Server is a fake YTS API and website serving the movies of a Dataset, allowing
+// a yts.Client to be tested without network access.
+type Server struct {
+	// The base URL of the server, of the form http://ipaddr:port with no trailing
+	// slash.
+	URL string
+
+	server  *httptest.Server
+	dataset *Dataset
+
+	mu       sync.Mutex
+	faults   map[string]Fault
+	requests map[string]int
+}
+
+// NewServer starts a *Server serving the provided dataset, DefaultDataset() is used
+// when dataset is nil. The caller should call Close when finished to shut it down.
+func NewServer(dataset *Dataset) *Server {
+	if dataset == nil {
+		dataset = DefaultDataset()
+	}
+
+	s := &Server{
+		dataset:  dataset,
+		faults:   make(map[string]Fault),
+		requests: make(map[string]int),
+	}
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests on this
+// server have completed.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ClientConfig returns the yts.DefaultClientConfig() with the API, site and image
+// URLs pointing at the server.
+func (s *Server) ClientConfig() yts.ClientConfig {
+	serverURL, _ := url.Parse(s.URL)
+	apiURL := serverURL.JoinPath("api", "v2")
+
+	config := yts.DefaultClientConfig()
+	config.APIBaseURL = *apiURL
+	config.SiteURL = *serverURL
+	config.SiteImageSubDomainURL = *serverURL
+	return config
+}
+
+// Client returns a new *yts.Client created with the ClientConfig of the server.
+func (s *Server) Client() *yts.Client {
+	config := s.ClientConfig()
+	client, _ := yts.NewClientWithConfig(&config)
+	return client
+}
+
+// matchesPath reports whether the path pattern used with SetFault or Requests
+// matches the requested path, patterns ending with a slash match every path they
+// prefix.
+func matchesPath(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(path, pattern)
+	}
+	return pattern == path
+}
+
+// SetFault sets the fault applied to the requests for path, replacing any fault
+// previously set for it. A path ending with a slash applies the fault to every path
+// it prefixes, "/" thus applies it to every request.
+func (s *Server) SetFault(path string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[path] = fault
+}
+
+// ClearFaults removes every fault set with SetFault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[string]Fault)
+}
+
+// Requests returns the number of requests received for path, which is matched the
+// same way as by SetFault.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for requested, n := range s.requests {
+		if matchesPath(path, requested) {
+			count += n
+		}
+	}
+	return count
+}
+
+// fault records the request for path and returns the fault set for it, the fault
+// set for the longest matching path is used when several match.
+func (s *Server) fault(path string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[path]++
+
+	var (
+		fault   Fault
+		matched = ""
+		found   = false
+	)
+
+	for pattern, f := range s.faults {
+		if matchesPath(pattern, path) && len(matched) <= len(pattern) {
+			fault, matched, found = f, pattern, true
+		}
+	}
+	return fault, found
+}
+
+// ServeHTTP implements the http.Handler interface, see the package documentation
+// for the endpoints served.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		path     = r.URL.Path
+		isAPI    = strings.HasPrefix(path, "/api/")
+		malforms = false
+	)
+
+	if fault, ok := s.fault(path); ok {
+		if 0 < fault.Latency {
+			timer := time.NewTimer(fault.Latency)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault.StatusCode != 0 {
+			w.WriteHeader(fault.StatusCode)
+			return
+		}
+		malforms = fault.Malformed
+	}
+
+	if malforms {
+		body, contentType := malformedHTML, "text/html; charset=utf-8"
+		if isAPI {
+			body, contentType = malformedJSON, "application/json"
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+		return
+	}
+
+	switch {
+	case path == ListMoviesPath:
+		s.serveListMovies(w, r)
+	case path == MovieDetailsPath:
+		s.serveMovieDetails(w, r)
+	case path == MovieSuggestionsPath:
+		s.serveMovieSuggestions(w, r)
+	case path == TrendingPath:
+		s.serveTrending(w)
+	case strings.HasPrefix(path, MoviePagePath):
+		s.serveMoviePage(w, strings.TrimPrefix(path, MoviePagePath))
+	case strings.HasPrefix(path, CommentsPath):
+		s.serveComments(w, r, strings.TrimPrefix(path, CommentsPath))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, data any) {
+	type response struct {
+		yts.BaseResponse
+		Data any `json:"data"`
+	}
+
+	meta := yts.Meta{
+		ServerTime:     int(time.Now().Unix()),
+		ServerTimezone: "CET",
+		APIVersion:     2,
+		ExecutionTime:  "0 ms",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response{
+		BaseResponse: yts.BaseResponse{Status: "ok", StatusMessage: "Query was successful", Meta: meta},
+		Data:         data,
+	})
+}
+
+func writeHTML(w http.ResponseWriter, t *template.Template, data any) {
+	buffer := &bytes.Buffer{}
+	if err := t.Execute(buffer, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = buffer.WriteTo(w)
+}
+
+func (m *Movie) listMovie() yts.Movie {
+	return yts.Movie{MoviePartial: m.MoviePartial, Summary: m.Summary, Synopsis: m.Summary, State: "ok"}
+}
+
+// matchesQuery reports whether the movie matches the query_term of a search, which
+// YTS matches against the title, IMDb code, cast and director of movies.
+func (m *Movie) matchesQuery(term string) bool {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" || strings.EqualFold(m.ImdbCode, term) || strings.Contains(strings.ToLower(m.Title), term) {
+		return true
+	}
+
+	if strings.Contains(strings.ToLower(m.Director.Name), term) {
+		return true
+	}
+
+	for _, cast := range m.Cast {
+		if strings.Contains(strings.ToLower(cast.Name), term) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Movie) hasQuality(quality string) bool {
+	if quality == "" || quality == string(yts.QualityAll) {
+		return true
+	}
+
+	for _, torrent := range m.Torrents {
+		if string(torrent.Quality) == quality {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Movie) hasGenre(genre string) bool {
+	if genre == "" || strings.EqualFold(genre, string(yts.GenreAll)) {
+		return true
+	}
+
+	for _, g := range m.Genres {
+		if strings.EqualFold(string(g), genre) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKey returns the value movies are sorted by for the sort_by query parameter.
+func (m *Movie) sortKey(sortBy string) any {
+	var seeds, peers int
+	for _, torrent := range m.Torrents {
+		seeds, peers = seeds+torrent.Seeds, peers+torrent.Peers
+	}
+
+	switch yts.SortBy(sortBy) {
+	case yts.SortByTitle:
+		return strings.ToLower(m.Title)
+	case yts.SortByYear:
+		return m.Year
+	case yts.SortByRating:
+		return m.Rating
+	case yts.SortBySeeds:
+		return seeds
+	case yts.SortByPeers:
+		return peers
+	case yts.SortByLikeCount, yts.SortByDownloadCount:
+		return m.LikeCount
+	default:
+		return m.DateUploadedUnix
+	}
+}
+
+func less(a, b any) bool {
+	switch a := a.(type) {
+	case string:
+		return a < b.(string)
+	case float64:
+		return a < b.(float64)
+	default:
+		return a.(int) < b.(int)
+	}
+}
+
+func queryInt(query url.Values, name string, fallback, lower, upper int) int {
+	value, err := strconv.Atoi(query.Get(name))
+	if err != nil || value < lower || upper < value {
+		return fallback
+	}
+	return value
+}
+
+func (s *Server) serveListMovies(w http.ResponseWriter, r *http.Request) {
+	const (
+		defaultLimit = 20
+		maxLimit     = 50
+	)
+
+	var (
+		query         = r.URL.Query()
+		limit         = queryInt(query, "limit", defaultLimit, 1, maxLimit)
+		page          = queryInt(query, "page", 1, 1, math.MaxInt)
+		minimumRating = queryInt(query, "minimum_rating", 0, 0, 9)
+		sortBy        = query.Get("sort_by")
+		ascending     = query.Get("order_by") == string(yts.OrderByAsc)
+		matches       = make([]*Movie, 0)
+	)
+
+	for i := range s.dataset.Movies {
+		movie := &s.dataset.Movies[i]
+		if movie.matchesQuery(query.Get("query_term")) &&
+			movie.hasQuality(query.Get("quality")) &&
+			movie.hasGenre(query.Get("genre")) &&
+			float64(minimumRating) <= movie.Rating {
+			matches = append(matches, movie)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i].sortKey(sortBy), matches[j].sortKey(sortBy)
+		if ascending {
+			return less(a, b)
+		}
+		return less(b, a)
+	})
+
+	data := yts.SearchMoviesData{MovieCount: len(matches), Limit: limit, PageNumber: page}
+	if start := (page - 1) * limit; start < len(matches) {
+		for _, movie := range matches[start:min(start+limit, len(matches))] {
+			data.Movies = append(data.Movies, movie.listMovie())
+		}
+	}
+
+	writeJSON(w, data)
+}
+
+// serveMovieDetails serves the details of the movie_id movie, like YTS a movie with
+// a zero ID is served when no movie has the requested ID.
+func (s *Server) serveMovieDetails(w http.ResponseWriter, r *http.Request) {
+	var (
+		query   = r.URL.Query()
+		id, _   = strconv.Atoi(query.Get("movie_id"))
+		details = yts.MovieDetails{}
+	)
+
+	if movie, ok := s.dataset.movieByID(id); ok {
+		details = movie.MovieDetails
+		if query.Get("with_cast") != "true" {
+			details.Cast = nil
+		}
+
+		if query.Get("with_images") != "true" {
+			details.MediumScreenshotImage1, details.LargeScreenshotImage1 = "", ""
+			details.MediumScreenshotImage2, details.LargeScreenshotImage2 = "", ""
+			details.MediumScreenshotImage3, details.LargeScreenshotImage3 = "", ""
+		}
+	}
+
+	writeJSON(w, yts.MovieDetailsData{Movie: details})
+}
+
+func (s *Server) serveMovieSuggestions(w http.ResponseWriter, r *http.Request) {
+	var (
+		id, _ = strconv.Atoi(r.URL.Query().Get("movie_id"))
+		data  = yts.MovieSuggestionsData{Movies: make([]yts.Movie, 0)}
+	)
+
+	if movie, ok := s.dataset.movieByID(id); ok {
+		for _, suggestionID := range movie.Suggestions {
+			if suggestion, ok := s.dataset.movieByID(suggestionID); ok {
+				data.Movies = append(data.Movies, suggestion.listMovie())
+			}
+		}
+	}
+
+	data.MovieCount = len(data.Movies)
+	writeJSON(w, data)
+}
+
+func (s *Server) serveMoviePage(w http.ResponseWriter, slug string) {
+	movie, ok := s.dataset.movieBySlug(slug)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	writeHTML(w, moviePageTemplate, movie)
+}
+
+func (s *Server) serveComments(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, _ := strconv.Atoi(rawID)
+	movie, ok := s.dataset.movieByID(id)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var (
+		offset   = queryInt(r.URL.Query(), "offset", 0, 0, math.MaxInt)
+		comments = make([]yts.SiteMovieComment, 0)
+	)
+
+	if offset < len(movie.Comments) {
+		comments = movie.Comments[offset:min(offset+commentsPerPage, len(movie.Comments))]
+	}
+
+	writeHTML(w, commentsTemplate, comments)
+}
+
+func (s *Server) serveTrending(w http.ResponseWriter) {
+	movies := make([]*Movie, 0, len(s.dataset.Trending))
+	for _, slug := range s.dataset.Trending {
+		if movie, ok := s.dataset.movieBySlug(slug); ok {
+			movies = append(movies, movie)
+		}
+	}
+
+	writeHTML(w, trendingTemplate, movies)
+}
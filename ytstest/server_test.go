@@ -0,0 +1,216 @@
+package ytstest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	yts "github.com/atifcppprogrammer/yflicks-yts"
+	"github.com/atifcppprogrammer/yflicks-yts/ytstest"
+)
+
+func assertEqual(t *testing.T, method string, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s() = %v, want %v", method, got, want)
+	}
+}
+
+func assertError(t *testing.T, method string, err, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) {
+		t.Errorf("%s() error = %v, wantErr %v", method, err, wantErr)
+	}
+}
+
+func newServer(t *testing.T, dataset *ytstest.Dataset) (*ytstest.Server, *yts.Client) {
+	t.Helper()
+	s := ytstest.NewServer(dataset)
+	t.Cleanup(s.Close)
+	return s, s.Client()
+}
+
+func TestServer_SearchMovies(t *testing.T) {
+	const methodName = "Client.SearchMovies"
+
+	_, client := newServer(t, nil)
+	titles := func(filters *yts.SearchMoviesFilters) []string {
+		t.Helper()
+		response, err := client.SearchMovies(filters)
+		assertError(t, methodName, err, nil)
+		if err != nil {
+			return nil
+		}
+
+		titles := make([]string, 0)
+		for _, movie := range response.Data.Movies {
+			titles = append(titles, movie.Title)
+		}
+		return titles
+	}
+
+	tests := []struct {
+		name       string
+		filters    func(f *yts.SearchMoviesFilters)
+		wantTitles []string
+	}{
+		{
+			name:       "returns movies by date added",
+			filters:    func(f *yts.SearchMoviesFilters) {},
+			wantTitles: []string{"Oppenheimer", "The Dark Knight", "Superbad"},
+		},
+		{
+			name:       "matches query term against titles, IMDb codes and cast",
+			filters:    func(f *yts.SearchMoviesFilters) { f.QueryTerm = "heath ledger" },
+			wantTitles: []string{"The Dark Knight"},
+		},
+		{
+			name: "filters by quality genre and rating",
+			filters: func(f *yts.SearchMoviesFilters) {
+				f.Quality, f.Genre, f.MinimumRating = yts.Quality1080p, yts.GenreDrama, 9
+			},
+			wantTitles: []string{"The Dark Knight"},
+		},
+		{
+			name: "sorts and paginates",
+			filters: func(f *yts.SearchMoviesFilters) {
+				f.SortBy, f.OrderBy, f.Limit, f.Page = yts.SortByTitle, yts.OrderByAsc, 2, 2
+			},
+			wantTitles: []string{"The Dark Knight"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters := yts.DefaultSearchMoviesFilters("")
+			tt.filters(filters)
+			assertEqual(t, methodName, titles(filters), tt.wantTitles)
+		})
+	}
+}
+
+func TestServer_MovieDetails(t *testing.T) {
+	const methodName = "Client.MovieDetails"
+
+	_, client := newServer(t, nil)
+	want := ytstest.DefaultDataset().Movies[0].MovieDetails
+
+	response, err := client.MovieDetails(want.ID, yts.DefaultMovieDetailsFilters())
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, response.Data.Movie, want)
+
+	response, _ = client.MovieDetails(want.ID, &yts.MovieDetailsFilters{})
+	assertEqual(t, methodName, len(response.Data.Movie.Cast), 0)
+
+	response, _ = client.MovieDetails(1, &yts.MovieDetailsFilters{})
+	assertEqual(t, methodName, response.Data.Movie.ID, 0)
+
+	suggestions, err := client.MovieSuggestions(want.ID)
+	assertError(t, "Client.MovieSuggestions", err, nil)
+	assertEqual(t, "Client.MovieSuggestions", suggestions.Data.Movies[0].Title, "The Dark Knight")
+}
+
+func TestServer_MoviePage(t *testing.T) {
+	const methodName = "Client.MovieAdditionalDetails"
+
+	var (
+		dataset     = ytstest.DefaultDataset()
+		movie       = dataset.Movies[0]
+		_, client   = newServer(t, dataset)
+		slug        = movie.Slug
+		reference   = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		wantAuthors = []string{"aaron2023", "AmanS666"}
+	)
+
+	response, err := client.MovieAdditionalDetails(slug)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, response.Data.Director, movie.Director)
+	assertEqual(t, methodName, response.Data.Reviews, movie.Reviews)
+	assertEqual(t, methodName, response.Data.ReviewsMoreLink, movie.ReviewsMoreLink)
+
+	authors := make([]string, 0)
+	for _, comment := range response.Data.Comments {
+		authors = append(authors, comment.Author)
+		postedAt, _ := yts.ParseCommentTimestamp(comment.Timestamp, reference)
+		if !postedAt.Equal(movie.Comments[len(authors)-1].PostedAt) {
+			t.Errorf("%s() comment timestamp = %s, want %s", methodName, comment.Timestamp, movie.Comments[len(authors)-1].PostedAt)
+		}
+	}
+	assertEqual(t, methodName, authors, wantAuthors)
+
+	crew, err := client.MovieCrew(slug)
+	assertError(t, "Client.MovieCrew", err, nil)
+	assertEqual(t, "Client.MovieCrew", crew.Data.Cast[0].ImdbCode, movie.Cast[0].ImdbCode)
+	assertEqual(t, "Client.MovieCrew", crew.Data.Cast[0].CharacterName, movie.Cast[0].CharacterName)
+
+	id, err := client.ResolveMovieSlugToID("superbad-2007")
+	assertError(t, "Client.ResolveMovieSlugToID", err, nil)
+	assertEqual(t, "Client.ResolveMovieSlugToID", id, 1675)
+
+	trending, err := client.TrendingMovies()
+	assertError(t, "Client.TrendingMovies", err, nil)
+	assertEqual(t, "Client.TrendingMovies", len(trending.Data.Movies), 2)
+	assertEqual(t, "Client.TrendingMovies", trending.Data.Movies[1].Slug, "the-dark-knight-2008")
+}
+
+func TestServer_MovieComments(t *testing.T) {
+	const methodName = "Client.MovieComments"
+
+	dataset := ytstest.DefaultDataset()
+	for i := 0; i < 35; i++ {
+		dataset.Movies[2].Comments = append(dataset.Movies[2].Comments, yts.SiteMovieComment{
+			ID:        i + 1,
+			Author:    fmt.Sprintf("user%d", i),
+			Content:   "content",
+			Timestamp: "1 year ago",
+		})
+	}
+	_, client := newServer(t, dataset)
+
+	response, err := client.MovieComments("superbad-2007", 1)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, len(response.Data.Comments), 30)
+	assertEqual(t, methodName, response.Data.CommentsMore, true)
+
+	response, err = client.MovieComments("superbad-2007", 2)
+	assertError(t, methodName, err, nil)
+	assertEqual(t, methodName, len(response.Data.Comments), 5)
+	assertEqual(t, methodName, response.Data.Comments[0].Author, "user30")
+	assertEqual(t, methodName, response.Data.CommentsMore, false)
+}
+
+func TestServer_SetFault(t *testing.T) {
+	const methodName = "Server.SetFault"
+
+	s, client := newServer(t, nil)
+
+	s.SetFault(ytstest.MovieDetailsPath, ytstest.Fault{StatusCode: 503})
+	_, err := client.MovieDetails(57427, &yts.MovieDetailsFilters{})
+	assertError(t, methodName, err, yts.ErrUnexpectedHTTPResponseStatus)
+
+	s.SetFault(ytstest.MoviePagePath, ytstest.Fault{Malformed: true})
+	_, err = client.MovieDirector("oppenheimer-2023")
+	assertError(t, methodName, err, yts.ErrContentRetrievalFailure)
+
+	s.SetFault(ytstest.ListMoviesPath, ytstest.Fault{Malformed: true})
+	_, err = client.SearchMovies(yts.DefaultSearchMoviesFilters(""))
+	if err == nil {
+		t.Errorf("%s() error = nil, want a decoding error", methodName)
+	}
+
+	s.SetFault("/", ytstest.Fault{Latency: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.TrendingMoviesWithContext(ctx)
+	assertError(t, methodName, err, context.DeadlineExceeded)
+
+	s.ClearFaults()
+	_, err = client.MovieDirector("oppenheimer-2023")
+	assertError(t, methodName, err, nil)
+
+	assertEqual(t, "Server.Requests", s.Requests(ytstest.MoviePagePath), 2)
+	assertEqual(t, "Server.Requests", s.Requests(ytstest.MovieDetailsPath), 1)
+	assertEqual(t, "Server.Requests", s.Requests("/"), 5)
+}